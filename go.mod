@@ -0,0 +1,3 @@
+module github.com/mtlynch/gofn-prosper
+
+go 1.24
@@ -0,0 +1,30 @@
+package interval
+
+import (
+	"time"
+
+	// Embed the time zone database so that Prosper's time zone loads even on
+	// systems without one.
+	_ "time/tzdata"
+)
+
+// prosperLocationName is the time zone in which Prosper operates and defines
+// its business dates.
+const prosperLocationName = "America/Los_Angeles"
+
+var defaultDateLocation = loadDefaultDateLocation()
+
+// DefaultDateLocation returns the location in which ParseRange interprets
+// date-only time bounds: America/Los_Angeles, where Prosper's business dates
+// are defined.
+func DefaultDateLocation() *time.Location {
+	return defaultDateLocation
+}
+
+func loadDefaultDateLocation() *time.Location {
+	loc, err := time.LoadLocation(prosperLocationName)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
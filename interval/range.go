@@ -3,106 +3,284 @@
 package interval
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"math"
 	"time"
 )
 
 type (
-	// Float64Range represents a range of numbers of type float64.
-	Float64Range struct {
-		Min *float64
-		Max *float64
+	// Value is the set of types that a Range can hold: the predeclared ordered
+	// types and time.Time.
+	Value interface {
+		int | int8 | int16 | int32 | int64 |
+			uint | uint8 | uint16 | uint32 | uint64 | uintptr |
+			float32 | float64 | string | time.Time
 	}
 
-	// Int32Range represents a range of numbers of type int32.
-	Int32Range struct {
-		Min *int32
-		Max *int32
+	// Range represents a range of values with an optional minimum and an
+	// optional maximum. A nil Min or Max leaves that side of the range
+	// unbounded. Bounds are inclusive unless MinExclusive or MaxExclusive is
+	// set.
+	Range[T Value] struct {
+		Min          *T
+		Max          *T
+		MinExclusive bool
+		MaxExclusive bool
 	}
 
+	// Float64Range represents a range of numbers of type float64.
+	Float64Range = Range[float64]
+
+	// Int32Range represents a range of numbers of type int32.
+	Int32Range = Range[int32]
+
 	// TimeRange represents a range of time.
-	TimeRange struct {
-		Min *time.Time
-		Max *time.Time
-	}
+	TimeRange = Range[time.Time]
 )
 
+// NewRange creates a new Range with inclusive bounds min and max.
+func NewRange[T Value](min, max T) Range[T] {
+	return Range[T]{Min: &min, Max: &max}
+}
+
 // NewFloat64Range creates a new Float64Range object.
 func NewFloat64Range(min, max float64) Float64Range {
-	return Float64Range{&min, &max}
+	return NewRange(min, max)
 }
 
 // NewInt32Range creates a new Int32Range object.
 func NewInt32Range(min, max int32) Int32Range {
-	return Int32Range{&min, &max}
+	return NewRange(min, max)
 }
 
-// Float64RangeEqual returns true if two ranges are equal.
-func Float64RangeEqual(a, b Float64Range) bool {
-	if (a.Min == nil && b.Min != nil) ||
-		(a.Min != nil && b.Min == nil) ||
-		(a.Max == nil && b.Max != nil) ||
-		(a.Max != nil && b.Max == nil) {
-		return false
+// NewTimeRange creates a new TimeRange object.
+func NewTimeRange(min, max time.Time) TimeRange {
+	return NewRange(min, max)
+}
+
+// compare returns -1, 0, or +1 depending on whether a is less than, equal to,
+// or greater than b. time.Time values are compared as instants.
+func compare[T Value](a, b T) int {
+	switch a := any(a).(type) {
+	case time.Time:
+		return a.Compare(any(b).(time.Time))
+	case int:
+		return orderedCompare(a, b)
+	case int8:
+		return orderedCompare(a, b)
+	case int16:
+		return orderedCompare(a, b)
+	case int32:
+		return orderedCompare(a, b)
+	case int64:
+		return orderedCompare(a, b)
+	case uint:
+		return orderedCompare(a, b)
+	case uint8:
+		return orderedCompare(a, b)
+	case uint16:
+		return orderedCompare(a, b)
+	case uint32:
+		return orderedCompare(a, b)
+	case uint64:
+		return orderedCompare(a, b)
+	case uintptr:
+		return orderedCompare(a, b)
+	case float32:
+		return orderedCompare(a, b)
+	case float64:
+		return orderedCompare(a, b)
+	case string:
+		return orderedCompare(a, b)
 	}
-	if (a.Min != nil) && (b.Min != nil) &&
-		(*a.Min != *b.Min) {
-		return false
+	panic(fmt.Sprintf("interval: unsupported range value type %T", a))
+}
+
+// orderedCompare compares a with b, which must hold a value of the same type.
+func orderedCompare[T cmp.Ordered](a T, b any) int {
+	return cmp.Compare(a, b.(T))
+}
+
+func isNaN[T Value](v T) bool {
+	switch v := any(v).(type) {
+	case float32:
+		return math.IsNaN(float64(v))
+	case float64:
+		return math.IsNaN(v)
 	}
-	if (a.Max != nil) && (b.Max != nil) &&
-		(*a.Max != *b.Max) {
-		return false
+	return false
+}
+
+// Equal returns true if r and o have the same bounds. time.Time bounds are
+// equal if they represent the same instant.
+func (r Range[T]) Equal(o Range[T]) bool {
+	return boundEqual(r.Min, o.Min, r.MinExclusive, o.MinExclusive) &&
+		boundEqual(r.Max, o.Max, r.MaxExclusive, o.MaxExclusive)
+}
+
+func boundEqual[T Value](a, b *T, aExclusive, bExclusive bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
 	}
-	return true
+	return compare(*a, *b) == 0 && aExclusive == bExclusive
 }
 
-// String returns a string representation of a Float64Range.
-func (r Float64Range) String() string {
-	var min, max string
+// Contains returns true if v falls within the range.
+func (r Range[T]) Contains(v T) bool {
 	if r.Min != nil {
-		min = fmt.Sprintf("%f", *r.Min)
-	} else {
-		min = "nil"
+		c := compare(v, *r.Min)
+		if c < 0 || (c == 0 && r.MinExclusive) {
+			return false
+		}
 	}
 	if r.Max != nil {
-		max = fmt.Sprintf("%f", *r.Max)
-	} else {
-		max = "nil"
+		c := compare(v, *r.Max)
+		if c > 0 || (c == 0 && r.MaxExclusive) {
+			return false
+		}
 	}
-	return fmt.Sprintf("{Min: %s, Max: %s}", min, max)
+	return true
 }
 
-// Int32RangeEqual returns true if two ranges are equal.
-func Int32RangeEqual(a, b Int32Range) bool {
-	if (a.Min == nil && b.Min != nil) ||
-		(a.Min != nil && b.Min == nil) ||
-		(a.Max == nil && b.Max != nil) ||
-		(a.Max != nil && b.Max == nil) {
+// IsEmpty returns true if no value can fall within the range, which is the case
+// when Min is greater than Max, or when they are equal and either bound is
+// exclusive. Ranges are treated as continuous, so an Int32Range from 1 to 2
+// with both bounds exclusive is not considered empty.
+func (r Range[T]) IsEmpty() bool {
+	if r.Min == nil || r.Max == nil {
 		return false
 	}
-	if (a.Min != nil) && (b.Min != nil) &&
-		(*a.Min != *b.Min) {
-		return false
+	c := compare(*r.Min, *r.Max)
+	return c > 0 || (c == 0 && (r.MinExclusive || r.MaxExclusive))
+}
+
+// Validate returns an error if a bound is NaN or if the range is empty.
+func (r Range[T]) Validate() error {
+	if (r.Min != nil && isNaN(*r.Min)) || (r.Max != nil && isNaN(*r.Max)) {
+		return errors.New("range bound is NaN")
+	}
+	if r.IsEmpty() {
+		return fmt.Errorf("range %s is empty: min must not exceed max", r)
+	}
+	return nil
+}
+
+// Intersect returns the range of values that fall within both r and o. The
+// result is empty if r and o do not overlap.
+func (r Range[T]) Intersect(o Range[T]) Range[T] {
+	var result Range[T]
+	result.Min, result.MinExclusive = tighterMin(r.Min, r.MinExclusive, o.Min, o.MinExclusive)
+	result.Max, result.MaxExclusive = tighterMax(r.Max, r.MaxExclusive, o.Max, o.MaxExclusive)
+	return result
+}
+
+// Hull returns the smallest range that contains both r and o. An empty range
+// contributes nothing to the hull.
+func (r Range[T]) Hull(o Range[T]) Range[T] {
+	if r.IsEmpty() {
+		return o
+	}
+	if o.IsEmpty() {
+		return r
 	}
-	if (a.Max != nil) && (b.Max != nil) &&
-		(*a.Max != *b.Max) {
+	var result Range[T]
+	result.Min, result.MinExclusive = looserMin(r.Min, r.MinExclusive, o.Min, o.MinExclusive)
+	result.Max, result.MaxExclusive = looserMax(r.Max, r.MaxExclusive, o.Max, o.MaxExclusive)
+	return result
+}
+
+// Union returns the range of values that fall within r or o. If r and o
+// neither overlap nor touch, their union is not a single range, and Union
+// returns their Hull and false.
+func (r Range[T]) Union(o Range[T]) (Range[T], bool) {
+	hull := r.Hull(o)
+	if r.IsEmpty() || o.IsEmpty() {
+		return hull, true
+	}
+	return hull, !separated(r, o) && !separated(o, r)
+}
+
+// separated returns true if every value of a lies below every value of b with
+// at least one value between them that neither contains.
+func separated[T Value](a, b Range[T]) bool {
+	if a.Max == nil || b.Min == nil {
 		return false
 	}
-	return true
+	c := compare(*a.Max, *b.Min)
+	return c < 0 || (c == 0 && a.MaxExclusive && b.MinExclusive)
 }
 
-// String returns a string representation of an Int32Range.
-func (r Int32Range) String() string {
-	var min, max string
-	if r.Min != nil {
-		min = fmt.Sprintf("%d", *r.Min)
-	} else {
-		min = "nil"
+func tighterMin[T Value](a *T, aExclusive bool, b *T, bExclusive bool) (*T, bool) {
+	if a == nil {
+		return b, bExclusive
 	}
-	if r.Max != nil {
-		max = fmt.Sprintf("%d", *r.Max)
-	} else {
-		max = "nil"
+	if b == nil {
+		return a, aExclusive
+	}
+	switch c := compare(*a, *b); {
+	case c > 0:
+		return a, aExclusive
+	case c < 0:
+		return b, bExclusive
 	}
-	return fmt.Sprintf("{Min: %s, Max: %s}", min, max)
+	return a, aExclusive || bExclusive
+}
+
+func tighterMax[T Value](a *T, aExclusive bool, b *T, bExclusive bool) (*T, bool) {
+	if a == nil {
+		return b, bExclusive
+	}
+	if b == nil {
+		return a, aExclusive
+	}
+	switch c := compare(*a, *b); {
+	case c < 0:
+		return a, aExclusive
+	case c > 0:
+		return b, bExclusive
+	}
+	return a, aExclusive || bExclusive
+}
+
+func looserMin[T Value](a *T, aExclusive bool, b *T, bExclusive bool) (*T, bool) {
+	if a == nil || b == nil {
+		return nil, false
+	}
+	switch c := compare(*a, *b); {
+	case c < 0:
+		return a, aExclusive
+	case c > 0:
+		return b, bExclusive
+	}
+	return a, aExclusive && bExclusive
+}
+
+func looserMax[T Value](a *T, aExclusive bool, b *T, bExclusive bool) (*T, bool) {
+	if a == nil || b == nil {
+		return nil, false
+	}
+	switch c := compare(*a, *b); {
+	case c > 0:
+		return a, aExclusive
+	case c < 0:
+		return b, bExclusive
+	}
+	return a, aExclusive && bExclusive
+}
+
+// Float64RangeEqual returns true if two ranges are equal.
+func Float64RangeEqual(a, b Float64Range) bool {
+	return a.Equal(b)
+}
+
+// Int32RangeEqual returns true if two ranges are equal.
+func Int32RangeEqual(a, b Int32Range) bool {
+	return a.Equal(b)
+}
+
+// TimeRangeEqual returns true if two ranges are equal.
+func TimeRangeEqual(a, b TimeRange) bool {
+	return a.Equal(b)
 }
@@ -1,7 +1,10 @@
 package interval
 
 import (
+	"fmt"
+	"math"
 	"testing"
+	"time"
 )
 
 func TestNewFloat64Range(t *testing.T) {
@@ -133,3 +136,136 @@ func TestInt32RangeEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestRangeContains(t *testing.T) {
+	var tests = []struct {
+		r    Int32Range
+		v    int32
+		want bool
+	}{
+		{Int32Range{}, 5, true},
+		{NewInt32Range(1, 5), 1, true},
+		{NewInt32Range(1, 5), 5, true},
+		{NewInt32Range(1, 5), 0, false},
+		{NewInt32Range(1, 5), 6, false},
+		{Int32Range{Min: CreateInt32(1), MinExclusive: true}, 1, false},
+		{Int32Range{Min: CreateInt32(1), MinExclusive: true}, 2, true},
+		{Int32Range{Max: CreateInt32(5), MaxExclusive: true}, 5, false},
+		{Int32Range{Max: CreateInt32(5), MaxExclusive: true}, -100, true},
+	}
+	for _, tt := range tests {
+		if got := tt.r.Contains(tt.v); got != tt.want {
+			t.Errorf("%s.Contains(%d) = %v, want %v", tt.r, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestTimeRangeContains(t *testing.T) {
+	start := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	r := NewTimeRange(start, end)
+	if !r.Contains(start.In(time.FixedZone("PST", -8*60*60))) {
+		t.Errorf("%s should contain its minimum in another location", r)
+	}
+	if r.Contains(end.Add(time.Nanosecond)) {
+		t.Errorf("%s should not contain a time after its maximum", r)
+	}
+}
+
+func TestRangeValidate(t *testing.T) {
+	var tests = []struct {
+		r             Float64Range
+		expectSuccess bool
+		msg           string
+	}{
+		{Float64Range{}, true, "unbounded range should be valid"},
+		{NewFloat64Range(1.0, 1.0), true, "single point range should be valid"},
+		{NewFloat64Range(1.0, 2.0), true, "min less than max should be valid"},
+		{NewFloat64Range(2.0, 1.0), false, "min greater than max should be invalid"},
+		{Float64Range{Min: CreateFloat64(1.0), Max: CreateFloat64(1.0), MaxExclusive: true}, false, "single point range with exclusive bound should be invalid"},
+		{Float64Range{Min: CreateFloat64(math.NaN())}, false, "NaN bound should be invalid"},
+	}
+	for _, tt := range tests {
+		err := tt.r.Validate()
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s: Validate failed: %v", tt.msg, err)
+		} else if !tt.expectSuccess && err == nil {
+			t.Errorf("%s: expected Validate to fail", tt.msg)
+		}
+	}
+}
+
+func TestRangeIntersect(t *testing.T) {
+	var tests = []struct {
+		a    Int32Range
+		b    Int32Range
+		want Int32Range
+	}{
+		{NewInt32Range(1, 5), NewInt32Range(3, 8), NewInt32Range(3, 5)},
+		{NewInt32Range(1, 5), Int32Range{}, NewInt32Range(1, 5)},
+		{Int32Range{Min: CreateInt32(1)}, Int32Range{Max: CreateInt32(5)}, NewInt32Range(1, 5)},
+		{
+			NewInt32Range(1, 5),
+			Int32Range{Min: CreateInt32(1), Max: CreateInt32(8), MinExclusive: true},
+			Int32Range{Min: CreateInt32(1), Max: CreateInt32(5), MinExclusive: true},
+		},
+		{NewInt32Range(1, 2), NewInt32Range(3, 4), NewInt32Range(3, 2)},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Intersect(tt.b); !got.Equal(tt.want) {
+			t.Errorf("%s.Intersect(%s) = %s, want %s", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if !NewInt32Range(1, 2).Intersect(NewInt32Range(3, 4)).IsEmpty() {
+		t.Errorf("intersection of disjoint ranges should be empty")
+	}
+}
+
+func TestRangeUnion(t *testing.T) {
+	var tests = []struct {
+		a      Int32Range
+		b      Int32Range
+		want   Int32Range
+		wantOK bool
+	}{
+		{NewInt32Range(1, 5), NewInt32Range(3, 8), NewInt32Range(1, 8), true},
+		{NewInt32Range(1, 5), NewInt32Range(5, 8), NewInt32Range(1, 8), true},
+		{NewInt32Range(1, 2), NewInt32Range(4, 8), NewInt32Range(1, 8), false},
+		{NewInt32Range(1, 5), Int32Range{Min: CreateInt32(3)}, Int32Range{Min: CreateInt32(1)}, true},
+		{NewInt32Range(5, 1), NewInt32Range(7, 8), NewInt32Range(7, 8), true},
+		{
+			Int32Range{Max: CreateInt32(5), MaxExclusive: true},
+			Int32Range{Min: CreateInt32(5), MinExclusive: true},
+			Int32Range{},
+			false,
+		},
+		{
+			Int32Range{Max: CreateInt32(5), MaxExclusive: true},
+			Int32Range{Min: CreateInt32(5)},
+			Int32Range{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		got, ok := tt.a.Union(tt.b)
+		if !got.Equal(tt.want) || ok != tt.wantOK {
+			t.Errorf("%s.Union(%s) = %s, %v, want %s, %v", tt.a, tt.b, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRangeString(t *testing.T) {
+	var tests = []struct {
+		r    fmt.Stringer
+		want string
+	}{
+		{NewFloat64Range(1.5, 2), "[1.5,2]"},
+		{Int32Range{Min: CreateInt32(3), MinExclusive: true}, "(3,)"},
+		{TimeRange{Max: CreateTime(time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC))}, "(,2017-01-02T03:04:05Z]"},
+	}
+	for _, tt := range tests {
+		if got := tt.r.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
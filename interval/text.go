@@ -0,0 +1,214 @@
+package interval
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayout is the layout of date-only time bounds, which are interpreted as
+// midnight in the parser's location.
+const dateLayout = "2006-01-02"
+
+// IsZero returns true if the range is unbounded on both sides.
+func (r Range[T]) IsZero() bool {
+	return r.Min == nil && r.Max == nil
+}
+
+// String returns the range in interval notation, such as "[0.05,0.12)" or
+// "[700,)". Square brackets mark inclusive bounds and parentheses mark
+// exclusive or missing bounds. ParseRange parses the result back into an equal
+// Range.
+func (r Range[T]) String() string {
+	left, right := "[", "]"
+	if r.Min == nil || r.MinExclusive {
+		left = "("
+	}
+	if r.Max == nil || r.MaxExclusive {
+		right = ")"
+	}
+	return left + formatBound(r.Min) + "," + formatBound(r.Max) + right
+}
+
+func formatBound[T Value](v *T) string {
+	if v == nil {
+		return ""
+	}
+	if t, ok := any(*v).(time.Time); ok {
+		return t.Format(time.RFC3339Nano)
+	}
+	rv := reflect.ValueOf(*v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, rv.Type().Bits())
+	case reflect.String:
+		return strconv.Quote(rv.String())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10)
+	}
+	return strconv.FormatInt(rv.Int(), 10)
+}
+
+// ParseRange parses a range in interval notation, such as "[0.05,0.12)",
+// "[700,]", or "(,2020-01-01]". A missing bound leaves that side of the range
+// unbounded, in which case either bracket may be used. Time bounds are RFC 3339
+// timestamps or dates, which are interpreted as midnight in
+// DefaultDateLocation. String bounds are Go-quoted strings. An empty string
+// parses to an unbounded range.
+func ParseRange[T Value](s string) (Range[T], error) {
+	return ParseRangeInLocation[T](s, DefaultDateLocation())
+}
+
+// ParseRangeInLocation is like ParseRange, but interprets date-only time bounds
+// as midnight in loc.
+func ParseRangeInLocation[T Value](s string, loc *time.Location) (Range[T], error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Range[T]{}, nil
+	}
+	if len(s) < 3 || !strings.ContainsRune("[(", rune(s[0])) || !strings.ContainsRune("])", rune(s[len(s)-1])) {
+		return Range[T]{}, fmt.Errorf("invalid range %q: must be of the form [min,max]", s)
+	}
+	minText, maxText, err := splitBounds(s[1 : len(s)-1])
+	if err != nil {
+		return Range[T]{}, fmt.Errorf("invalid range %q: %v", s, err)
+	}
+	var r Range[T]
+	if r.Min, err = parseBound[T](minText, loc); err != nil {
+		return Range[T]{}, fmt.Errorf("invalid range %q: %v", s, err)
+	}
+	if r.Max, err = parseBound[T](maxText, loc); err != nil {
+		return Range[T]{}, fmt.Errorf("invalid range %q: %v", s, err)
+	}
+	r.MinExclusive = r.Min != nil && s[0] == '('
+	r.MaxExclusive = r.Max != nil && s[len(s)-1] == ')'
+	return r, nil
+}
+
+// ParseFloat64Range parses a Float64Range in interval notation.
+func ParseFloat64Range(s string) (Float64Range, error) {
+	return ParseRange[float64](s)
+}
+
+// ParseInt32Range parses an Int32Range in interval notation.
+func ParseInt32Range(s string) (Int32Range, error) {
+	return ParseRange[int32](s)
+}
+
+// ParseTimeRange parses a TimeRange in interval notation.
+func ParseTimeRange(s string) (TimeRange, error) {
+	return ParseRange[time.Time](s)
+}
+
+// splitBounds splits the text between a range's brackets at the comma that
+// separates its bounds, ignoring commas within quoted strings.
+func splitBounds(s string) (string, string, error) {
+	sep := -1
+	inQuote := false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case inQuote && s[i] == '\\':
+			i++
+		case s[i] == '"':
+			inQuote = !inQuote
+		case !inQuote && s[i] == ',':
+			if sep >= 0 {
+				return "", "", errors.New("too many commas")
+			}
+			sep = i
+		}
+	}
+	if sep < 0 {
+		return "", "", errors.New("missing comma between bounds")
+	}
+	return strings.TrimSpace(s[:sep]), strings.TrimSpace(s[sep+1:]), nil
+}
+
+func parseBound[T Value](s string, loc *time.Location) (*T, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var v T
+	if p, ok := any(&v).(*time.Time); ok {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			t, err = time.ParseInLocation(dateLayout, s, loc)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid time bound %q", s)
+		}
+		*p = t
+		return &v, nil
+	}
+	rv := reflect.ValueOf(&v).Elem()
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, rv.Type().Bits())
+		if err != nil {
+			return nil, err
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, rv.Type().Bits())
+		if err != nil {
+			return nil, err
+		}
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, rv.Type().Bits())
+		if err != nil {
+			return nil, err
+		}
+		rv.SetFloat(f)
+	case reflect.String:
+		u, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string bound %s", s)
+		}
+		rv.SetString(u)
+	}
+	return &v, nil
+}
+
+// MarshalText encodes the range in interval notation.
+func (r Range[T]) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText decodes a range in interval notation.
+func (r *Range[T]) UnmarshalText(text []byte) error {
+	parsed, err := ParseRange[T](string(text))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// UnmarshalJSON decodes a range from a JSON string in interval notation. It
+// also accepts the legacy object form with Min and Max attributes.
+func (r *Range[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '{' {
+		var legacy struct {
+			Min, Max                   *T
+			MinExclusive, MaxExclusive bool
+		}
+		if err := json.Unmarshal(data, &legacy); err != nil {
+			return err
+		}
+		*r = Range[T](legacy)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return r.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,190 @@
+package interval
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseFloat64Range(t *testing.T) {
+	var tests = []struct {
+		s             string
+		want          Float64Range
+		expectSuccess bool
+		msg           string
+	}{
+		{
+			s:             "[0.05,0.12)",
+			want:          Float64Range{Min: CreateFloat64(0.05), Max: CreateFloat64(0.12), MaxExclusive: true},
+			expectSuccess: true,
+			msg:           "half-open range should parse",
+		},
+		{
+			s:             " ( 0.05 , 0.12 ] ",
+			want:          Float64Range{Min: CreateFloat64(0.05), Max: CreateFloat64(0.12), MinExclusive: true},
+			expectSuccess: true,
+			msg:           "whitespace should be ignored",
+		},
+		{
+			s:             "[700,]",
+			want:          Float64Range{Min: CreateFloat64(700)},
+			expectSuccess: true,
+			msg:           "missing max should be unbounded",
+		},
+		{
+			s:             "(,)",
+			want:          Float64Range{},
+			expectSuccess: true,
+			msg:           "missing bounds should be unbounded",
+		},
+		{
+			s:             "",
+			want:          Float64Range{},
+			expectSuccess: true,
+			msg:           "empty string should be unbounded",
+		},
+		{
+			s:             "0.05,0.12",
+			expectSuccess: false,
+			msg:           "missing brackets should fail",
+		},
+		{
+			s:             "[0.05]",
+			expectSuccess: false,
+			msg:           "missing comma should fail",
+		},
+		{
+			s:             "[1,2,3]",
+			expectSuccess: false,
+			msg:           "extra comma should fail",
+		},
+		{
+			s:             "[a,2]",
+			expectSuccess: false,
+			msg:           "non-numeric bound should fail",
+		},
+	}
+	for _, tt := range tests {
+		got, err := ParseFloat64Range(tt.s)
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s: ParseFloat64Range(%q) failed: %v", tt.msg, tt.s, err)
+			continue
+		} else if !tt.expectSuccess {
+			if err == nil {
+				t.Errorf("%s: expected ParseFloat64Range(%q) to fail", tt.msg, tt.s)
+			}
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: ParseFloat64Range(%q) = %s, want %s", tt.msg, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimeRange(t *testing.T) {
+	got, err := ParseTimeRange("(,2020-01-01]")
+	if err != nil {
+		t.Fatalf("ParseTimeRange failed: %v", err)
+	}
+	want := TimeRange{Max: CreateTime(time.Date(2020, 1, 1, 0, 0, 0, 0, DefaultDateLocation()))}
+	if !got.Equal(want) {
+		t.Errorf("ParseTimeRange = %s, want %s", got, want)
+	}
+	got, err = ParseRangeInLocation[time.Time]("[2020-01-01,2020-01-01T12:00:00Z]", time.UTC)
+	if err != nil {
+		t.Fatalf("ParseRangeInLocation failed: %v", err)
+	}
+	want = TimeRange{
+		Min: CreateTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Max: CreateTime(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)),
+	}
+	if !got.Equal(want) {
+		t.Errorf("ParseRangeInLocation = %s, want %s", got, want)
+	}
+	if _, err := ParseTimeRange("[yesterday,]"); err == nil {
+		t.Errorf("ParseTimeRange should fail on an invalid time")
+	}
+}
+
+func TestRangeStringRoundTrip(t *testing.T) {
+	pst := time.FixedZone("PST", -8*60*60)
+	float64Ranges := []Float64Range{
+		{},
+		NewFloat64Range(0.05, 0.12),
+		{Min: CreateFloat64(-1e-7), MinExclusive: true},
+		{Max: CreateFloat64(1234567.891), MaxExclusive: true},
+	}
+	for _, r := range float64Ranges {
+		got, err := ParseFloat64Range(r.String())
+		if err != nil || !got.Equal(r) {
+			t.Errorf("ParseFloat64Range(%q) = %s, %v, want %s", r.String(), got, err, r)
+		}
+	}
+	int32Ranges := []Int32Range{
+		{Min: CreateInt32(-5)},
+		NewInt32Range(600, 619),
+	}
+	for _, r := range int32Ranges {
+		got, err := ParseInt32Range(r.String())
+		if err != nil || !got.Equal(r) {
+			t.Errorf("ParseInt32Range(%q) = %s, %v, want %s", r.String(), got, err, r)
+		}
+	}
+	timeRange := NewTimeRange(
+		time.Date(2017, 1, 2, 3, 4, 5, 6, pst),
+		time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC))
+	got, err := ParseTimeRange(timeRange.String())
+	if err != nil || !got.Equal(timeRange) {
+		t.Errorf("ParseTimeRange(%q) = %s, %v, want %s", timeRange.String(), got, err, timeRange)
+	}
+	stringRange := Range[string]{Min: new(string), Max: new(string)}
+	*stringRange.Min, *stringRange.Max = `a,"b"`, "z"
+	gotString, err := ParseRange[string](stringRange.String())
+	if err != nil || !gotString.Equal(stringRange) {
+		t.Errorf("ParseRange(%q) = %s, %v, want %s", stringRange.String(), gotString, err, stringRange)
+	}
+}
+
+func TestRangeJSON(t *testing.T) {
+	type filter struct {
+		Yield Float64Range `json:"yield"`
+		Score Int32Range   `json:"score,omitzero"`
+	}
+	f := filter{Yield: Float64Range{Min: CreateFloat64(0.05), Max: CreateFloat64(0.12), MaxExclusive: true}}
+	encoded, err := json.Marshal(f)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	if want := `{"yield":"[0.05,0.12)"}`; string(encoded) != want {
+		t.Errorf("json.Marshal = %s, want %s", encoded, want)
+	}
+	var decoded filter
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if !decoded.Yield.Equal(f.Yield) || !decoded.Score.IsZero() {
+		t.Errorf("json.Unmarshal = %+v, want %+v", decoded, f)
+	}
+
+	var legacy filter
+	if err := json.Unmarshal([]byte(`{"yield":{"Min":0.05,"Max":null},"score":null}`), &legacy); err != nil {
+		t.Fatalf("json.Unmarshal of legacy object failed: %v", err)
+	}
+	if want := (Float64Range{Min: CreateFloat64(0.05)}); !legacy.Yield.Equal(want) {
+		t.Errorf("json.Unmarshal of legacy object = %s, want %s", legacy.Yield, want)
+	}
+	if err := json.Unmarshal([]byte(`{"yield":"[x,]"}`), &legacy); err == nil {
+		t.Errorf("json.Unmarshal should fail on invalid range")
+	}
+}
+
+func TestDefaultDateLocation(t *testing.T) {
+	loc := DefaultDateLocation()
+	if loc.String() != "America/Los_Angeles" {
+		t.Errorf("DefaultDateLocation() = %v, want America/Los_Angeles", loc)
+	}
+	summer := time.Date(2020, 7, 1, 0, 0, 0, 0, loc)
+	if _, offset := summer.Zone(); offset != -7*60*60 {
+		t.Errorf("DefaultDateLocation() offset in July = %d, want daylight saving time offset %d", offset, -7*60*60)
+	}
+}
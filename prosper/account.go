@@ -1,7 +1,7 @@
 package prosper
 
 import (
-	"time"
+	"encoding/json"
 
 	"github.com/mtlynch/gofn-prosper/prosper/thin"
 )
@@ -21,14 +21,22 @@ type (
 		OutstandingPrincipalOnActiveNotes   float64
 		LastWithdrawAmount                  float64
 		LastDepositAmount                   float64
-		LastDepositDate                     time.Time
+		LastDepositDate                     Date
 		PendingInvestmentsPrimaryMarket     float64
 		PendingInvestmentsSecondaryMarket   float64
 		PendingQuickInvestOrders            float64
 		TotalAmountInvestedOnActiveNotes    float64
 		TotalAccountValue                   float64
 		InflightGross                       float64
-		LastWithdrawDate                    time.Time
+		LastWithdrawDate                    Date
+
+		// Raw holds the JSON Prosper sent for the account. It is only
+		// populated when the client keeps raw JSON.
+		Raw json.RawMessage `json:",omitempty"`
+
+		// amountText holds the decimal text Prosper sent for the currency
+		// fields.
+		amountText thin.AccountResponseAmounts
 	}
 
 	// Accounter supports the Account interface for retrieving user account
@@ -43,6 +51,7 @@ type (
 // implements the REST API described at:
 // https://developers.prosper.com/docs/investor/accounts-api/
 func (c defaultClient) Account(AccountParams) (AccountInformation, error) {
+	c.wait()
 	rawResponse, err := c.rawClient.Account(thin.AccountParams{})
 	if err != nil {
 		return AccountInformation{}, err
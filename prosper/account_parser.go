@@ -31,5 +31,7 @@ func (a defaultAccountParser) Parse(r thin.AccountResponse) (AccountInformation,
 		TotalAccountValue:                   r.TotalAccountValue,
 		InflightGross:                       r.InflightGross,
 		LastWithdrawDate:                    lastWithdrawDate,
+		Raw:                                 r.Raw,
+		amountText:                          r.Amounts,
 	}, nil
 }
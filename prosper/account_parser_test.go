@@ -3,7 +3,6 @@ package prosper
 import (
 	"reflect"
 	"testing"
-	"time"
 
 	"github.com/mtlynch/gofn-prosper/prosper/thin"
 )
@@ -35,9 +34,9 @@ func TestAccountParserParsesTimeCorrectly(t *testing.T) {
 		TotalAccountValue:                   326476.16,
 		InflightGross:                       12345.67,
 		LastDepositAmount:                   20000,
-		LastDepositDate:                     time.Date(2015, 10, 23, 0, 0, 0, 0, time.UTC),
+		LastDepositDate:                     Date{2015, 10, 23},
 		LastWithdrawAmount:                  5400,
-		LastWithdrawDate:                    time.Date(2015, 10, 2, 0, 0, 0, 0, time.UTC),
+		LastWithdrawDate:                    Date{2015, 10, 2},
 	}
 	if err != nil {
 		t.Errorf("accountParser.Parse failed: %v", err)
@@ -72,7 +71,7 @@ func TestAccountParserParsesCorrectlyWhenNoWithdrawsExist(t *testing.T) {
 		TotalAccountValue:                   326476.16,
 		InflightGross:                       12345.67,
 		LastDepositAmount:                   20000,
-		LastDepositDate:                     time.Date(2015, 10, 23, 0, 0, 0, 0, time.UTC),
+		LastDepositDate:                     Date{2015, 10, 23},
 	}
 	if err != nil {
 		t.Errorf("accountParser.Parse failed: %v", err)
@@ -107,7 +106,7 @@ func TestAccountParserParsesCorrectlyWhenNoDepositsExist(t *testing.T) {
 		TotalAccountValue:                   326476.16,
 		InflightGross:                       12345.67,
 		LastWithdrawAmount:                  5400,
-		LastWithdrawDate:                    time.Date(2015, 10, 2, 0, 0, 0, 0, time.UTC),
+		LastWithdrawDate:                    Date{2015, 10, 2},
 	}
 	if err != nil {
 		t.Errorf("accountParser.Parse failed: %v", err)
@@ -158,3 +157,17 @@ func TestAccountParserFailsOnInvalidLastWithdrawDate(t *testing.T) {
 		t.Error("accountParser.Parse should fail when LastWithdrawDate is invalid")
 	}
 }
+
+func TestAccountParserKeepsRawJSON(t *testing.T) {
+	raw := []byte(`{"last_deposit_date": "2015-10-23"}`)
+	got, err := defaultAccountParser{}.Parse(thin.AccountResponse{
+		LastDepositDate: "2015-10-23",
+		Raw:             raw,
+	})
+	if err != nil {
+		t.Fatalf("accountParser.Parse failed: %v", err)
+	}
+	if string(got.Raw) != string(raw) {
+		t.Errorf("accountParser.Parse returned Raw %s, want %s", got.Raw, raw)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"reflect"
 	"testing"
-	"time"
 
 	"github.com/mtlynch/gofn-prosper/prosper/thin"
 )
@@ -31,7 +30,7 @@ func TestAccountSuccess(t *testing.T) {
 	}
 	want := AccountInformation{
 		LastDepositAmount: 250,
-		LastDepositDate:   time.Date(2015, 10, 5, 0, 0, 0, 0, time.UTC),
+		LastDepositDate:   Date{2015, 10, 5},
 	}
 	parser := mockAccountParser{accountInformation: want}
 	client := defaultClient{
@@ -42,10 +41,10 @@ func TestAccountSuccess(t *testing.T) {
 	if err != nil {
 		t.Errorf("Client.Account failed with %v", err)
 	}
-	if got != want {
+	if !reflect.DeepEqual(got, want) {
 		t.Errorf("Client.Account got %#v, want %#v", got, want)
 	}
-	if parser.accountsResponseGot != a {
+	if !reflect.DeepEqual(parser.accountsResponseGot, a) {
 		t.Errorf("parser got: %v, want %v", parser.accountsResponseGot, a)
 	}
 }
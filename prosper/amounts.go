@@ -0,0 +1,220 @@
+package prosper
+
+import "encoding/json"
+
+// The types in this file offer exact Money views of the currency fields on
+// AccountInformation, Note, Listing and BidStatus. The float64 fields on those
+// types remain for compatibility; callers who need exact arithmetic opt in by
+// calling Amounts() or CheckedAmounts(). For values parsed from a Prosper
+// response, both convert from the decimal text Prosper sent rather than from
+// the float64 fields, so no precision is lost. For values built by hand, they
+// convert from the float64 fields.
+
+// AccountAmounts holds the currency fields of AccountInformation as Money.
+type AccountAmounts struct {
+	AvailableCashBalance                Money
+	TotalPrincipalReceivedOnActiveNotes Money
+	OutstandingPrincipalOnActiveNotes   Money
+	LastWithdrawAmount                  Money
+	LastDepositAmount                   Money
+	PendingInvestmentsPrimaryMarket     Money
+	PendingInvestmentsSecondaryMarket   Money
+	PendingQuickInvestOrders            Money
+	TotalAmountInvestedOnActiveNotes    Money
+	TotalAccountValue                   Money
+	InflightGross                       Money
+}
+
+// Amounts returns the currency fields of the account information as Money.
+// Amounts that fail to convert are taken from the float64 fields and saturate,
+// as in MoneyFromFloat64.
+func (a AccountInformation) Amounts() AccountAmounts {
+	amounts, _ := a.CheckedAmounts()
+	return amounts
+}
+
+// CheckedAmounts returns the currency fields of the account information as
+// Money, failing if any is malformed or beyond the range of Money.
+func (a AccountInformation) CheckedAmounts() (AccountAmounts, error) {
+	c := moneyConverter{}
+	t := a.amountText
+	amounts := AccountAmounts{
+		AvailableCashBalance:                c.convert(a.AvailableCashBalance, t.AvailableCashBalance),
+		TotalPrincipalReceivedOnActiveNotes: c.convert(a.TotalPrincipalReceivedOnActiveNotes, t.TotalPrincipalReceivedOnActiveNotes),
+		OutstandingPrincipalOnActiveNotes:   c.convert(a.OutstandingPrincipalOnActiveNotes, t.OutstandingPrincipalOnActiveNotes),
+		LastWithdrawAmount:                  c.convert(a.LastWithdrawAmount, t.LastWithdrawAmount),
+		LastDepositAmount:                   c.convert(a.LastDepositAmount, t.LastDepositAmount),
+		PendingInvestmentsPrimaryMarket:     c.convert(a.PendingInvestmentsPrimaryMarket, t.PendingInvestmentsPrimaryMarket),
+		PendingInvestmentsSecondaryMarket:   c.convert(a.PendingInvestmentsSecondaryMarket, t.PendingInvestmentsSecondaryMarket),
+		PendingQuickInvestOrders:            c.convert(a.PendingQuickInvestOrders, t.PendingQuickInvestOrders),
+		TotalAmountInvestedOnActiveNotes:    c.convert(a.TotalAmountInvestedOnActiveNotes, t.TotalAmountInvestedOnActiveNotes),
+		TotalAccountValue:                   c.convert(a.TotalAccountValue, t.TotalAccountValue),
+		InflightGross:                       c.convert(a.InflightGross, t.InflightGross),
+	}
+	return amounts, c.err
+}
+
+// NoteAmounts holds the currency fields of a Note as Money.
+type NoteAmounts struct {
+	AmountBorrowed                       Money
+	DebtSaleProceedsReceivedProRataShare Money
+	InterestPaidProRataShare             Money
+	LateFeesPaidProRataShare             Money
+	NextPaymentDueAmountProRataShare     Money
+	NoteOwnershipAmount                  Money
+	NoteSaleFeesPaid                     Money
+	NoteSaleGrossAmountReceived          Money
+	PrincipalBalanceProRataShare         Money
+	PrincipalPaidProRataShare            Money
+	ProsperFeesPaidProRataShare          Money
+	ServiceFeesPaidProRataShare          Money
+}
+
+// Amounts returns the currency fields of the note as Money. Amounts that fail
+// to convert are taken from the float64 fields and saturate, as in
+// MoneyFromFloat64.
+func (n Note) Amounts() NoteAmounts {
+	amounts, _ := n.CheckedAmounts()
+	return amounts
+}
+
+// CheckedAmounts returns the currency fields of the note as Money, failing if
+// any is malformed or beyond the range of Money.
+func (n Note) CheckedAmounts() (NoteAmounts, error) {
+	c := moneyConverter{}
+	t := n.amountText
+	amounts := NoteAmounts{
+		AmountBorrowed:                       c.convert(n.AmountBorrowed, t.AmountBorrowed),
+		DebtSaleProceedsReceivedProRataShare: c.convert(n.DebtSaleProceedsReceivedProRataShare, t.DebtSaleProceedsReceivedProRataShare),
+		InterestPaidProRataShare:             c.convert(n.InterestPaidProRataShare, t.InterestPaidProRataShare),
+		LateFeesPaidProRataShare:             c.convert(n.LateFeesPaidProRataShare, t.LateFeesPaidProRataShare),
+		NextPaymentDueAmountProRataShare:     c.convert(n.NextPaymentDueAmountProRataShare, t.NextPaymentDueAmountProRataShare),
+		NoteOwnershipAmount:                  c.convert(n.NoteOwnershipAmount, t.NoteOwnershipAmount),
+		NoteSaleFeesPaid:                     c.convert(n.NoteSaleFeesPaid, t.NoteSaleFeesPaid),
+		NoteSaleGrossAmountReceived:          c.convert(n.NoteSaleGrossAmountReceived, t.NoteSaleGrossAmountReceived),
+		PrincipalBalanceProRataShare:         c.convert(n.PrincipalBalanceProRataShare, t.PrincipalBalanceProRataShare),
+		PrincipalPaidProRataShare:            c.convert(n.PrincipalPaidProRataShare, t.PrincipalPaidProRataShare),
+		ProsperFeesPaidProRataShare:          c.convert(n.ProsperFeesPaidProRataShare, t.ProsperFeesPaidProRataShare),
+		ServiceFeesPaidProRataShare:          c.convert(n.ServiceFeesPaidProRataShare, t.ServiceFeesPaidProRataShare),
+	}
+	return amounts, c.err
+}
+
+// ListingAmounts holds the currency fields of a Listing as Money.
+type ListingAmounts struct {
+	AmountDelinquent                      Money
+	AmountFunded                          Money
+	AmountParticipation                   Money
+	AmountRemaining                       Money
+	CombinedStatedMonthlyIncome           Money
+	InstallmentBalance                    Money
+	ListingAmount                         Money
+	ListingMonthlyPayment                 Money
+	MaxPriorProsperLoan                   Money
+	MinPriorProsperLoan                   Money
+	MonthlyDebt                           Money
+	PriorProsperLoansBalanceOutstanding   Money
+	PriorProsperLoansPrincipalBorrowed    Money
+	PriorProsperLoansPrincipalOutstanding Money
+	RealEstateBalance                     Money
+	RealEstatePayment                     Money
+	RevolvingBalance                      Money
+	StatedMonthlyIncome                   Money
+}
+
+// Amounts returns the currency fields of the listing as Money. Amounts that
+// fail to convert are taken from the float64 fields and saturate, as in
+// MoneyFromFloat64.
+func (l Listing) Amounts() ListingAmounts {
+	amounts, _ := l.CheckedAmounts()
+	return amounts
+}
+
+// CheckedAmounts returns the currency fields of the listing as Money, failing
+// if any is malformed or beyond the range of Money.
+func (l Listing) CheckedAmounts() (ListingAmounts, error) {
+	c := moneyConverter{}
+	t := l.amountText
+	amounts := ListingAmounts{
+		AmountDelinquent:                      c.convert(l.AmountDelinquent, t.AmountDelinquent),
+		AmountFunded:                          c.convert(l.AmountFunded, t.AmountFunded),
+		AmountParticipation:                   c.convert(l.AmountParticipation, t.AmountParticipation),
+		AmountRemaining:                       c.convert(l.AmountRemaining, t.AmountRemaining),
+		CombinedStatedMonthlyIncome:           c.convert(l.CombinedStatedMonthlyIncome, t.CombinedStatedMonthlyIncome),
+		InstallmentBalance:                    c.convert(l.InstallmentBalance, t.InstallmentBalance),
+		ListingAmount:                         c.convert(l.ListingAmount, t.ListingAmount),
+		ListingMonthlyPayment:                 c.convert(l.ListingMonthlyPayment, t.ListingMonthlyPayment),
+		MaxPriorProsperLoan:                   c.convert(l.MaxPriorProsperLoan, t.MaxPriorProsperLoan),
+		MinPriorProsperLoan:                   c.convert(l.MinPriorProsperLoan, t.MinPriorProsperLoan),
+		MonthlyDebt:                           c.convert(l.MonthlyDebt, t.MonthlyDebt),
+		PriorProsperLoansBalanceOutstanding:   c.convert(l.PriorProsperLoansBalanceOutstanding, t.PriorProsperLoansBalanceOutstanding),
+		PriorProsperLoansPrincipalBorrowed:    c.convert(l.PriorProsperLoansPrincipalBorrowed, t.PriorProsperLoansPrincipalBorrowed),
+		PriorProsperLoansPrincipalOutstanding: c.convert(l.PriorProsperLoansPrincipalOutstanding, t.PriorProsperLoansPrincipalOutstanding),
+		RealEstateBalance:                     c.convert(l.RealEstateBalance, t.RealEstateBalance),
+		RealEstatePayment:                     c.convert(l.RealEstatePayment, t.RealEstatePayment),
+		RevolvingBalance:                      c.convert(l.RevolvingBalance, t.RevolvingBalance),
+		StatedMonthlyIncome:                   c.convert(l.StatedMonthlyIncome, t.StatedMonthlyIncome),
+	}
+	return amounts, c.err
+}
+
+// BidStatusAmounts holds the currency fields of a BidStatus as Money.
+type BidStatusAmounts struct {
+	BidAmount       Money
+	BidAmountPlaced Money
+}
+
+// Amounts returns the currency fields of the bid status as Money. Amounts that
+// fail to convert are taken from the float64 fields and saturate, as in
+// MoneyFromFloat64.
+func (s BidStatus) Amounts() BidStatusAmounts {
+	amounts, _ := s.CheckedAmounts()
+	return amounts
+}
+
+// CheckedAmounts returns the currency fields of the bid status as Money,
+// failing if any is malformed or beyond the range of Money.
+func (s BidStatus) CheckedAmounts() (BidStatusAmounts, error) {
+	c := moneyConverter{}
+	t := s.amountText
+	amounts := BidStatusAmounts{
+		BidAmount:       c.convert(s.BidAmount, t.BidAmount),
+		BidAmountPlaced: c.convert(s.BidAmountPlaced, t.BidAmountPlaced),
+	}
+	return amounts, c.err
+}
+
+// moneyConverter converts dollar amounts to Money and records the first
+// amount that fails to convert.
+type moneyConverter struct {
+	err error
+}
+
+// convert returns text as Money if Prosper sent it, or dollars otherwise. If
+// the conversion fails, it records the error and returns dollars as Money,
+// saturating as in MoneyFromFloat64.
+func (c *moneyConverter) convert(dollars float64, text json.Number) Money {
+	var m Money
+	var err error
+	if text != "" {
+		m, err = ParseMoney(text.String())
+	} else {
+		m, err = CheckedMoneyFromFloat64(dollars)
+	}
+	if err != nil {
+		if c.err == nil {
+			c.err = err
+		}
+		return MoneyFromFloat64(dollars)
+	}
+	return m
+}
+
+// NewBidRequest creates a BidRequest for the given listing, rounding amount to
+// whole cents so that Prosper receives an exact dollar-and-cents value.
+func NewBidRequest(listingID ListingNumber, amount Money) BidRequest {
+	return BidRequest{
+		ListingID: listingID,
+		BidAmount: amount.RoundToCents().Float64(),
+	}
+}
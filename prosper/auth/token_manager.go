@@ -3,6 +3,8 @@ package auth
 import (
 	"sync"
 	"time"
+
+	"github.com/mtlynch/gofn-prosper/prosper/logging"
 )
 
 // OAuthToken is an authentication token from Prosper that is valid for a
@@ -25,16 +27,25 @@ type defaultTokenManager struct {
 	authenticator ProsperAuthenticator
 	clock         Clock
 	lock          sync.Mutex
+	logger        logging.Logger
 }
 
 // NewTokenManager creates a new TokenManager instance that authenticates to
 // Propser with the given authenticator.
 func NewTokenManager(authenticator ProsperAuthenticator) TokenManager {
+	return NewTokenManagerWithLogger(authenticator, logging.Discard)
+}
+
+// NewTokenManagerWithLogger creates a new TokenManager instance that
+// authenticates to Prosper with the given authenticator and reports token
+// refreshes to the given logger.
+func NewTokenManagerWithLogger(authenticator ProsperAuthenticator, logger logging.Logger) TokenManager {
 	return &defaultTokenManager{
 		token:         OAuthToken{},
 		authenticator: authenticator,
 		clock:         DefaultClock{},
 		lock:          sync.Mutex{},
+		logger:        logger,
 	}
 }
 
@@ -46,10 +57,14 @@ func (m *defaultTokenManager) Token() (OAuthToken, error) {
 	if m.clock.Now().Before(m.token.Expiration) {
 		return m.token, nil
 	}
+	logger := logging.OrDiscard(m.logger)
+	logger.Log(logging.Debug, "refreshing OAuth token")
 	token, err := m.tokenFromAuthenticator()
 	if err != nil {
+		logger.Log(logging.Error, "failed to refresh OAuth token", logging.F("error", err))
 		return OAuthToken{}, err
 	}
+	logger.Log(logging.Info, "refreshed OAuth token", logging.F("expiration", token.Expiration))
 	m.token = token
 	return m.token, nil
 }
@@ -6,6 +6,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/mtlynch/gofn-prosper/prosper/logging"
 )
 
 type mockProsperAuthenticator struct {
@@ -21,6 +23,16 @@ func (m *mockProsperAuthenticator) Authenticate() (oauthResponse, error) {
 	return m.OAuthResponse, m.Err
 }
 
+type mockLogger struct {
+	levels   []logging.Level
+	messages []string
+}
+
+func (l *mockLogger) Log(level logging.Level, msg string, fields ...logging.Field) {
+	l.levels = append(l.levels, level)
+	l.messages = append(l.messages, msg)
+}
+
 func TestMultipleCallsWithinExpirationPeriodOnlyAuthenticateOnce(t *testing.T) {
 	a := &mockProsperAuthenticator{
 		OAuthResponse: oauthResponse{
@@ -165,3 +177,37 @@ func TestFailsWhenAuthenticatorFails(t *testing.T) {
 		t.Errorf("Token() failed with unexpected error, got: %v, want: %v", err, authErr)
 	}
 }
+
+func TestTokenManagerLogsRefresh(t *testing.T) {
+	var tests = []struct {
+		authErr      error
+		wantMessages []string
+		msg          string
+	}{
+		{
+			wantMessages: []string{"refreshing OAuth token", "refreshed OAuth token"},
+			msg:          "successful refresh should be logged",
+		},
+		{
+			authErr:      errors.New("mock auth error"),
+			wantMessages: []string{"refreshing OAuth token", "failed to refresh OAuth token"},
+			msg:          "failed refresh should be logged",
+		},
+	}
+	for _, tt := range tests {
+		logger := &mockLogger{}
+		now := time.Date(2015, 12, 24, 10, 0, 0, 0, time.UTC)
+		m := defaultTokenManager{
+			authenticator: &mockProsperAuthenticator{
+				OAuthResponse: oauthResponse{ExpiresIn: 3599},
+				Err:           tt.authErr,
+			},
+			clock:  mockClock{&now},
+			logger: logger,
+		}
+		m.Token()
+		if !reflect.DeepEqual(logger.messages, tt.wantMessages) {
+			t.Errorf("%s: logged messages %v, want %v", tt.msg, logger.messages, tt.wantMessages)
+		}
+	}
+}
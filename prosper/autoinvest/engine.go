@@ -0,0 +1,433 @@
+// Package autoinvest runs an investment strategy end to end. An Engine watches
+// Prosper for new listings, selects the ones its strategy accepts, checks them
+// against the account's cash and exposure limits, places bids, and tracks the
+// resulting orders until Prosper completes them.
+package autoinvest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mtlynch/gofn-prosper/prosper"
+	"github.com/mtlynch/gofn-prosper/prosper/logging"
+	"github.com/mtlynch/gofn-prosper/prosper/strategy"
+)
+
+const defaultOrderPollInterval = 30 * time.Second
+
+// EventType identifies what happened in an Event.
+type EventType int8
+
+// Set of possible EventType values.
+const (
+	// ListingRejected means the strategy rejected a listing.
+	ListingRejected EventType = iota
+	// BidSkipped means a bid exceeded the engine's limits.
+	BidSkipped
+	// BidDeclined means the approval hook declined a bid.
+	BidDeclined
+	// BidPlaced means Prosper accepted an order for a bid.
+	BidPlaced
+	// BidFailed means PlaceBid returned an error. Prosper may still have
+	// placed the bid, so the engine does not bid on the listing again.
+	BidFailed
+	// OrderCompleted means Prosper finished processing an order.
+	OrderCompleted
+	// EngineError means the engine failed to search for listings, retrieve
+	// the account, or check an order.
+	EngineError
+)
+
+var eventTypeStrings = map[EventType]string{
+	ListingRejected: "ListingRejected",
+	BidSkipped:      "BidSkipped",
+	BidDeclined:     "BidDeclined",
+	BidPlaced:       "BidPlaced",
+	BidFailed:       "BidFailed",
+	OrderCompleted:  "OrderCompleted",
+	EngineError:     "EngineError",
+}
+
+func (t EventType) String() string {
+	s, ok := eventTypeStrings[t]
+	if !ok {
+		return "Invalid"
+	}
+	return s
+}
+
+type (
+	// Event reports a decision or outcome of the engine. Only the fields that
+	// apply to the event's Type are set.
+	Event struct {
+		Type    EventType
+		Listing prosper.Listing
+		Bid     prosper.BidRequest
+		Order   prosper.OrderResponse
+		// Reasons explains why a listing was rejected or a bid was skipped.
+		Reasons []string
+		Err     error
+	}
+
+	// Proposal is a bid the engine intends to place.
+	Proposal struct {
+		Listing prosper.Listing
+		Bid     prosper.BidRequest
+		// Reasons explains why the listing satisfies the strategy.
+		Reasons []string
+	}
+
+	// Params contains the parameters to NewEngine.
+	Params struct {
+		// Budget is the most the engine invests over its lifetime. Bids
+		// count against the budget when they are placed, and the parts that
+		// Prosper does not invest are returned to it when their orders
+		// complete. The strategy's Budget also applies over the engine's
+		// lifetime, rather than to each batch of listings.
+		Budget prosper.Money
+		// CashReserve is the part of the account's available cash that the
+		// engine leaves uninvested.
+		CashReserve prosper.Money
+		// MaxExposureByRating limits the outstanding principal of the
+		// account's active notes plus the engine's bids for each rating. Ratings
+		// without an entry are unlimited.
+		MaxExposureByRating map[prosper.Rating]prosper.Money
+		// Score ranks listings, highest first, before the strategy selects
+		// them, so that the best listings get the budget when it runs short.
+		// Defaults to ranking by EstimatedReturn.
+		Score func(prosper.Listing) float64
+		// Approve, if set, is called before each bid is placed. The bid is
+		// placed only if Approve returns true.
+		Approve func(context.Context, Proposal) bool
+		// OnEvent, if set, is called for every Event.
+		OnEvent func(Event)
+		// Logger receives every Event. If nil, events are not logged.
+		Logger logging.Logger
+		// Watch controls how often the engine polls for listings. Its Search
+		// and OnError fields are set by the engine.
+		Watch prosper.WatchParams
+		// OrderPollInterval is the time between checks of pending orders.
+		// Defaults to 30 seconds.
+		OrderPollInterval time.Duration
+	}
+
+	// Engine places bids on the listings that an investment strategy selects.
+	// An Engine is not safe for concurrent use.
+	Engine struct {
+		client   prosper.Client
+		strategy *strategy.Strategy
+		params   Params
+		logger   logging.Logger
+		spent    prosper.Money
+		bids     int
+		exposure map[prosper.Rating]prosper.Money
+		bidOn    map[prosper.ListingNumber]bool
+		pending  map[prosper.OrderID]prosper.Listing
+	}
+)
+
+// NewEngine creates an Engine that invests through c according to s. It fails
+// if s or p is invalid.
+func NewEngine(c prosper.Client, s *strategy.Strategy, p Params) (*Engine, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	if p.Budget <= 0 {
+		return nil, fmt.Errorf("budget must be positive: %s", p.Budget)
+	}
+	if p.CashReserve < 0 {
+		return nil, fmt.Errorf("cash reserve must not be negative: %s", p.CashReserve)
+	}
+	for rating, limit := range p.MaxExposureByRating {
+		if limit < 0 {
+			return nil, fmt.Errorf("exposure limit for rating %s must not be negative: %s", rating, limit)
+		}
+	}
+	if p.Score == nil {
+		p.Score = func(l prosper.Listing) float64 { return l.EstimatedReturn }
+	}
+	if p.OrderPollInterval == 0 {
+		p.OrderPollInterval = defaultOrderPollInterval
+	}
+	return &Engine{
+		client:   c,
+		strategy: s,
+		params:   p,
+		logger:   logging.OrDiscard(p.Logger),
+		exposure: map[prosper.Rating]prosper.Money{},
+		bidOn:    map[prosper.ListingNumber]bool{},
+		pending:  map[prosper.OrderID]prosper.Listing{},
+	}, nil
+}
+
+// Run invests until ctx is cancelled. It shuts down gracefully: it stops
+// watching for listings, places no further bids, checks the status of pending
+// orders one last time, and returns nil. Orders that are still pending are
+// listed by Pending. Run fails only if it cannot load the account's exposure
+// at startup.
+func (e *Engine) Run(ctx context.Context) error {
+	if len(e.params.MaxExposureByRating) > 0 {
+		if err := e.loadExposure(ctx); err != nil {
+			return fmt.Errorf("failed to load exposure: %w", err)
+		}
+	}
+	search, err := e.strategy.SearchParams()
+	if err != nil {
+		return err
+	}
+	watchCtx, stopWatching := context.WithCancel(ctx)
+	defer stopWatching()
+	// Forward the watcher's errors so that every event is emitted from Run's
+	// goroutine.
+	watchErrs := make(chan error)
+	wp := e.params.Watch
+	wp.Search = search
+	wp.OnError = func(err error) {
+		select {
+		case watchErrs <- err:
+		case <-watchCtx.Done():
+		}
+	}
+	listings := prosper.NewListingWatcher(e.client, wp).Watch(watchCtx)
+	ticker := time.NewTicker(e.params.OrderPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-listings:
+			if !ok {
+				e.checkOrders()
+				return nil
+			}
+			e.handle(ctx, append([]prosper.Listing{event.Listing}, drain(listings)...))
+		case err := <-watchErrs:
+			e.emit(Event{Type: EngineError, Err: err})
+		case <-ticker.C:
+			e.checkOrders()
+		case <-ctx.Done():
+			e.checkOrders()
+			return nil
+		}
+	}
+}
+
+// drain returns the listing events that are ready without blocking, so that
+// listings released together are ranked together.
+func drain(events <-chan prosper.ListingEvent) []prosper.Listing {
+	var listings []prosper.Listing
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return listings
+			}
+			listings = append(listings, event.Listing)
+		default:
+			return listings
+		}
+	}
+}
+
+// Spent returns the amount the engine has invested or has pending in orders.
+func (e *Engine) Spent() prosper.Money {
+	return e.spent
+}
+
+// Pending returns the orders that Prosper has not finished processing.
+func (e *Engine) Pending() []prosper.OrderID {
+	var ids prosper.OrderIDs
+	for id := range e.pending {
+		ids = append(ids, id)
+	}
+	sort.Sort(ids)
+	return ids
+}
+
+// loadExposure sums the outstanding principal of the account's active notes by
+// rating.
+func (e *Engine) loadExposure(ctx context.Context) error {
+	it := prosper.AllNotes(ctx, e.client, prosper.NotesParams{})
+	for it.Next() {
+		n := it.Note()
+		if n.NoteStatus.IsTerminal() {
+			continue
+		}
+		e.exposure[n.Rating] += prosper.MoneyFromFloat64(n.PrincipalBalanceProRataShare)
+	}
+	return it.Err()
+}
+
+// handle ranks listings, evaluates them against the strategy, and places the
+// bids that fit the engine's limits. The strategy's budget spans every batch:
+// the amount the engine has spent and the bids it has placed so far count
+// against it.
+func (e *Engine) handle(ctx context.Context, listings []prosper.Listing) {
+	byNumber := map[prosper.ListingNumber]prosper.Listing{}
+	var candidates []prosper.Listing
+	for _, l := range listings {
+		if e.bidOn[l.ListingNumber] {
+			continue
+		}
+		byNumber[l.ListingNumber] = l
+		candidates = append(candidates, l)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return e.params.Score(candidates[i]) > e.params.Score(candidates[j])
+	})
+
+	evaluation := e.strategy.EvaluateRemaining(candidates, e.spent, e.bids)
+	for _, r := range evaluation.Rejected {
+		e.emit(Event{Type: ListingRejected, Listing: byNumber[r.ListingNumber], Reasons: r.Reasons})
+	}
+	if len(evaluation.Bids) == 0 {
+		return
+	}
+	account, err := e.client.Account(prosper.AccountParams{})
+	if err != nil {
+		e.emit(Event{Type: EngineError, Err: fmt.Errorf("failed to retrieve account: %w", err)})
+		return
+	}
+	cash := prosper.MoneyFromFloat64(account.AvailableCashBalance) - e.params.CashReserve
+
+	for _, bid := range evaluation.Bids {
+		if ctx.Err() != nil {
+			return
+		}
+		l := byNumber[bid.ListingID]
+		amount, err := e.fit(l, prosper.MoneyFromFloat64(bid.BidAmount), cash)
+		if err != nil {
+			e.emit(Event{Type: BidSkipped, Listing: l, Bid: bid, Reasons: []string{err.Error()}})
+			continue
+		}
+		bid = prosper.NewBidRequest(l.ListingNumber, amount)
+		if e.params.Approve != nil {
+			p := Proposal{Listing: l, Bid: bid}
+			if e.strategy.Require != nil {
+				p.Reasons = e.strategy.Require.Evaluate(l).Reasons
+			}
+			if !e.params.Approve(ctx, p) {
+				e.emit(Event{Type: BidDeclined, Listing: l, Bid: bid})
+				continue
+			}
+		}
+		order, err := e.client.PlaceBid(bid)
+		if err != nil {
+			// The request may have reached Prosper before it failed, so bidding
+			// again could invest in the listing twice.
+			e.bidOn[l.ListingNumber] = true
+			e.emit(Event{Type: BidFailed, Listing: l, Bid: bid, Err: err})
+			continue
+		}
+		e.bidOn[l.ListingNumber] = true
+		e.spent += amount
+		e.bids++
+		e.exposure[l.Rating] += amount
+		cash -= amount
+		e.emit(Event{Type: BidPlaced, Listing: l, Bid: bid, Order: order})
+		if order.OrderStatus.IsTerminal() {
+			e.complete(l, order)
+		} else {
+			e.pending[order.OrderID] = l
+		}
+	}
+}
+
+// fit shrinks amount to the engine's remaining budget, the available cash, and
+// the exposure limit for l's rating. It fails if the result is below the
+// strategy's minimum bid.
+func (e *Engine) fit(l prosper.Listing, amount, cash prosper.Money) (prosper.Money, error) {
+	type limit struct {
+		name      string
+		remaining prosper.Money
+	}
+	limits := []limit{
+		{"remaining budget", e.params.Budget - e.spent},
+		{"available cash", cash},
+	}
+	if ceiling, ok := e.params.MaxExposureByRating[l.Rating]; ok {
+		limits = append(limits, limit{fmt.Sprintf("remaining exposure to rating %s", l.Rating), ceiling - e.exposure[l.Rating]})
+	}
+	minBid := e.strategy.Bid.MinBid()
+	for _, limit := range limits {
+		if limit.remaining >= amount {
+			continue
+		}
+		if limit.remaining < minBid {
+			return 0, fmt.Errorf("%s of $%s is below the bid minimum of $%s", limit.name, max(limit.remaining, 0), minBid)
+		}
+		amount = limit.remaining - limit.remaining%prosper.Cent
+	}
+	return amount, nil
+}
+
+// checkOrders retrieves the status of each pending order.
+func (e *Engine) checkOrders() {
+	for _, id := range e.Pending() {
+		order, err := e.client.OrderStatus(id)
+		if err != nil {
+			e.emit(Event{Type: EngineError, Listing: e.pending[id], Err: fmt.Errorf("failed to check order %s: %w", id, err)})
+			continue
+		}
+		if order.OrderStatus.IsTerminal() {
+			e.complete(e.pending[id], order)
+			delete(e.pending, id)
+		}
+	}
+}
+
+// complete returns the parts of a completed order's bids that Prosper did not
+// invest to the budget and exposure limits. A bid that Prosper invested none of
+// no longer counts against the strategy's bid limit.
+func (e *Engine) complete(l prosper.Listing, order prosper.OrderResponse) {
+	for _, status := range order.BidStatus {
+		unplaced := prosper.MoneyFromFloat64(status.BidAmount) - prosper.MoneyFromFloat64(status.BidAmountPlaced)
+		if unplaced > 0 {
+			e.spent -= unplaced
+			e.exposure[l.Rating] -= unplaced
+		}
+		if status.BidAmount > 0 && status.BidAmountPlaced == 0 {
+			e.bids--
+		}
+	}
+	e.emit(Event{Type: OrderCompleted, Listing: l, Order: order})
+}
+
+func (e *Engine) emit(event Event) {
+	fields := []logging.Field{logging.F("event", event.Type)}
+	if event.Listing.ListingNumber != 0 {
+		fields = append(fields, logging.F("listing_number", event.Listing.ListingNumber))
+	}
+	if event.Bid.BidAmount != 0 {
+		fields = append(fields, logging.F("bid_amount", event.Bid.BidAmount))
+	}
+	if event.Order.OrderID != "" {
+		fields = append(fields, logging.F("order_id", event.Order.OrderID))
+	}
+	if len(event.Reasons) > 0 {
+		fields = append(fields, logging.F("reasons", event.Reasons))
+	}
+	level := logging.Info
+	switch event.Type {
+	case ListingRejected:
+		level = logging.Debug
+	case BidFailed, EngineError:
+		level = logging.Error
+		fields = append(fields, logging.F("error", event.Err))
+	}
+	e.logger.Log(level, eventMessages[event.Type], fields...)
+	if e.params.OnEvent != nil {
+		e.params.OnEvent(event)
+	}
+}
+
+var eventMessages = map[EventType]string{
+	ListingRejected: "strategy rejected listing",
+	BidSkipped:      "skipped bid that exceeds limits",
+	BidDeclined:     "bid declined by approval hook",
+	BidPlaced:       "placed bid",
+	BidFailed:       "failed to place bid",
+	OrderCompleted:  "order completed",
+	EngineError:     "auto-invest engine error",
+}
@@ -0,0 +1,417 @@
+package autoinvest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtlynch/gofn-prosper/prosper"
+	"github.com/mtlynch/gofn-prosper/prosper/strategy"
+)
+
+// mockClient serves listings and notes, places bids on every listing except
+// those in failBids, and reports the orders in orders, failing for the rest.
+type mockClient struct {
+	listings []prosper.Listing
+	notes    []prosper.Note
+	cash     float64
+	failBids map[prosper.ListingNumber]bool
+	orders   map[prosper.OrderID]prosper.OrderResponse
+	bidsGot  []prosper.BidRequest
+	lock     sync.Mutex
+}
+
+func (c *mockClient) Account(prosper.AccountParams) (prosper.AccountInformation, error) {
+	return prosper.AccountInformation{AvailableCashBalance: c.cash}, nil
+}
+
+func (c *mockClient) BulkNotes(prosper.BulkNotesParams) (prosper.NotesResponse, error) {
+	return prosper.NotesResponse{}, errors.New("mock BulkNotes not implemented")
+}
+
+func (c *mockClient) Listings([]prosper.ListingNumber) ([]prosper.ListingResult, error) {
+	return nil, errors.New("mock Listings not implemented")
+}
+
+func (c *mockClient) MultiSearch(prosper.MultiSearchParams) (prosper.MultiSearchResponse, error) {
+	return prosper.MultiSearchResponse{}, errors.New("mock MultiSearch not implemented")
+}
+
+func (c *mockClient) Notes(p prosper.NotesParams) (prosper.NotesResponse, error) {
+	if p.Offset > 0 {
+		return prosper.NotesResponse{TotalCount: len(c.notes)}, nil
+	}
+	return prosper.NotesResponse{Result: c.notes, ResultCount: len(c.notes), TotalCount: len(c.notes)}, nil
+}
+
+func (c *mockClient) OrderStatus(id prosper.OrderID) (prosper.OrderResponse, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	order, ok := c.orders[id]
+	if !ok {
+		return prosper.OrderResponse{}, errors.New("mock OrderStatus error")
+	}
+	return order, nil
+}
+
+func (c *mockClient) PlaceBid(b prosper.BidRequest) (prosper.OrderResponse, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.bidsGot = append(c.bidsGot, b)
+	if c.failBids[b.ListingID] {
+		return prosper.OrderResponse{}, errors.New("mock PlaceBid error")
+	}
+	return prosper.OrderResponse{OrderID: orderID(b.ListingID), OrderStatus: prosper.OrderInProgress}, nil
+}
+
+func (c *mockClient) Search(p prosper.SearchParams) (prosper.SearchResponse, error) {
+	if p.Offset > 0 {
+		return prosper.SearchResponse{TotalCount: len(c.listings)}, nil
+	}
+	return prosper.SearchResponse{Results: c.listings, ResultCount: len(c.listings), TotalCount: len(c.listings)}, nil
+}
+
+func orderID(n prosper.ListingNumber) prosper.OrderID {
+	return prosper.OrderID(fmt.Sprintf("order-%d", n))
+}
+
+func testListing(n int64, rating prosper.Rating, utilization, estimatedReturn float64) prosper.Listing {
+	return prosper.Listing{
+		ListingNumber:       prosper.ListingNumber(n),
+		Rating:              rating,
+		BankcardUtilization: utilization,
+		EstimatedReturn:     estimatedReturn,
+		AmountRemaining:     1000.0,
+	}
+}
+
+func testStrategy() *strategy.Strategy {
+	return &strategy.Strategy{
+		Name:    "test",
+		Require: prosper.Compare(prosper.FieldBankcardUtilization, prosper.LessThan, 0.6),
+		Bid:     strategy.BidRule{Amount: 50 * prosper.Dollar},
+		Budget:  strategy.Budget{Total: 1000 * prosper.Dollar},
+	}
+}
+
+type eventSummary struct {
+	Type    EventType
+	Listing prosper.ListingNumber
+	Bid     float64
+}
+
+func summarize(events []Event) []eventSummary {
+	var summaries []eventSummary
+	for _, e := range events {
+		summaries = append(summaries, eventSummary{e.Type, e.Listing.ListingNumber, e.Bid.BidAmount})
+	}
+	return summaries
+}
+
+func TestNewEngine(t *testing.T) {
+	var tests = []struct {
+		strategy      *strategy.Strategy
+		params        Params
+		expectSuccess bool
+		msg           string
+	}{
+		{
+			strategy:      testStrategy(),
+			params:        Params{Budget: 100 * prosper.Dollar},
+			expectSuccess: true,
+			msg:           "valid parameters should succeed",
+		},
+		{
+			strategy:      &strategy.Strategy{Name: "invalid"},
+			params:        Params{Budget: 100 * prosper.Dollar},
+			expectSuccess: false,
+			msg:           "invalid strategy should fail",
+		},
+		{
+			strategy:      testStrategy(),
+			params:        Params{},
+			expectSuccess: false,
+			msg:           "missing budget should fail",
+		},
+		{
+			strategy:      testStrategy(),
+			params:        Params{Budget: 100 * prosper.Dollar, CashReserve: -prosper.Dollar},
+			expectSuccess: false,
+			msg:           "negative cash reserve should fail",
+		},
+		{
+			strategy: testStrategy(),
+			params: Params{
+				Budget:              100 * prosper.Dollar,
+				MaxExposureByRating: map[prosper.Rating]prosper.Money{prosper.RatingA: -prosper.Dollar},
+			},
+			expectSuccess: false,
+			msg:           "negative exposure limit should fail",
+		},
+	}
+	for _, tt := range tests {
+		_, err := NewEngine(&mockClient{}, tt.strategy, tt.params)
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s: expected success, got error: %v", tt.msg, err)
+		} else if !tt.expectSuccess && err == nil {
+			t.Errorf("%s: expected failure, got success", tt.msg)
+		}
+	}
+}
+
+func TestEngineHandle(t *testing.T) {
+	c := &mockClient{
+		cash:     150.0,
+		failBids: map[prosper.ListingNumber]bool{5: true},
+	}
+	var events []Event
+	var proposals []Proposal
+	e, err := NewEngine(c, testStrategy(), Params{
+		Budget:              200 * prosper.Dollar,
+		CashReserve:         10 * prosper.Dollar,
+		MaxExposureByRating: map[prosper.Rating]prosper.Money{prosper.RatingA: 60 * prosper.Dollar},
+		Approve: func(_ context.Context, p Proposal) bool {
+			proposals = append(proposals, p)
+			return p.Listing.ListingNumber != 6
+		},
+		OnEvent: func(event Event) { events = append(events, event) },
+	})
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	e.handle(context.Background(), []prosper.Listing{
+		testListing(1, prosper.RatingA, 0.1, 0.05),
+		testListing(2, prosper.RatingB, 0.9, 0.09),
+		testListing(3, prosper.RatingB, 0.1, 0.08),
+		testListing(4, prosper.RatingA, 0.1, 0.07),
+		testListing(5, prosper.RatingC, 0.2, 0.06),
+		testListing(6, prosper.RatingB, 0.1, 0.01),
+	})
+	want := []eventSummary{
+		{ListingRejected, 2, 0},
+		{BidPlaced, 3, 50.0},
+		{BidPlaced, 4, 50.0},
+		{BidFailed, 5, 40.0},
+		{BidSkipped, 1, 50.0},
+		{BidDeclined, 6, 40.0},
+	}
+	if got := summarize(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected events.\ngot:  %+v\nwant: %+v", got, want)
+	}
+	if got, want := events[4].Reasons, []string{"remaining exposure to rating A of $10.00 is below the bid minimum of $25.00"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected reasons for skipped bid. got: %v, want: %v", got, want)
+	}
+	if len(proposals) != 4 || len(proposals[0].Reasons) == 0 {
+		t.Errorf("approval hook should receive each bid with the strategy's reasons, got: %+v", proposals)
+	}
+	wantBids := []prosper.BidRequest{
+		{ListingID: 3, BidAmount: 50.0},
+		{ListingID: 4, BidAmount: 50.0},
+		{ListingID: 5, BidAmount: 40.0},
+	}
+	if !reflect.DeepEqual(c.bidsGot, wantBids) {
+		t.Errorf("unexpected bids placed. got: %+v, want: %+v", c.bidsGot, wantBids)
+	}
+	if got, want := e.Spent(), 100*prosper.Dollar; got != want {
+		t.Errorf("unexpected amount spent. got: %s, want: %s", got, want)
+	}
+	if got, want := e.Pending(), []prosper.OrderID{orderID(3), orderID(4)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected pending orders. got: %v, want: %v", got, want)
+	}
+
+	events = nil
+	e.handle(context.Background(), []prosper.Listing{
+		testListing(3, prosper.RatingB, 0.1, 0.08),
+		testListing(5, prosper.RatingC, 0.2, 0.06),
+	})
+	if len(events) != 0 || len(c.bidsGot) != 3 {
+		t.Errorf("engine should not bid on a listing twice, even after a failed bid, got events: %+v", events)
+	}
+}
+
+func TestEngineStrategyBudgetSpansBatches(t *testing.T) {
+	var tests = []struct {
+		budget   strategy.Budget
+		wantBids []prosper.BidRequest
+		msg      string
+	}{
+		{
+			budget: strategy.Budget{Total: 120 * prosper.Dollar},
+			wantBids: []prosper.BidRequest{
+				{ListingID: 1, BidAmount: 50.0},
+				{ListingID: 2, BidAmount: 50.0},
+			},
+			msg: "strategy total should count bids from earlier batches",
+		},
+		{
+			budget: strategy.Budget{Total: 1000 * prosper.Dollar, MaxBids: 3},
+			wantBids: []prosper.BidRequest{
+				{ListingID: 1, BidAmount: 50.0},
+				{ListingID: 2, BidAmount: 50.0},
+				{ListingID: 3, BidAmount: 50.0},
+			},
+			msg: "strategy bid limit should count bids from earlier batches",
+		},
+	}
+	for _, tt := range tests {
+		c := &mockClient{cash: 1000.0}
+		s := testStrategy()
+		s.Budget = tt.budget
+		e, err := NewEngine(c, s, Params{Budget: 1000 * prosper.Dollar})
+		if err != nil {
+			t.Fatalf("%s: failed to create engine: %v", tt.msg, err)
+		}
+		for _, batch := range [][]prosper.Listing{
+			{testListing(1, prosper.RatingA, 0.1, 0.05), testListing(2, prosper.RatingA, 0.1, 0.05)},
+			{testListing(3, prosper.RatingA, 0.1, 0.05), testListing(4, prosper.RatingA, 0.1, 0.05)},
+			{testListing(5, prosper.RatingA, 0.1, 0.05)},
+		} {
+			e.handle(context.Background(), batch)
+		}
+		if !reflect.DeepEqual(c.bidsGot, tt.wantBids) {
+			t.Errorf("%s: unexpected bids placed. got: %+v, want: %+v", tt.msg, c.bidsGot, tt.wantBids)
+		}
+	}
+}
+
+func TestEngineCheckOrders(t *testing.T) {
+	c := &mockClient{
+		cash: 1000.0,
+		orders: map[prosper.OrderID]prosper.OrderResponse{
+			orderID(3): {
+				OrderID:     orderID(3),
+				OrderStatus: prosper.OrderCompleted,
+				BidStatus: []prosper.BidStatus{
+					{BidRequest: prosper.BidRequest{ListingID: 3, BidAmount: 50.0}, BidAmountPlaced: 30.0},
+				},
+			},
+		},
+	}
+	var events []Event
+	e, err := NewEngine(c, testStrategy(), Params{
+		Budget:  200 * prosper.Dollar,
+		OnEvent: func(event Event) { events = append(events, event) },
+	})
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	e.handle(context.Background(), []prosper.Listing{
+		testListing(3, prosper.RatingB, 0.1, 0.08),
+		testListing(4, prosper.RatingA, 0.1, 0.07),
+	})
+
+	events = nil
+	e.checkOrders()
+	want := []eventSummary{
+		{OrderCompleted, 3, 0},
+		{EngineError, 4, 0},
+	}
+	if got := summarize(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected events.\ngot:  %+v\nwant: %+v", got, want)
+	}
+	if got, want := e.Spent(), 80*prosper.Dollar; got != want {
+		t.Errorf("uninvested part of completed order should be refunded. got: %s, want: %s", got, want)
+	}
+	if got, want := e.Pending(), []prosper.OrderID{orderID(4)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected pending orders. got: %v, want: %v", got, want)
+	}
+}
+
+func TestEngineRejectedBidFreesStrategyBid(t *testing.T) {
+	c := &mockClient{
+		cash: 1000.0,
+		orders: map[prosper.OrderID]prosper.OrderResponse{
+			orderID(1): {
+				OrderID:     orderID(1),
+				OrderStatus: prosper.OrderCompleted,
+				BidStatus: []prosper.BidStatus{
+					{BidRequest: prosper.BidRequest{ListingID: 1, BidAmount: 50.0}, BidAmountPlaced: 0},
+				},
+			},
+		},
+	}
+	s := testStrategy()
+	s.Budget.MaxBids = 1
+	e, err := NewEngine(c, s, Params{Budget: 1000 * prosper.Dollar})
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+	e.handle(context.Background(), []prosper.Listing{testListing(1, prosper.RatingA, 0.1, 0.05)})
+	e.checkOrders()
+	e.handle(context.Background(), []prosper.Listing{testListing(2, prosper.RatingA, 0.1, 0.05)})
+	wantBids := []prosper.BidRequest{
+		{ListingID: 1, BidAmount: 50.0},
+		{ListingID: 2, BidAmount: 50.0},
+	}
+	if !reflect.DeepEqual(c.bidsGot, wantBids) {
+		t.Errorf("bid that Prosper invested none of should not count against the bid limit. got: %+v, want: %+v", c.bidsGot, wantBids)
+	}
+	if got, want := e.Spent(), 50*prosper.Dollar; got != want {
+		t.Errorf("unexpected amount spent. got: %s, want: %s", got, want)
+	}
+}
+
+func TestEngineRun(t *testing.T) {
+	c := &mockClient{
+		cash: 1000.0,
+		listings: []prosper.Listing{
+			testListing(3, prosper.RatingB, 0.1, 0.08),
+			testListing(4, prosper.RatingA, 0.1, 0.07),
+		},
+		notes: []prosper.Note{
+			{Rating: prosper.RatingA, PrincipalBalanceProRataShare: 40.0, NoteStatus: prosper.Current},
+			{Rating: prosper.RatingA, PrincipalBalanceProRataShare: 100.0, NoteStatus: prosper.Completed},
+		},
+		orders: map[prosper.OrderID]prosper.OrderResponse{
+			orderID(3): {OrderID: orderID(3), OrderStatus: prosper.OrderCompleted},
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var events []Event
+	e, err := NewEngine(c, testStrategy(), Params{
+		Budget:              200 * prosper.Dollar,
+		MaxExposureByRating: map[prosper.Rating]prosper.Money{prosper.RatingA: 60 * prosper.Dollar},
+		OnEvent: func(event Event) {
+			events = append(events, event)
+			if event.Type == BidSkipped {
+				cancel()
+			}
+		},
+		OrderPollInterval: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("failed to create engine: %v", err)
+	}
+
+	done := make(chan error)
+	go func() { done <- e.Run(ctx) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected graceful shutdown, got error: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("engine did not shut down after cancellation")
+	}
+
+	want := []eventSummary{
+		{BidPlaced, 3, 50.0},
+		{BidSkipped, 4, 50.0},
+		{OrderCompleted, 3, 0},
+	}
+	if got := summarize(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected events.\ngot:  %+v\nwant: %+v", got, want)
+	}
+	if got, want := events[1].Reasons, []string{"remaining exposure to rating A of $20.00 is below the bid minimum of $25.00"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("exposure should include active notes. got: %v, want: %v", got, want)
+	}
+	if len(e.Pending()) != 0 {
+		t.Errorf("orders should be checked on shutdown, got pending: %v", e.Pending())
+	}
+}
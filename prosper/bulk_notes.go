@@ -0,0 +1,148 @@
+package prosper
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/mtlynch/gofn-prosper/prosper/thin"
+)
+
+const (
+	defaultBulkNotesConcurrency  = 4
+	defaultBulkNotesMaxAttempts  = 3
+	defaultBulkNotesRetryBackoff = 500 * time.Millisecond
+)
+
+// ErrNotesChanged indicates that the user's notes changed while BulkNotes was
+// fetching them, so the pages it retrieved do not form a consistent snapshot.
+// Callers may retry the fetch.
+var ErrNotesChanged = errors.New("notes changed during bulk fetch")
+
+// BulkNotesParams contains the parameters to BulkNotes.
+type BulkNotesParams struct {
+	// PageSize is the number of notes to request per page. Values of zero or
+	// less default to 25.
+	PageSize int
+	// Concurrency is the maximum number of pages to request at once. Values
+	// of zero or less default to 4.
+	Concurrency int
+	// MaxAttempts is the maximum number of times to request a single page
+	// before giving up. Values of zero or less default to 3.
+	MaxAttempts int
+	// RetryBackoff is the time to wait before retrying a failed page. It
+	// doubles after each failed attempt. Values of zero or less default to
+	// 500 milliseconds.
+	RetryBackoff time.Duration
+}
+
+// BulkNoteFetcher supports retrieving all of the user's notes at once.
+type BulkNoteFetcher interface {
+	BulkNotes(BulkNotesParams) (NotesResponse, error)
+}
+
+// BulkNotes retrieves every note that the user owns. It reads the total note
+// count from the first page, then requests the remaining pages concurrently,
+// subject to the client's rate limiter. Pages that fail are retried, with
+// exponential backoff, up to p.MaxAttempts times.
+func (c defaultClient) BulkNotes(p BulkNotesParams) (NotesResponse, error) {
+	if p.PageSize <= 0 {
+		p.PageSize = defaultPageSize
+	}
+	if p.Concurrency <= 0 {
+		p.Concurrency = defaultBulkNotesConcurrency
+	}
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultBulkNotesMaxAttempts
+	}
+	if p.RetryBackoff <= 0 {
+		p.RetryBackoff = defaultBulkNotesRetryBackoff
+	}
+
+	first, err := c.fetchNotesPage(thin.NotesParams{Limit: p.PageSize}, p)
+	if err != nil {
+		return NotesResponse{}, err
+	}
+	var offsets []int
+	for offset := p.PageSize; offset < first.TotalCount; offset += p.PageSize {
+		offsets = append(offsets, offset)
+	}
+
+	pages := make([]NotesResponse, len(offsets))
+	errs := make([]error, len(offsets))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < p.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				pages[i], errs[i] = c.fetchNotesPage(thin.NotesParams{
+					Offset: offsets[i],
+					Limit:  p.PageSize,
+				}, p)
+			}
+		}()
+	}
+	for i := range offsets {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return NotesResponse{}, err
+		}
+	}
+	return mergeNotesPages(append([]NotesResponse{first}, pages...))
+}
+
+func (c defaultClient) fetchNotesPage(p thin.NotesParams, bp BulkNotesParams) (NotesResponse, error) {
+	var raw thin.NotesResponse
+	var err error
+	backoff := bp.RetryBackoff
+	for attempt := 0; attempt < bp.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		c.wait()
+		raw, err = c.rawClient.Notes(p)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return NotesResponse{}, err
+	}
+	return c.notesResponseParser.Parse(raw, ParseStrict)
+}
+
+// mergeNotesPages combines pages of notes, in offset order, into a single
+// NotesResponse. It fails if the pages disagree about the total note count.
+func mergeNotesPages(pages []NotesResponse) (NotesResponse, error) {
+	totalCount := pages[0].TotalCount
+	seen := map[string]bool{}
+	var notes []Note
+	for _, page := range pages {
+		if page.TotalCount != totalCount {
+			return NotesResponse{}, ErrNotesChanged
+		}
+		for _, n := range page.Result {
+			if seen[n.LoanNoteID] {
+				continue
+			}
+			seen[n.LoanNoteID] = true
+			notes = append(notes, n)
+		}
+	}
+	if len(notes) != totalCount {
+		return NotesResponse{}, ErrNotesChanged
+	}
+	return NotesResponse{
+		Result:      notes,
+		ResultCount: len(notes),
+		TotalCount:  totalCount,
+	}, nil
+}
@@ -0,0 +1,158 @@
+package prosper
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtlynch/gofn-prosper/prosper/thin"
+)
+
+type noopRateLimiter struct{}
+
+func (r noopRateLimiter) Wait() {}
+
+// mockBulkNotesRawClient serves pages of a fixed set of raw notes, failing the
+// first failuresPerOffset[offset] requests for a given offset.
+type mockBulkNotesRawClient struct {
+	mockRawClient
+	notes             []thin.NoteResult
+	totalCountDrift   map[int]int
+	failuresPerOffset map[int]int
+	requests          map[int]int
+	lock              sync.Mutex
+}
+
+func (c *mockBulkNotesRawClient) Notes(p thin.NotesParams) (thin.NotesResponse, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.requests[p.Offset]++
+	if c.requests[p.Offset] <= c.failuresPerOffset[p.Offset] {
+		return thin.NotesResponse{}, errMockRawClientFail
+	}
+	var result []thin.NoteResult
+	for i := p.Offset; i < len(c.notes) && i < p.Offset+p.Limit; i++ {
+		result = append(result, c.notes[i])
+	}
+	return thin.NotesResponse{
+		Result:      result,
+		ResultCount: len(result),
+		TotalCount:  len(c.notes) + c.totalCountDrift[p.Offset],
+	}, nil
+}
+
+func TestBulkNotes(t *testing.T) {
+	var rawNotes []thin.NoteResult
+	var wantNotes []Note
+	for i := 0; i < 103; i++ {
+		id := fmt.Sprintf("note-%d", i)
+		rawNotes = append(rawNotes, thin.NoteResult{LoanNoteID: id, Rating: "A", NoteStatus: 1})
+		wantNotes = append(wantNotes, Note{LoanNoteID: id, Rating: RatingA, NoteStatus: Current})
+	}
+	var tests = []struct {
+		params            BulkNotesParams
+		notes             []thin.NoteResult
+		totalCountDrift   map[int]int
+		failuresPerOffset map[int]int
+		want              NotesResponse
+		wantRequests      int
+		wantMinElapsed    time.Duration
+		wantErr           error
+		msg               string
+	}{
+		{
+			params:       BulkNotesParams{PageSize: 10},
+			notes:        nil,
+			want:         NotesResponse{},
+			wantRequests: 1,
+			msg:          "user with no notes should fetch a single page",
+		},
+		{
+			params: BulkNotesParams{PageSize: 10, Concurrency: 3},
+			notes:  rawNotes,
+			want: NotesResponse{
+				Result:      wantNotes,
+				ResultCount: 103,
+				TotalCount:  103,
+			},
+			wantRequests: 11,
+			msg:          "all pages should be fetched and reassembled in order",
+		},
+		{
+			params: BulkNotesParams{PageSize: 10, Concurrency: -1},
+			notes:  rawNotes,
+			want: NotesResponse{
+				Result:      wantNotes,
+				ResultCount: 103,
+				TotalCount:  103,
+			},
+			wantRequests: 11,
+			msg:          "negative concurrency should use the default",
+		},
+		{
+			params:            BulkNotesParams{PageSize: 25, RetryBackoff: 10 * time.Millisecond},
+			notes:             rawNotes,
+			failuresPerOffset: map[int]int{0: 1, 50: 2},
+			want: NotesResponse{
+				Result:      wantNotes,
+				ResultCount: 103,
+				TotalCount:  103,
+			},
+			wantRequests:   8,
+			wantMinElapsed: 40 * time.Millisecond,
+			msg:            "failed pages should be retried with exponential backoff",
+		},
+		{
+			params:            BulkNotesParams{PageSize: 25, MaxAttempts: 2, RetryBackoff: time.Millisecond},
+			notes:             rawNotes,
+			failuresPerOffset: map[int]int{75: 2},
+			wantErr:           errMockRawClientFail,
+			msg:               "page that fails every attempt should fail the fetch",
+		},
+		{
+			params:          BulkNotesParams{PageSize: 25},
+			notes:           rawNotes,
+			totalCountDrift: map[int]int{50: 1},
+			wantErr:         ErrNotesChanged,
+			msg:             "change in total count during fetch should fail",
+		},
+	}
+	for _, tt := range tests {
+		rawClient := mockBulkNotesRawClient{
+			notes:             tt.notes,
+			totalCountDrift:   tt.totalCountDrift,
+			failuresPerOffset: tt.failuresPerOffset,
+			requests:          map[int]int{},
+		}
+		c := defaultClient{
+			rawClient:           &rawClient,
+			notesResponseParser: newNotesResponseParser(defaultNoteParser{}),
+			rateLimiter:         noopRateLimiter{},
+		}
+		start := time.Now()
+		got, err := c.BulkNotes(tt.params)
+		elapsed := time.Since(start)
+		if err != tt.wantErr {
+			t.Errorf("%s: unexpected error. got %v, want %v", tt.msg, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.msg, got, tt.want)
+		}
+		requests := 0
+		for _, n := range rawClient.requests {
+			requests += n
+		}
+		if requests != tt.wantRequests {
+			t.Errorf("%s: unexpected number of requests. got %d, want %d", tt.msg, requests, tt.wantRequests)
+		}
+		if elapsed < tt.wantMinElapsed {
+			t.Errorf("%s: retries should back off. took %v, want at least %v", tt.msg, elapsed, tt.wantMinElapsed)
+		}
+	}
+}
@@ -2,13 +2,19 @@
 package prosper
 
 import (
+	"time"
+
 	"github.com/mtlynch/gofn-prosper/prosper/auth"
+	"github.com/mtlynch/gofn-prosper/prosper/logging"
 	"github.com/mtlynch/gofn-prosper/prosper/thin"
 )
 
 // Client is a Prosper client that communicates with the Prosper HTTP endpoints.
 type Client interface {
 	Account(AccountParams) (AccountInformation, error)
+	BulkNotes(BulkNotesParams) (NotesResponse, error)
+	Listings([]ListingNumber) ([]ListingResult, error)
+	MultiSearch(MultiSearchParams) (MultiSearchResponse, error)
 	Notes(p NotesParams) (NotesResponse, error)
 	OrderStatus(orderID OrderID) (OrderResponse, error)
 	PlaceBid(BidRequest) (OrderResponse, error)
@@ -21,16 +27,87 @@ type defaultClient struct {
 	notesResponseParser notesResponseParser
 	listingParser       listingParser
 	orderParser         orderParser
+	rateLimiter         RateLimiter
+	logger              logging.Logger
+}
+
+// ClientOptions specifies optional settings for a Client.
+type ClientOptions struct {
+	// Logger receives structured events from the client, including API
+	// requests, OAuth token refreshes and parse failures. If nil, events are
+	// discarded.
+	Logger logging.Logger
+	// Location is the time zone in which parsed timestamps are expressed and
+	// time filters are sent to Prosper. If nil, thin.DefaultLocation(),
+	// America/Los_Angeles, is used.
+	Location *time.Location
+	// AcceptUnknownEnums makes the client accept enum values it does not
+	// recognize instead of failing to parse them. Such values parse to the
+	// enum's Unknown variant, their raw values are kept in the
+	// UnknownEnumValues field of the parsed object, and a warning is logged.
+	AcceptUnknownEnums bool
+	// OnUnknownEnum, if non-nil, is called for each unknown enum value the
+	// client accepts. It has no effect unless AcceptUnknownEnums is set.
+	OnUnknownEnum UnknownEnumHandler
+	// StrictDecoding and OnSchemaDrift enable schema drift detection on raw API
+	// responses. See thin.ClientOptions for details.
+	StrictDecoding bool
+	OnSchemaDrift  func(thin.SchemaDrift)
+	// KeepRawJSON makes the client keep the raw JSON Prosper sent for each
+	// account, note, listing and order in the Raw field of the parsed object.
+	KeepRawJSON bool
+	// RateLimiter paces every request the client sends to Prosper. If nil,
+	// the client sends at most 10 requests per second.
+	RateLimiter RateLimiter
 }
 
 // NewClient creates a new Client with the given Prosper credentials.
 func NewClient(creds auth.ClientCredentials) Client {
-	tokenMgr := auth.NewTokenManager(auth.NewAuthenticator(creds))
+	return NewClientWithOptions(creds, ClientOptions{})
+}
+
+// NewClientWithOptions creates a new Client with the given Prosper credentials
+// and options.
+func NewClientWithOptions(creds auth.ClientCredentials, opts ClientOptions) Client {
+	logger := logging.OrDiscard(opts.Logger)
+	location := opts.Location
+	if location == nil {
+		location = thin.DefaultLocation()
+	}
+	unknownEnums := unknownEnumPolicy{
+		accept:    opts.AcceptUnknownEnums,
+		onUnknown: opts.OnUnknownEnum,
+		logger:    logger,
+	}
+	rateLimiter := opts.RateLimiter
+	if rateLimiter == nil {
+		rateLimiter = newIntervalRateLimiter(time.Second / defaultRequestsPerSecond)
+	}
+	tokenMgr := auth.NewTokenManagerWithLogger(auth.NewAuthenticator(creds), logger)
 	return &defaultClient{
-		rawClient:           thin.NewClient(tokenMgr),
+		rawClient: thin.NewClientWithOptions(tokenMgr, thin.ClientOptions{
+			Logger:         logger,
+			Location:       location,
+			StrictDecoding: opts.StrictDecoding,
+			OnSchemaDrift:  opts.OnSchemaDrift,
+			KeepRawJSON:    opts.KeepRawJSON,
+		}),
 		accountParser:       defaultAccountParser{},
-		notesResponseParser: newNotesResponseParser(),
-		listingParser:       defaultListingParser{},
-		orderParser:         defaultOrderParser{},
+		notesResponseParser: newNotesResponseParser(defaultNoteParser{unknownEnums: unknownEnums}),
+		listingParser:       defaultListingParser{location: location, unknownEnums: unknownEnums},
+		orderParser:         defaultOrderParser{location: location, unknownEnums: unknownEnums},
+		rateLimiter:         rateLimiter,
+		logger:              logger,
+	}
+}
+
+// wait blocks until the rate limiter permits another request to Prosper.
+func (c defaultClient) wait() {
+	if c.rateLimiter != nil {
+		c.rateLimiter.Wait()
 	}
 }
+
+func (c defaultClient) log() logging.Logger {
+	return logging.OrDiscard(c.logger)
+}
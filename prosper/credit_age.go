@@ -0,0 +1,93 @@
+package prosper
+
+import (
+	"fmt"
+	"time"
+)
+
+// CreditAgeKind describes how precisely the age of a borrower's oldest credit
+// trade line is known.
+type CreditAgeKind int8
+
+// Set of possible CreditAgeKind values.
+const (
+	CreditAgeUnknown CreditAgeKind = iota
+	CreditAgeExact
+	CreditAgeOlderThanFiveYears
+	CreditAgeOlderThanTenYears
+)
+
+// CreditAge represents the age of the borrower's oldest credit trade line, as
+// reported in the oldest_trade_open_date attribute documented at:
+// https://developers.prosper.com/docs/investor/searchlistings-api/
+// Prosper reports either an exact open date or, for older trade lines, only a
+// lower bound of five or ten years. OpenDate is set only when Kind is
+// CreditAgeExact.
+type CreditAge struct {
+	Kind     CreditAgeKind
+	OpenDate Date
+}
+
+// MinAgeInMonths returns the minimum number of whole months the trade line had
+// been open as of asOf. ok is false if the credit age is unknown.
+func (c CreditAge) MinAgeInMonths(asOf Date) (months int, ok bool) {
+	switch c.Kind {
+	case CreditAgeExact:
+		months = (asOf.Year-c.OpenDate.Year)*12 + int(asOf.Month-c.OpenDate.Month)
+		if asOf.Day < c.OpenDate.Day {
+			months--
+		}
+		if months < 0 {
+			months = 0
+		}
+		return months, true
+	case CreditAgeOlderThanFiveYears:
+		return 5 * 12, true
+	case CreditAgeOlderThanTenYears:
+		return 10 * 12, true
+	}
+	return 0, false
+}
+
+// String returns a string representation of a CreditAge.
+func (c CreditAge) String() string {
+	switch c.Kind {
+	case CreditAgeExact:
+		return fmt.Sprintf("opened %s", c.OpenDate)
+	case CreditAgeOlderThanFiveYears:
+		return "older than 5 years"
+	case CreditAgeOlderThanTenYears:
+		return "older than 10 years"
+	}
+	return "unknown"
+}
+
+// MinCreditAgeInMonths returns the minimum age in months of the borrower's
+// oldest credit trade line as of the listing's credit pull date. ok is false if
+// the credit age or the credit pull date is unknown.
+func (l Listing) MinCreditAgeInMonths() (months int, ok bool) {
+	if l.CreditPullDate.IsZero() {
+		return 0, false
+	}
+	return l.OldestTradeOpenDate.MinAgeInMonths(DateOf(l.CreditPullDate))
+}
+
+// parseCreditAge parses Prosper's oldest_trade_open_date, which is either a
+// date in MMDDYYYY form or one of the sentinel values "1" (older than five
+// years) and "2" (older than ten years). An empty value means Prosper does not
+// know the credit age.
+func parseCreditAge(serialized string) (CreditAge, error) {
+	switch serialized {
+	case "":
+		return CreditAge{Kind: CreditAgeUnknown}, nil
+	case dateOlderThanFiveYears:
+		return CreditAge{Kind: CreditAgeOlderThanFiveYears}, nil
+	case dateOlderThanTenYears:
+		return CreditAge{Kind: CreditAgeOlderThanTenYears}, nil
+	}
+	t, err := time.Parse(prosperOldTimeFormat, serialized)
+	if err != nil {
+		return CreditAge{}, err
+	}
+	return CreditAge{Kind: CreditAgeExact, OpenDate: DateOf(t)}, nil
+}
@@ -0,0 +1,122 @@
+package prosper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCreditAge(t *testing.T) {
+	var tests = []struct {
+		input         string
+		want          CreditAge
+		expectSuccess bool
+		msg           string
+	}{
+		{
+			input:         "03221991",
+			want:          CreditAge{Kind: CreditAgeExact, OpenDate: Date{1991, 3, 22}},
+			expectSuccess: true,
+			msg:           "normal date should parse as exact credit age",
+		},
+		{
+			input:         "27051991",
+			expectSuccess: false,
+			msg:           "date with invalid month should fail",
+		},
+		{
+			input:         "1",
+			want:          CreditAge{Kind: CreditAgeOlderThanFiveYears},
+			expectSuccess: true,
+			msg:           "'1' value should be treated as older than five years",
+		},
+		{
+			input:         "2",
+			want:          CreditAge{Kind: CreditAgeOlderThanTenYears},
+			expectSuccess: true,
+			msg:           "'2' value should be treated as older than ten years",
+		},
+		{
+			input:         "",
+			want:          CreditAge{Kind: CreditAgeUnknown},
+			expectSuccess: true,
+			msg:           "empty value should be treated as unknown credit age",
+		},
+	}
+	for _, tt := range tests {
+		got, err := parseCreditAge(tt.input)
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s - expected successful parsing of %+v, got error: %v", tt.msg, tt.input, err)
+		} else if !tt.expectSuccess && err == nil {
+			t.Errorf("%s - expected failure for %+v, got nil", tt.msg, tt.input)
+		}
+		if tt.expectSuccess && got != tt.want {
+			t.Errorf("%s - parseCreditAge got: %#v, want: %#v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestCreditAgeMinAgeInMonths(t *testing.T) {
+	asOf := Date{2016, 3, 21}
+	var tests = []struct {
+		age        CreditAge
+		wantMonths int
+		wantOk     bool
+		msg        string
+	}{
+		{
+			age:        CreditAge{Kind: CreditAgeExact, OpenDate: Date{2016, 1, 21}},
+			wantMonths: 2,
+			wantOk:     true,
+			msg:        "exact date on same day of month should count full months",
+		},
+		{
+			age:        CreditAge{Kind: CreditAgeExact, OpenDate: Date{2016, 1, 22}},
+			wantMonths: 1,
+			wantOk:     true,
+			msg:        "partial month should not be counted",
+		},
+		{
+			age:        CreditAge{Kind: CreditAgeExact, OpenDate: Date{2016, 4, 1}},
+			wantMonths: 0,
+			wantOk:     true,
+			msg:        "open date after as-of date should count zero months",
+		},
+		{
+			age:        CreditAge{Kind: CreditAgeOlderThanFiveYears},
+			wantMonths: 60,
+			wantOk:     true,
+			msg:        "older than five years should be at least 60 months",
+		},
+		{
+			age:        CreditAge{Kind: CreditAgeOlderThanTenYears},
+			wantMonths: 120,
+			wantOk:     true,
+			msg:        "older than ten years should be at least 120 months",
+		},
+		{
+			age:    CreditAge{},
+			wantOk: false,
+			msg:    "unknown credit age should not report an age",
+		},
+	}
+	for _, tt := range tests {
+		months, ok := tt.age.MinAgeInMonths(asOf)
+		if months != tt.wantMonths || ok != tt.wantOk {
+			t.Errorf("%s - MinAgeInMonths got: (%d, %v), want: (%d, %v)", tt.msg, months, ok, tt.wantMonths, tt.wantOk)
+		}
+	}
+}
+
+func TestListingMinCreditAgeInMonths(t *testing.T) {
+	l := Listing{
+		CreditPullDate:      time.Date(2016, 3, 21, 10, 0, 0, 0, time.UTC),
+		OldestTradeOpenDate: CreditAge{Kind: CreditAgeExact, OpenDate: Date{2006, 3, 21}},
+	}
+	if months, ok := l.MinCreditAgeInMonths(); months != 120 || !ok {
+		t.Errorf("MinCreditAgeInMonths got: (%d, %v), want: (120, true)", months, ok)
+	}
+	l.CreditPullDate = time.Time{}
+	if _, ok := l.MinCreditAgeInMonths(); ok {
+		t.Errorf("MinCreditAgeInMonths should fail without a credit pull date")
+	}
+}
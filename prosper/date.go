@@ -0,0 +1,71 @@
+package prosper
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date represents a calendar date with no time of day or location, such as the
+// due date of a note payment. Prosper defines its calendar dates as business
+// dates in Pacific time; use In to find the instant a date begins in a given
+// location.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the calendar date of t in t's location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{year, month, day}
+}
+
+// IsZero returns true if d is the zero Date, which represents a missing date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// In returns the time at which d begins (midnight) in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns the date n days after d. n may be negative.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// Before returns true if d is earlier than o.
+func (d Date) Before(o Date) bool {
+	return d.In(time.UTC).Before(o.In(time.UTC))
+}
+
+// After returns true if d is later than o.
+func (d Date) After(o Date) bool {
+	return o.Before(d)
+}
+
+// String returns d in the form "2006-01-02", or an empty string if d is the
+// zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := parseProsperDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,57 @@
+package prosper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateString(t *testing.T) {
+	var tests = []struct {
+		d    Date
+		want string
+	}{
+		{Date{2015, 7, 23}, "2015-07-23"},
+		{Date{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.d.String(); got != tt.want {
+			t.Errorf("Date(%#v).String() = %q, want %q", tt.d, got, tt.want)
+		}
+		var parsed Date
+		if err := parsed.UnmarshalText([]byte(tt.want)); err != nil {
+			t.Errorf("UnmarshalText(%q) failed: %v", tt.want, err)
+		} else if parsed != tt.d {
+			t.Errorf("UnmarshalText(%q) = %#v, want %#v", tt.want, parsed, tt.d)
+		}
+	}
+}
+
+func TestDateIn(t *testing.T) {
+	pacific := time.FixedZone("PDT", -7*60*60)
+	eastern := time.FixedZone("EDT", -4*60*60)
+	due := Date{2015, 7, 23}
+
+	start := due.In(pacific)
+	if got := DateOf(start.In(eastern)); got != due {
+		t.Errorf("payment due date seen from Eastern time = %v, want %v", got, due)
+	}
+	if got := DateOf(due.In(time.UTC).In(eastern)); got == due {
+		t.Errorf("UTC midnight seen from Eastern time should fall on the previous day")
+	}
+}
+
+func TestDateArithmetic(t *testing.T) {
+	d := Date{2016, 2, 28}
+	if got, want := d.AddDays(1), (Date{2016, 2, 29}); got != want {
+		t.Errorf("AddDays(1) = %v, want %v", got, want)
+	}
+	if got, want := d.AddDays(2), (Date{2016, 3, 1}); got != want {
+		t.Errorf("AddDays(2) = %v, want %v", got, want)
+	}
+	if got, want := d.AddDays(-28), (Date{2016, 1, 31}); got != want {
+		t.Errorf("AddDays(-28) = %v, want %v", got, want)
+	}
+	if !d.Before(d.AddDays(1)) || d.Before(d) || !d.AddDays(1).After(d) {
+		t.Errorf("Before/After returned unexpected results for %v", d)
+	}
+}
@@ -0,0 +1,723 @@
+package prosper
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// The enum types in this package marshal to text (and therefore to JSON) using
+// the strings Prosper uses on the wire where the API defines them, such as
+// "AA" for RatingAA or "INVESTED" for Invested. For enums that Prosper
+// transmits as integers, the text form is the matching *_description string.
+// When unmarshaling JSON, the legacy integer representation is also accepted.
+// Enums whose zero value is not a Prosper value, such as ListingStatus,
+// marshal the zero value to an empty string, which unmarshals back to the zero
+// value, so that zero-valued structs such as an empty Listing can be encoded.
+
+// unmarshalEnumJSON decodes data as either a JSON string, which it passes to
+// fromText, or a JSON integer, which it passes to fromInt.
+func unmarshalEnumJSON(data []byte, fromText func([]byte) error, fromInt func(int64) error) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return fromText([]byte(s))
+	}
+	var i int64
+	if err := json.Unmarshal(data, &i); err != nil {
+		return fmt.Errorf("expected string or integer enum value, got: %s", data)
+	}
+	return fromInt(i)
+}
+
+var ratingStrings = map[Rating]string{
+	RatingAA:      "AA",
+	RatingA:       "A",
+	RatingB:       "B",
+	RatingC:       "C",
+	RatingD:       "D",
+	RatingE:       "E",
+	RatingHR:      "HR",
+	RatingNA:      "N/A",
+	RatingUnknown: unknownEnumText,
+}
+
+// String returns the Prosper representation of a Rating, such as "AA".
+func (r Rating) String() string {
+	s, ok := ratingStrings[r]
+	if !ok {
+		return "Invalid"
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r Rating) MarshalText() ([]byte, error) {
+	s, ok := ratingStrings[r]
+	if !ok {
+		return nil, fmt.Errorf("invalid Prosper rating: %d", r)
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *Rating) UnmarshalText(text []byte) error {
+	if string(text) == unknownEnumText {
+		*r = RatingUnknown
+		return nil
+	}
+	parsed, err := parseRating(string(text))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Rating) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, r.UnmarshalText, func(i int64) error {
+		if _, ok := ratingStrings[Rating(i)]; !ok || i != int64(Rating(i)) {
+			return fmt.Errorf("invalid Prosper rating: %d", i)
+		}
+		*r = Rating(i)
+		return nil
+	})
+}
+
+var ficoScoreStrings = map[FicoScore]string{
+	Below600:         "<600",
+	Between600And619: "600-619",
+	Between620And639: "620-639",
+	Between640And659: "640-659",
+	Between660And679: "660-679",
+	Between680And699: "680-699",
+	Between700And719: "700-719",
+	Between720And739: "720-739",
+	Between740And759: "740-759",
+	Between760And779: "760-779",
+	Between780And799: "780-799",
+	Between800And819: "800-819",
+	Between820And850: "820-850",
+	FicoScoreUnknown: unknownEnumText,
+}
+
+// String returns the Prosper representation of a FicoScore, such as
+// "700-719".
+func (f FicoScore) String() string {
+	s, ok := ficoScoreStrings[f]
+	if !ok {
+		return "Invalid"
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f FicoScore) MarshalText() ([]byte, error) {
+	s, ok := ficoScoreStrings[f]
+	if !ok {
+		return nil, fmt.Errorf("invalid FICO score: %d", f)
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *FicoScore) UnmarshalText(text []byte) error {
+	if string(text) == unknownEnumText {
+		*f = FicoScoreUnknown
+		return nil
+	}
+	parsed, err := parseFicoScore(string(text))
+	if err != nil {
+		return err
+	}
+	*f = parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *FicoScore) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, f.UnmarshalText, func(i int64) error {
+		if _, ok := ficoScoreStrings[FicoScore(i)]; !ok || i != int64(FicoScore(i)) {
+			return fmt.Errorf("invalid FICO score: %d", i)
+		}
+		*f = FicoScore(i)
+		return nil
+	})
+}
+
+var bidStatusValueStrings = map[BidStatusValue]string{
+	Pending:          "PENDING",
+	Invested:         "INVESTED",
+	Expired:          "EXPIRED",
+	BidStatusUnknown: unknownEnumText,
+}
+
+// String returns the Prosper representation of a BidStatusValue, such as
+// "INVESTED".
+func (s BidStatusValue) String() string {
+	str, ok := bidStatusValueStrings[s]
+	if !ok {
+		return "Invalid"
+	}
+	return str
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s BidStatusValue) MarshalText() ([]byte, error) {
+	str, ok := bidStatusValueStrings[s]
+	if !ok {
+		return nil, fmt.Errorf("invalid bid status value: %d", s)
+	}
+	return []byte(str), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *BidStatusValue) UnmarshalText(text []byte) error {
+	if string(text) == unknownEnumText {
+		*s = BidStatusUnknown
+		return nil
+	}
+	parsed, err := parseBidStatusValue(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *BidStatusValue) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, s.UnmarshalText, func(i int64) error {
+		if _, ok := bidStatusValueStrings[BidStatusValue(i)]; !ok || i != int64(BidStatusValue(i)) {
+			return fmt.Errorf("invalid bid status value: %d", i)
+		}
+		*s = BidStatusValue(i)
+		return nil
+	})
+}
+
+// IsTerminal returns true if the bid has reached a final state and its status
+// will no longer change.
+func (s BidStatusValue) IsTerminal() bool {
+	return s == Invested || s == Expired
+}
+
+var bidResultStrings = map[BidResult]string{
+	NoBidResult:                     "NONE",
+	AmountBidTooHigh:                "AMOUNT_BID_TOO_HIGH",
+	AmountBidTooLow:                 "AMOUNT_BID_TOO_LOW",
+	BidFailed:                       "BID_FAILED",
+	BidSucceeded:                    "BID_SUCCEEDED",
+	CannotBidOnSelf:                 "CANNOT_BID_ON_SELF",
+	InsufficientFunds:               "INSUFFICIENT_FUNDS",
+	InternalError:                   "INTERNAL_ERROR",
+	InvestmentOrderAlreadyProcessed: "INVESTMENT_ORDER_ALREADY_PROCESSED",
+	LenderNotEligibleToBid:          "LENDER_NOT_ELIGIBLE_TO_BID",
+	ListingNotBiddable:              "LISTING_NOT_BIDDABLE",
+	SuitabilityRequirementsNotMet:   "SUITABILITY_REQUIREMENTS_NOT_MET",
+	PartialBidSucceeded:             "PARTIAL_BID_SUCCEEDED",
+	BidResultUnknown:                unknownEnumText,
+}
+
+// String returns the Prosper representation of a BidResult, such as
+// "BID_SUCCEEDED".
+func (r BidResult) String() string {
+	s, ok := bidResultStrings[r]
+	if !ok {
+		return "Invalid"
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r BidResult) MarshalText() ([]byte, error) {
+	s, ok := bidResultStrings[r]
+	if !ok {
+		return nil, fmt.Errorf("invalid bid result value: %d", r)
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *BidResult) UnmarshalText(text []byte) error {
+	if string(text) == unknownEnumText {
+		*r = BidResultUnknown
+		return nil
+	}
+	parsed, err := parseBidResult(string(text))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *BidResult) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, r.UnmarshalText, func(i int64) error {
+		if _, ok := bidResultStrings[BidResult(i)]; !ok || i != int64(BidResult(i)) {
+			return fmt.Errorf("invalid bid result value: %d", i)
+		}
+		*r = BidResult(i)
+		return nil
+	})
+}
+
+// IsSuccess returns true if the bid was placed for all or part of the
+// requested amount.
+func (r BidResult) IsSuccess() bool {
+	return r == BidSucceeded || r == PartialBidSucceeded
+}
+
+var orderStatusStrings = map[OrderStatus]string{
+	OrderInProgress:    "IN_PROGRESS",
+	OrderCompleted:     "COMPLETED",
+	OrderStatusUnknown: unknownEnumText,
+}
+
+// String returns the Prosper representation of an OrderStatus, such as
+// "COMPLETED".
+func (s OrderStatus) String() string {
+	str, ok := orderStatusStrings[s]
+	if !ok {
+		return "Invalid"
+	}
+	return str
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s OrderStatus) MarshalText() ([]byte, error) {
+	str, ok := orderStatusStrings[s]
+	if !ok {
+		return nil, fmt.Errorf("invalid order status value: %d", s)
+	}
+	return []byte(str), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *OrderStatus) UnmarshalText(text []byte) error {
+	if string(text) == unknownEnumText {
+		*s = OrderStatusUnknown
+		return nil
+	}
+	parsed, err := parseOrderStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *OrderStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, s.UnmarshalText, func(i int64) error {
+		if _, ok := orderStatusStrings[OrderStatus(i)]; !ok || i != int64(OrderStatus(i)) {
+			return fmt.Errorf("invalid order status value: %d", i)
+		}
+		*s = OrderStatus(i)
+		return nil
+	})
+}
+
+// IsTerminal returns true if Prosper has finished processing the order.
+func (s OrderStatus) IsTerminal() bool {
+	return s == OrderCompleted
+}
+
+var incomeRangeStrings = map[IncomeRange]string{
+	NotDisplayed:       "Not displayed",
+	ZeroIncome:         "$0",
+	Between0And25k:     "$1-24,999",
+	Between25kAnd50k:   "$25,000-49,999",
+	Between50kAnd75k:   "$50,000-74,999",
+	Between75kAnd100k:  "$75,000-99,999",
+	Over100k:           "$100,000+",
+	NotEmployed:        "Not employed",
+	IncomeRangeUnknown: unknownEnumText,
+}
+
+// String returns the Prosper description of an IncomeRange, such as
+// "$25,000-49,999".
+func (r IncomeRange) String() string {
+	s, ok := incomeRangeStrings[r]
+	if !ok {
+		return "Invalid"
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r IncomeRange) MarshalText() ([]byte, error) {
+	s, ok := incomeRangeStrings[r]
+	if !ok {
+		return nil, fmt.Errorf("invalid income range: %d", r)
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *IncomeRange) UnmarshalText(text []byte) error {
+	for v, s := range incomeRangeStrings {
+		if s == string(text) {
+			*r = v
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized income range: %s", text)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *IncomeRange) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, r.UnmarshalText, func(i int64) error {
+		parsed, err := parseIncomeRange(i)
+		if err != nil {
+			return err
+		}
+		*r = parsed
+		return nil
+	})
+}
+
+var listingStatusStrings = map[ListingStatus]string{
+	ListingActive:                    "ACTIVE",
+	ListingWithdrawn:                 "WITHDRAWN",
+	ListingExpired:                   "EXPIRED",
+	ListingCompleted:                 "COMPLETED",
+	ListingCancelled:                 "CANCELLED",
+	ListingPendingReviewOrAcceptance: "PENDING_REVIEW_OR_ACCEPTANCE",
+	ListingStatusUnknown:             unknownEnumText,
+}
+
+// String returns a string representation of a ListingStatus, such as
+// "ACTIVE".
+func (s ListingStatus) String() string {
+	str, ok := listingStatusStrings[s]
+	if !ok {
+		return "Invalid"
+	}
+	return str
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ListingStatus) MarshalText() ([]byte, error) {
+	if s == 0 {
+		return []byte{}, nil
+	}
+	str, ok := listingStatusStrings[s]
+	if !ok {
+		return nil, fmt.Errorf("invalid listing status: %d", s)
+	}
+	return []byte(str), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ListingStatus) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*s = 0
+		return nil
+	}
+	for v, str := range listingStatusStrings {
+		if str == string(text) {
+			*s = v
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized listing status: %s", text)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ListingStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, s.UnmarshalText, func(i int64) error {
+		if _, ok := listingStatusStrings[ListingStatus(i)]; !ok || i != int64(ListingStatus(i)) {
+			return fmt.Errorf("invalid listing status: %d", i)
+		}
+		*s = ListingStatus(i)
+		return nil
+	})
+}
+
+var noteStatusStrings = map[NoteStatus]string{
+	OriginationDelayed:     "ORIGINATIONDELAYED",
+	Current:                "CURRENT",
+	Chargeoff:              "CHARGEOFF",
+	Defaulted:              "DEFAULTED",
+	Completed:              "COMPLETED",
+	FinalPaymentInProgress: "FINALPAYMENTINPROGRESS",
+	Cancelled:              "CANCELLED",
+	NoteStatusUnknown:      unknownEnumText,
+}
+
+// String returns the Prosper description of a NoteStatus, such as "CURRENT".
+func (s NoteStatus) String() string {
+	str, ok := noteStatusStrings[s]
+	if !ok {
+		return "Invalid"
+	}
+	return str
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s NoteStatus) MarshalText() ([]byte, error) {
+	str, ok := noteStatusStrings[s]
+	if !ok {
+		return nil, fmt.Errorf("invalid note status: %d", s)
+	}
+	return []byte(str), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *NoteStatus) UnmarshalText(text []byte) error {
+	for v, str := range noteStatusStrings {
+		if str == string(text) {
+			*s = v
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized note status: %s", text)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *NoteStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, s.UnmarshalText, func(i int64) error {
+		parsed, err := parseNoteStatus(i)
+		if err != nil {
+			return err
+		}
+		*s = parsed
+		return nil
+	})
+}
+
+// IsTerminal returns true if the note has reached a final state and will
+// receive no further scheduled payments.
+func (s NoteStatus) IsTerminal() bool {
+	switch s {
+	case Chargeoff, Defaulted, Completed, Cancelled:
+		return true
+	}
+	return false
+}
+
+var defaultReasonStrings = map[DefaultReason]string{
+	Delinquency:          "Delinquency",
+	Bankruptcy:           "Bankruptcy",
+	Deceased:             "Deceased",
+	Repurchased:          "Repurchased",
+	PaidInFull:           "PaidInFull",
+	SettledInFull:        "SettledInFull",
+	Sold:                 "Sold",
+	DefaultReasonUnknown: unknownEnumText,
+}
+
+// String returns the Prosper description of a DefaultReason, such as
+// "Bankruptcy".
+func (r DefaultReason) String() string {
+	s, ok := defaultReasonStrings[r]
+	if !ok {
+		return "Invalid"
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r DefaultReason) MarshalText() ([]byte, error) {
+	if r == 0 {
+		return []byte{}, nil
+	}
+	s, ok := defaultReasonStrings[r]
+	if !ok {
+		return nil, fmt.Errorf("invalid default reason: %d", r)
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *DefaultReason) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*r = 0
+		return nil
+	}
+	for v, s := range defaultReasonStrings {
+		if s == string(text) {
+			*r = v
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized default reason: %s", text)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *DefaultReason) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, r.UnmarshalText, func(i int64) error {
+		if _, ok := defaultReasonStrings[DefaultReason(i)]; !ok {
+			return fmt.Errorf("default reason out of range: %d, expected %d-%d", i, DefaultReasonMin, DefaultReasonMax)
+		}
+		*r = DefaultReason(i)
+		return nil
+	})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v VerificationStage) MarshalText() ([]byte, error) {
+	if v == 0 {
+		return []byte{}, nil
+	}
+	if v == VerificationStageUnknown {
+		return []byte(unknownEnumText), nil
+	}
+	if _, err := parseVerificationStage(int64(v)); err != nil {
+		return nil, err
+	}
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *VerificationStage) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*v = 0
+		return nil
+	}
+	if string(text) == unknownEnumText {
+		*v = VerificationStageUnknown
+		return nil
+	}
+	for s := VerificationStageMin; s <= VerificationStageMax; s++ {
+		if s.String() == string(text) {
+			*v = s
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized verification stage: %s", text)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *VerificationStage) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, v.UnmarshalText, func(i int64) error {
+		parsed, err := parseVerificationStage(i)
+		if err != nil {
+			return err
+		}
+		*v = parsed
+		return nil
+	})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t InvestmentType) MarshalText() ([]byte, error) {
+	if t == 0 {
+		return []byte{}, nil
+	}
+	if t == InvestmentTypeUnknown {
+		return []byte(unknownEnumText), nil
+	}
+	if _, err := parseInvestmentType(int64(t)); err != nil {
+		return nil, err
+	}
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *InvestmentType) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = 0
+		return nil
+	}
+	if string(text) == unknownEnumText {
+		*t = InvestmentTypeUnknown
+		return nil
+	}
+	for _, it := range []InvestmentType{InvestmentFractional, InvestmentWhole} {
+		if it.String() == string(text) {
+			*t = it
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized investment type: %s", text)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *InvestmentType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, t.UnmarshalText, func(i int64) error {
+		parsed, err := parseInvestmentType(i)
+		if err != nil {
+			return err
+		}
+		*t = parsed
+		return nil
+	})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l LenderIndicator) MarshalText() ([]byte, error) {
+	if l == LenderIndicatorUnknown {
+		return []byte(unknownEnumText), nil
+	}
+	if _, err := parseLenderIndicator(int64(l)); err != nil {
+		return nil, err
+	}
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (l *LenderIndicator) UnmarshalText(text []byte) error {
+	if string(text) == unknownEnumText {
+		*l = LenderIndicatorUnknown
+		return nil
+	}
+	for _, li := range []LenderIndicator{BorrowerIsNotLender, BorrowerIsLender} {
+		if li.String() == string(text) {
+			*l = li
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized lender indicator: %s", text)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *LenderIndicator) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, l.UnmarshalText, func(i int64) error {
+		parsed, err := parseLenderIndicator(i)
+		if err != nil {
+			return err
+		}
+		*l = parsed
+		return nil
+	})
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c ListingCategory) MarshalText() ([]byte, error) {
+	if c == ListingCategoryUnknown {
+		return []byte(unknownEnumText), nil
+	}
+	if _, err := parseListingCategory(int64(c)); err != nil {
+		return nil, err
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *ListingCategory) UnmarshalText(text []byte) error {
+	if string(text) == unknownEnumText {
+		*c = ListingCategoryUnknown
+		return nil
+	}
+	for lc := ListingCategoryMin; lc <= ListingCategoryMax; lc++ {
+		if lc.String() == string(text) {
+			*c = lc
+			return nil
+		}
+	}
+	return fmt.Errorf("unrecognized listing category: %s", text)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *ListingCategory) UnmarshalJSON(data []byte) error {
+	return unmarshalEnumJSON(data, c.UnmarshalText, func(i int64) error {
+		parsed, err := parseListingCategory(i)
+		if err != nil {
+			return err
+		}
+		*c = parsed
+		return nil
+	})
+}
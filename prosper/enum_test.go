@@ -0,0 +1,209 @@
+package prosper
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEnumTextRoundTrip(t *testing.T) {
+	var tests = []struct {
+		value    encoding.TextMarshaler
+		newValue func() encoding.TextUnmarshaler
+		wantText string
+	}{
+		{RatingAA, func() encoding.TextUnmarshaler { return new(Rating) }, "AA"},
+		{RatingHR, func() encoding.TextUnmarshaler { return new(Rating) }, "HR"},
+		{RatingNA, func() encoding.TextUnmarshaler { return new(Rating) }, "N/A"},
+		{Below600, func() encoding.TextUnmarshaler { return new(FicoScore) }, "<600"},
+		{Between820And850, func() encoding.TextUnmarshaler { return new(FicoScore) }, "820-850"},
+		{Over100k, func() encoding.TextUnmarshaler { return new(IncomeRange) }, "$100,000+"},
+		{ListingActive, func() encoding.TextUnmarshaler { return new(ListingStatus) }, "ACTIVE"},
+		{Chargeoff, func() encoding.TextUnmarshaler { return new(NoteStatus) }, "CHARGEOFF"},
+		{Bankruptcy, func() encoding.TextUnmarshaler { return new(DefaultReason) }, "Bankruptcy"},
+		{Invested, func() encoding.TextUnmarshaler { return new(BidStatusValue) }, "INVESTED"},
+		{PartialBidSucceeded, func() encoding.TextUnmarshaler { return new(BidResult) }, "PARTIAL_BID_SUCCEEDED"},
+		{OrderInProgress, func() encoding.TextUnmarshaler { return new(OrderStatus) }, "IN_PROGRESS"},
+		{VerificationStageTwo, func() encoding.TextUnmarshaler { return new(VerificationStage) }, "Stage 2"},
+		{InvestmentWhole, func() encoding.TextUnmarshaler { return new(InvestmentType) }, "Whole"},
+		{BorrowerIsLender, func() encoding.TextUnmarshaler { return new(LenderIndicator) }, "Lender"},
+		{CategoryMedicalDental, func() encoding.TextUnmarshaler { return new(ListingCategory) }, "Medical/Dental"},
+		{BidResultUnknown, func() encoding.TextUnmarshaler { return new(BidResult) }, "Unknown"},
+		{NoteStatusUnknown, func() encoding.TextUnmarshaler { return new(NoteStatus) }, "Unknown"},
+		{ListingCategoryUnknown, func() encoding.TextUnmarshaler { return new(ListingCategory) }, "Unknown"},
+		{ListingStatus(0), func() encoding.TextUnmarshaler { return new(ListingStatus) }, ""},
+		{DefaultReason(0), func() encoding.TextUnmarshaler { return new(DefaultReason) }, ""},
+		{VerificationStage(0), func() encoding.TextUnmarshaler { return new(VerificationStage) }, ""},
+		{InvestmentType(0), func() encoding.TextUnmarshaler { return new(InvestmentType) }, ""},
+	}
+	for _, tt := range tests {
+		text, err := tt.value.MarshalText()
+		if err != nil {
+			t.Errorf("MarshalText(%#v) failed: %v", tt.value, err)
+			continue
+		}
+		if string(text) != tt.wantText {
+			t.Errorf("MarshalText(%#v) = %q, want %q", tt.value, text, tt.wantText)
+		}
+		got := tt.newValue()
+		if err := got.UnmarshalText(text); err != nil {
+			t.Errorf("UnmarshalText(%q) failed: %v", text, err)
+			continue
+		}
+		if v := reflect.ValueOf(got).Elem().Interface(); v != tt.value {
+			t.Errorf("UnmarshalText(%q) = %#v, want %#v", text, v, tt.value)
+		}
+	}
+}
+
+func TestZeroValueJSONRoundTrip(t *testing.T) {
+	var tests = []struct {
+		value    interface{}
+		newValue func() interface{}
+	}{
+		{Listing{}, func() interface{} { return new(Listing) }},
+		{ListingResult{}, func() interface{} { return new(ListingResult) }},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.value)
+		if err != nil {
+			t.Errorf("json.Marshal(%T{}) failed: %v", tt.value, err)
+			continue
+		}
+		got := tt.newValue()
+		if err := json.Unmarshal(data, got); err != nil {
+			t.Errorf("json.Unmarshal(%s) failed: %v", data, err)
+			continue
+		}
+		if v := reflect.ValueOf(got).Elem().Interface(); !reflect.DeepEqual(v, tt.value) {
+			t.Errorf("%T{} did not survive a JSON round trip, got %+v", tt.value, v)
+		}
+	}
+}
+
+func TestEnumMarshalTextInvalid(t *testing.T) {
+	var tests = []encoding.TextMarshaler{
+		Rating(42),
+		FicoScoreInvalid,
+		IncomeRangeInvalid,
+		ListingStatus(3),
+		NoteStatusInvalid,
+		DefaultReason(42),
+		BidStatusValue(42),
+		BidResult(42),
+		OrderStatus(42),
+		VerificationStageInvalid,
+		InvestmentTypeInvalid,
+		LenderIndicatorInvalid,
+		ListingCategoryInvalid,
+	}
+	for _, v := range tests {
+		if _, err := v.MarshalText(); err == nil {
+			t.Errorf("MarshalText(%#v) should fail for invalid value", v)
+		}
+	}
+}
+
+func TestEnumJSON(t *testing.T) {
+	type enums struct {
+		Rating        Rating
+		FicoScore     FicoScore
+		NoteStatus    NoteStatus
+		DefaultReason *DefaultReason
+		BidResult     BidResult
+	}
+	bankruptcy := Bankruptcy
+	var tests = []struct {
+		json          string
+		want          enums
+		expectSuccess bool
+		msg           string
+	}{
+		{
+			json: `{"Rating":"HR","FicoScore":"700-719","NoteStatus":"CURRENT","DefaultReason":"Bankruptcy","BidResult":"BID_SUCCEEDED"}`,
+			want: enums{
+				Rating:        RatingHR,
+				FicoScore:     Between700And719,
+				NoteStatus:    Current,
+				DefaultReason: &bankruptcy,
+				BidResult:     BidSucceeded,
+			},
+			expectSuccess: true,
+			msg:           "string values should unmarshal",
+		},
+		{
+			json: `{"Rating":6,"FicoScore":6,"NoteStatus":1,"DefaultReason":2,"BidResult":4}`,
+			want: enums{
+				Rating:        RatingHR,
+				FicoScore:     Between700And719,
+				NoteStatus:    Current,
+				DefaultReason: &bankruptcy,
+				BidResult:     BidSucceeded,
+			},
+			expectSuccess: true,
+			msg:           "legacy integer values should unmarshal",
+		},
+		{
+			json:          `{"Rating":"Z"}`,
+			expectSuccess: false,
+			msg:           "unrecognized string should fail",
+		},
+		{
+			json:          `{"Rating":300}`,
+			expectSuccess: false,
+			msg:           "out of range integer should fail",
+		},
+		{
+			json:          `{"NoteStatus":true}`,
+			expectSuccess: false,
+			msg:           "non-string, non-integer value should fail",
+		},
+	}
+	for _, tt := range tests {
+		var got enums
+		err := json.Unmarshal([]byte(tt.json), &got)
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s: json.Unmarshal failed: %v", tt.msg, err)
+			continue
+		} else if !tt.expectSuccess {
+			if err == nil {
+				t.Errorf("%s: expected json.Unmarshal to fail", tt.msg)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.msg, got, tt.want)
+		}
+		encoded, err := json.Marshal(got)
+		if err != nil {
+			t.Errorf("%s: json.Marshal failed: %v", tt.msg, err)
+			continue
+		}
+		wantEncoded := `{"Rating":"HR","FicoScore":"700-719","NoteStatus":"CURRENT","DefaultReason":"Bankruptcy","BidResult":"BID_SUCCEEDED"}`
+		if string(encoded) != wantEncoded {
+			t.Errorf("%s: json.Marshal = %s, want %s", tt.msg, encoded, wantEncoded)
+		}
+	}
+}
+
+func TestEnumPredicates(t *testing.T) {
+	for s := NoteStatusMin; s <= NoteStatusMax; s++ {
+		want := s == Chargeoff || s == Defaulted || s == Completed || s == Cancelled
+		if got := s.IsTerminal(); got != want {
+			t.Errorf("NoteStatus(%v).IsTerminal() = %v, want %v", s, got, want)
+		}
+	}
+	for r := range bidResultStrings {
+		want := r == BidSucceeded || r == PartialBidSucceeded
+		if got := r.IsSuccess(); got != want {
+			t.Errorf("BidResult(%v).IsSuccess() = %v, want %v", r, got, want)
+		}
+	}
+	if Pending.IsTerminal() || !Invested.IsTerminal() || !Expired.IsTerminal() {
+		t.Errorf("BidStatusValue.IsTerminal() returned unexpected results")
+	}
+	if OrderInProgress.IsTerminal() || !OrderCompleted.IsTerminal() {
+		t.Errorf("OrderStatus.IsTerminal() returned unexpected results")
+	}
+}
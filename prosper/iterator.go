@@ -0,0 +1,233 @@
+package prosper
+
+import "context"
+
+// defaultPageSize is the number of results to request per page when the
+// caller does not specify a limit.
+const defaultPageSize = 25
+
+// pageFetcher retrieves a single page of results at the given offset, returning
+// the number of results on the page and the total number of results available.
+type pageFetcher func(offset, limit int) (resultCount, totalCount int, err error)
+
+// pager tracks the position of a scan through a paged Prosper API.
+type pager struct {
+	ctx         context.Context
+	startOffset int
+	offset      int
+	limit       int
+	totalCount  int
+	started     bool
+	done        bool
+	err         error
+}
+
+func newPager(ctx context.Context, offset, limit int) pager {
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	return pager{
+		ctx:         ctx,
+		startOffset: offset,
+		offset:      offset,
+		limit:       limit,
+	}
+}
+
+// fetchNext retrieves the next page of results. It returns false once all
+// pages have been read, the context is cancelled, or fetch fails.
+func (p *pager) fetchNext(fetch pageFetcher) bool {
+	if p.done {
+		return false
+	}
+	if p.started && p.offset >= p.totalCount {
+		p.done = true
+		return false
+	}
+	if !p.checkContext() {
+		return false
+	}
+	resultCount, totalCount, err := fetch(p.offset, p.limit)
+	if err != nil {
+		p.err = err
+		p.done = true
+		return false
+	}
+	if resultCount == 0 {
+		p.done = true
+		return false
+	}
+	p.offset += resultCount
+	if p.started && totalCount < p.totalCount {
+		// Results were removed since the last page, so unread results have
+		// shifted toward the start. Back up by the number removed so that
+		// results shifted past the end of this page are not skipped, while
+		// still moving forward when results are removed on every page. Any
+		// results read twice are discarded by the iterator.
+		p.offset -= p.totalCount - totalCount
+		if p.offset < p.startOffset {
+			p.offset = p.startOffset
+		}
+	}
+	p.started = true
+	p.totalCount = totalCount
+	return true
+}
+
+func (p *pager) checkContext() bool {
+	if err := p.ctx.Err(); err != nil {
+		p.err = err
+		p.done = true
+		return false
+	}
+	return true
+}
+
+// NoteIterator iterates over all of the user's notes, paging through the Notes
+// API transparently.
+type NoteIterator struct {
+	pager       pager
+	fetcher     NoteFetcher
+	params      NotesParams
+	buffer      []Note
+	current     Note
+	seen        map[string]bool
+	parseErrors []*NoteParseError
+}
+
+// AllNotes returns an iterator over every note that the user owns, starting at
+// p.Offset and requesting p.Limit notes per page. Notes that appear more than
+// once because results shifted between pages are returned only once.
+func AllNotes(ctx context.Context, f NoteFetcher, p NotesParams) *NoteIterator {
+	return &NoteIterator{
+		pager:   newPager(ctx, p.Offset, p.Limit),
+		fetcher: f,
+		params:  p,
+		seen:    map[string]bool{},
+	}
+}
+
+// Next advances the iterator to the next note. It returns false when there are
+// no more notes or an error occurred.
+func (it *NoteIterator) Next() bool {
+	if !it.pager.checkContext() {
+		return false
+	}
+	for len(it.buffer) == 0 {
+		if !it.pager.fetchNext(it.fetchPage) {
+			return false
+		}
+	}
+	it.current, it.buffer = it.buffer[0], it.buffer[1:]
+	return true
+}
+
+// Note returns the note at the current position of the iterator.
+func (it *NoteIterator) Note() Note {
+	return it.current
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *NoteIterator) Err() error {
+	return it.pager.err
+}
+
+// ParseErrors returns the notes that have been skipped so far because they
+// failed to parse. It is only populated in ParseLenient mode.
+func (it *NoteIterator) ParseErrors() []*NoteParseError {
+	return it.parseErrors
+}
+
+func (it *NoteIterator) fetchPage(offset, limit int) (int, int, error) {
+	p := it.params
+	p.Offset = offset
+	p.Limit = limit
+	response, err := it.fetcher.Notes(p)
+	if err != nil {
+		return 0, 0, err
+	}
+	it.parseErrors = append(it.parseErrors, response.ParseErrors...)
+	for _, n := range response.Result {
+		if it.seen[n.LoanNoteID] {
+			continue
+		}
+		it.seen[n.LoanNoteID] = true
+		it.buffer = append(it.buffer, n)
+	}
+	return len(response.Result) + len(response.ParseErrors), response.TotalCount, nil
+}
+
+// ListingIterator iterates over all listings that match a search, paging
+// through the Search API transparently.
+type ListingIterator struct {
+	pager       pager
+	searcher    ListingSearcher
+	params      SearchParams
+	buffer      []Listing
+	current     Listing
+	seen        map[ListingNumber]bool
+	parseErrors []*ListingParseError
+}
+
+// AllListings returns an iterator over every listing that matches the search
+// parameters, starting at p.Offset and requesting p.Limit listings per page.
+// Listings that appear more than once because results shifted between pages
+// are returned only once.
+func AllListings(ctx context.Context, s ListingSearcher, p SearchParams) *ListingIterator {
+	return &ListingIterator{
+		pager:    newPager(ctx, p.Offset, p.Limit),
+		searcher: s,
+		params:   p,
+		seen:     map[ListingNumber]bool{},
+	}
+}
+
+// Next advances the iterator to the next listing. It returns false when there
+// are no more listings or an error occurred.
+func (it *ListingIterator) Next() bool {
+	if !it.pager.checkContext() {
+		return false
+	}
+	for len(it.buffer) == 0 {
+		if !it.pager.fetchNext(it.fetchPage) {
+			return false
+		}
+	}
+	it.current, it.buffer = it.buffer[0], it.buffer[1:]
+	return true
+}
+
+// Listing returns the listing at the current position of the iterator.
+func (it *ListingIterator) Listing() Listing {
+	return it.current
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *ListingIterator) Err() error {
+	return it.pager.err
+}
+
+// ParseErrors returns the listings that have been skipped so far because they
+// failed to parse. It is only populated in ParseLenient mode.
+func (it *ListingIterator) ParseErrors() []*ListingParseError {
+	return it.parseErrors
+}
+
+func (it *ListingIterator) fetchPage(offset, limit int) (int, int, error) {
+	p := it.params
+	p.Offset = offset
+	p.Limit = limit
+	response, err := it.searcher.Search(p)
+	if err != nil {
+		return 0, 0, err
+	}
+	it.parseErrors = append(it.parseErrors, response.ParseErrors...)
+	for _, l := range response.Results {
+		if it.seen[l.ListingNumber] {
+			continue
+		}
+		it.seen[l.ListingNumber] = true
+		it.buffer = append(it.buffer, l)
+	}
+	return len(response.Results) + len(response.ParseErrors), response.TotalCount, nil
+}
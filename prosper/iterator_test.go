@@ -0,0 +1,236 @@
+package prosper
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// mockNoteFetcher serves notes from a sequence of snapshots of the user's
+// notes. Each call to Notes uses the next snapshot, or the last snapshot once
+// all have been used.
+type mockNoteFetcher struct {
+	snapshots [][]Note
+	errAt     int
+	err       error
+	paramsGot []NotesParams
+}
+
+func (f *mockNoteFetcher) Notes(p NotesParams) (NotesResponse, error) {
+	call := len(f.paramsGot)
+	f.paramsGot = append(f.paramsGot, p)
+	if f.err != nil && call == f.errAt {
+		return NotesResponse{}, f.err
+	}
+	snapshot := f.snapshots[len(f.snapshots)-1]
+	if call < len(f.snapshots) {
+		snapshot = f.snapshots[call]
+	}
+	var result []Note
+	for i := p.Offset; i < len(snapshot) && i < p.Offset+p.Limit; i++ {
+		result = append(result, snapshot[i])
+	}
+	return NotesResponse{
+		Result:      result,
+		ResultCount: len(result),
+		TotalCount:  len(snapshot),
+	}, nil
+}
+
+// mockListingSearcher serves listings from a sequence of snapshots in the same
+// way as mockNoteFetcher.
+type mockListingSearcher struct {
+	snapshots [][]Listing
+	paramsGot []SearchParams
+}
+
+func (s *mockListingSearcher) Search(p SearchParams) (SearchResponse, error) {
+	call := len(s.paramsGot)
+	s.paramsGot = append(s.paramsGot, p)
+	snapshot := s.snapshots[len(s.snapshots)-1]
+	if call < len(s.snapshots) {
+		snapshot = s.snapshots[call]
+	}
+	var results []Listing
+	for i := p.Offset; i < len(snapshot) && i < p.Offset+p.Limit; i++ {
+		results = append(results, snapshot[i])
+	}
+	return SearchResponse{
+		Results:     results,
+		ResultCount: len(results),
+		TotalCount:  len(snapshot),
+	}, nil
+}
+
+var (
+	noteA = Note{LoanNoteID: "a"}
+	noteB = Note{LoanNoteID: "b"}
+	noteC = Note{LoanNoteID: "c"}
+	noteD = Note{LoanNoteID: "d"}
+	noteX = Note{LoanNoteID: "x"}
+)
+
+func TestAllNotes(t *testing.T) {
+	var tests = []struct {
+		params      NotesParams
+		snapshots   [][]Note
+		errAt       int
+		err         error
+		want        []Note
+		wantOffsets []int
+		wantErr     error
+		msg         string
+	}{
+		{
+			params:      NotesParams{Limit: 2},
+			snapshots:   [][]Note{{}},
+			want:        nil,
+			wantOffsets: []int{0},
+			msg:         "user with no notes should produce no notes",
+		},
+		{
+			params:      NotesParams{Limit: 2},
+			snapshots:   [][]Note{{noteA, noteB, noteC}},
+			want:        []Note{noteA, noteB, noteC},
+			wantOffsets: []int{0, 2},
+			msg:         "iterator should read all pages",
+		},
+		{
+			params:      NotesParams{Offset: 1, Limit: 2},
+			snapshots:   [][]Note{{noteA, noteB, noteC}},
+			want:        []Note{noteB, noteC},
+			wantOffsets: []int{1},
+			msg:         "iterator should start at requested offset",
+		},
+		{
+			params: NotesParams{Limit: 2},
+			snapshots: [][]Note{
+				{noteA, noteB, noteC},
+				{noteX, noteA, noteB, noteC},
+			},
+			want:        []Note{noteA, noteB, noteC},
+			wantOffsets: []int{0, 2},
+			msg:         "notes shifted by an insertion should not be returned twice",
+		},
+		{
+			params: NotesParams{Limit: 2},
+			snapshots: [][]Note{
+				{noteA, noteB, noteC, noteD},
+				{noteB, noteC, noteD},
+			},
+			want:        []Note{noteA, noteB, noteD},
+			wantOffsets: []int{0, 2, 2},
+			msg:         "iterator should back up by the number of notes removed",
+		},
+		{
+			params: NotesParams{Limit: 2},
+			snapshots: [][]Note{
+				{noteA, noteB, noteC, noteD, noteX},
+				{noteB, noteC, noteD, noteX},
+				{noteC, noteD, noteX},
+				{noteD, noteX},
+				{noteX},
+			},
+			want:        []Note{noteA, noteB, noteD, noteX},
+			wantOffsets: []int{0, 2, 3},
+			msg:         "iterator should move forward when notes are removed on every page",
+		},
+		{
+			params:      NotesParams{Limit: 2},
+			snapshots:   [][]Note{{noteA, noteB, noteC}},
+			errAt:       1,
+			err:         errMockParserFail,
+			want:        []Note{noteA, noteB},
+			wantOffsets: []int{0, 2},
+			wantErr:     errMockParserFail,
+			msg:         "iterator should stop on error",
+		},
+	}
+	for _, tt := range tests {
+		f := mockNoteFetcher{
+			snapshots: tt.snapshots,
+			errAt:     tt.errAt,
+			err:       tt.err,
+		}
+		it := AllNotes(context.Background(), &f, tt.params)
+		var got []Note
+		for it.Next() {
+			got = append(got, it.Note())
+		}
+		if it.Err() != tt.wantErr {
+			t.Errorf("%s: unexpected error. got %v, want %v", tt.msg, it.Err(), tt.wantErr)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.msg, got, tt.want)
+		}
+		var gotOffsets []int
+		for _, p := range f.paramsGot {
+			gotOffsets = append(gotOffsets, p.Offset)
+			if p.Limit != tt.params.Limit {
+				t.Errorf("%s: unexpected page limit. got %d, want %d", tt.msg, p.Limit, tt.params.Limit)
+			}
+		}
+		if !reflect.DeepEqual(gotOffsets, tt.wantOffsets) {
+			t.Errorf("%s: unexpected offsets requested. got %v, want %v", tt.msg, gotOffsets, tt.wantOffsets)
+		}
+	}
+}
+
+func TestAllNotesDefaultPageSize(t *testing.T) {
+	f := mockNoteFetcher{snapshots: [][]Note{{noteA}}}
+	it := AllNotes(context.Background(), &f, NotesParams{})
+	for it.Next() {
+	}
+	if len(f.paramsGot) != 1 || f.paramsGot[0].Limit != defaultPageSize {
+		t.Errorf("iterator should request default page size when limit is unset, got %v", f.paramsGot)
+	}
+}
+
+func TestAllNotesStopsOnContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := mockNoteFetcher{snapshots: [][]Note{{noteA, noteB, noteC}}}
+	it := AllNotes(ctx, &f, NotesParams{Limit: 1})
+	if !it.Next() {
+		t.Fatalf("expected first note, got error: %v", it.Err())
+	}
+	cancel()
+	if it.Next() {
+		t.Errorf("iterator should stop after context is cancelled, got %v", it.Note())
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("unexpected error. got %v, want %v", it.Err(), context.Canceled)
+	}
+	if len(f.paramsGot) != 1 {
+		t.Errorf("iterator should not fetch after cancellation, fetched %d pages", len(f.paramsGot))
+	}
+}
+
+func TestAllListings(t *testing.T) {
+	s := mockListingSearcher{
+		snapshots: [][]Listing{
+			{listingA, listingB},
+			{{ListingNumber: 9999}, listingA, listingB},
+		},
+	}
+	params := SearchParams{
+		Limit:  1,
+		Filter: SearchFilter{Rating: []Rating{RatingA}},
+	}
+	it := AllListings(context.Background(), &s, params)
+	var got []Listing
+	for it.Next() {
+		got = append(got, it.Listing())
+	}
+	if it.Err() != nil {
+		t.Fatalf("unexpected error: %v", it.Err())
+	}
+	want := []Listing{listingA, listingB}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	for _, p := range s.paramsGot {
+		if !reflect.DeepEqual(p.Filter, params.Filter) {
+			t.Errorf("iterator should preserve search filter, got %+v, want %+v", p.Filter, params.Filter)
+		}
+	}
+}
@@ -2,7 +2,11 @@ package prosper
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/mtlynch/gofn-prosper/interval"
 	"github.com/mtlynch/gofn-prosper/prosper/thin"
 )
 
@@ -10,60 +14,122 @@ type listingParser interface {
 	Parse(thin.SearchResult) (Listing, error)
 }
 
-type defaultListingParser struct{}
+type defaultListingParser struct {
+	// location is the location in which parsed timestamps are expressed. If
+	// nil, timestamps keep the offset Prosper sent.
+	location *time.Location
+	// unknownEnums controls whether unrecognized enum values are accepted.
+	unknownEnums unknownEnumPolicy
+}
 
 func (p defaultListingParser) Parse(r thin.SearchResult) (Listing, error) {
+	unknownValues := map[string]string{}
 	incomeRange, err := parseIncomeRange(r.IncomeRange)
 	if err != nil {
-		return Listing{}, err
+		if !p.unknownEnums.acceptUnknown("income_range", r.IncomeRange, unknownValues) {
+			return Listing{}, newListingParseError(r.ListingNumber, "income_range", r.IncomeRange, err)
+		}
+		incomeRange = IncomeRangeUnknown
 	}
 	listingStatus, err := parseListingStatus(r.ListingStatus)
 	if err != nil {
-		return Listing{}, err
+		if !p.unknownEnums.acceptUnknown("listing_status", r.ListingStatus, unknownValues) {
+			return Listing{}, newListingParseError(r.ListingNumber, "listing_status", r.ListingStatus, err)
+		}
+		listingStatus = ListingStatusUnknown
 	}
 	ficoScore, err := parseFicoScore(r.FicoScore)
 	if err != nil {
-		return Listing{}, err
+		if !p.unknownEnums.acceptUnknown("fico_score", r.FicoScore, unknownValues) {
+			return Listing{}, newListingParseError(r.ListingNumber, "fico_score", r.FicoScore, err)
+		}
+		ficoScore = FicoScoreUnknown
 	}
 	rating, err := parseRating(r.Rating)
 	if err != nil {
-		return Listing{}, err
+		if !p.unknownEnums.acceptUnknown("prosper_rating", r.Rating, unknownValues) {
+			return Listing{}, newListingParseError(r.ListingNumber, "prosper_rating", r.Rating, err)
+		}
+		rating = RatingUnknown
+	}
+	oldestTradeOpenDate, err := parseCreditAge(r.OldestTradeOpenDate)
+	if err != nil {
+		return Listing{}, newListingParseError(r.ListingNumber, "oldest_trade_open_date", r.OldestTradeOpenDate, err)
+	}
+	firstRecordedCreditLine, err := parseProsperTime(r.FirstRecordedCreditLine, p.location)
+	if err != nil {
+		return Listing{}, newListingParseError(r.ListingNumber, "first_recorded_credit_line", r.FirstRecordedCreditLine, err)
+	}
+	creditPullDate, err := parseProsperTime(r.CreditPullDate, p.location)
+	if err != nil {
+		return Listing{}, newListingParseError(r.ListingNumber, "credit_pull_date", r.CreditPullDate, err)
+	}
+	listingCreationDate, err := parseProsperTime(r.ListingCreationDate, p.location)
+	if err != nil {
+		return Listing{}, newListingParseError(r.ListingNumber, "listing_creation_date", r.ListingCreationDate, err)
 	}
-	oldestTradeOpenDate, err := parseProsperOldTime(r.OldestTradeOpenDate)
+	listingEndDate, err := parseProsperTime(r.ListingEndDate, p.location)
 	if err != nil {
-		return Listing{}, err
+		return Listing{}, newListingParseError(r.ListingNumber, "listing_end_date", r.ListingEndDate, err)
 	}
-	firstRecordedCreditLine, err := parseProsperTime(r.FirstRecordedCreditLine)
+	listingStartDate, err := parseProsperTime(r.ListingStartDate, p.location)
 	if err != nil {
-		return Listing{}, err
+		return Listing{}, newListingParseError(r.ListingNumber, "listing_start_date", r.ListingStartDate, err)
 	}
-	creditPullDate, err := parseProsperTime(r.CreditPullDate)
+	wholeLoanStartDate, err := parseProsperTime(r.WholeLoanStartDate, p.location)
 	if err != nil {
-		return Listing{}, err
+		return Listing{}, newListingParseError(r.ListingNumber, "whole_loan_start_date", r.WholeLoanStartDate, err)
 	}
-	listingCreationDate, err := parseProsperTime(r.ListingCreationDate)
+	wholeLoanEndDate, err := parseProsperTime(r.WholeLoanEndDate, p.location)
 	if err != nil {
-		return Listing{}, err
+		return Listing{}, newListingParseError(r.ListingNumber, "whole_loan_end_date", r.WholeLoanEndDate, err)
 	}
-	listingEndDate, err := parseProsperTime(r.ListingEndDate)
+	lastUpdatedDate, err := parseProsperTime(r.LastUpdatedDate, p.location)
 	if err != nil {
-		return Listing{}, err
+		return Listing{}, newListingParseError(r.ListingNumber, "last_updated_date", r.LastUpdatedDate, err)
 	}
-	listingStartDate, err := parseProsperTime(r.ListingStartDate)
+	prosperScore, err := parseProsperScore(r.ProsperScore)
 	if err != nil {
-		return Listing{}, err
+		if !p.unknownEnums.acceptUnknown("prosper_score", r.ProsperScore, unknownValues) {
+			return Listing{}, newListingParseError(r.ListingNumber, "prosper_score", r.ProsperScore, err)
+		}
+		prosperScore = ProsperScoreUnknown
 	}
-	wholeLoanStartDate, err := parseProsperTime(r.WholeLoanStartDate)
+	scoreX, err := parseScoreX(r.Scorex)
 	if err != nil {
-		return Listing{}, err
+		return Listing{}, newListingParseError(r.ListingNumber, "scorex", r.Scorex, err)
 	}
-	wholeLoanEndDate, err := parseProsperTime(r.WholeLoanEndDate)
+	scoreXChange, err := parseScoreXChange(r.ScorexChange)
 	if err != nil {
-		return Listing{}, err
+		return Listing{}, newListingParseError(r.ListingNumber, "scorex_change", r.ScorexChange, err)
 	}
-	lastUpdatedDate, err := parseProsperTime(r.LastUpdatedDate)
+	verificationStage, err := parseVerificationStage(r.VerificationStage)
 	if err != nil {
-		return Listing{}, err
+		if !p.unknownEnums.acceptUnknown("verification_stage", r.VerificationStage, unknownValues) {
+			return Listing{}, newListingParseError(r.ListingNumber, "verification_stage", r.VerificationStage, err)
+		}
+		verificationStage = VerificationStageUnknown
+	}
+	investmentType, err := parseInvestmentType(r.InvestmentTypeid)
+	if err != nil {
+		if !p.unknownEnums.acceptUnknown("investment_typeid", r.InvestmentTypeid, unknownValues) {
+			return Listing{}, newListingParseError(r.ListingNumber, "investment_typeid", r.InvestmentTypeid, err)
+		}
+		investmentType = InvestmentTypeUnknown
+	}
+	lenderIndicator, err := parseLenderIndicator(r.LenderIndicator)
+	if err != nil {
+		if !p.unknownEnums.acceptUnknown("lender_indicator", r.LenderIndicator, unknownValues) {
+			return Listing{}, newListingParseError(r.ListingNumber, "lender_indicator", r.LenderIndicator, err)
+		}
+		lenderIndicator = LenderIndicatorUnknown
+	}
+	listingCategory, err := parseListingCategory(r.ListingCategoryID)
+	if err != nil {
+		if !p.unknownEnums.acceptUnknown("listing_category_id", r.ListingCategoryID, unknownValues) {
+			return Listing{}, newListingParseError(r.ListingNumber, "listing_category_id", r.ListingCategoryID, err)
+		}
+		listingCategory = ListingCategoryUnknown
 	}
 	return Listing{
 		PriorProsperLoans:                         r.PriorProsperLoans,
@@ -76,7 +142,7 @@ func (p defaultListingParser) Parse(r thin.SearchResult) (Listing, error) {
 		PriorProsperLoansPrincipalOutstanding:     r.PriorProsperLoansPrincipalOutstanding,
 		PublicRecordsLast12Months:                 r.PublicRecordsLast12Months,
 		TotalOpenRevolvingAccounts:                r.TotalOpenRevolvingAccounts,
-		VerificationStage:                         r.VerificationStage,
+		VerificationStage:                         verificationStage,
 		ListingStatus:                             listingStatus,
 		ListingTitle:                              r.ListingTitle,
 		DelinquenciesLast7Years:                   r.DelinquenciesLast7Years,
@@ -92,9 +158,19 @@ func (p defaultListingParser) Parse(r thin.SearchResult) (Listing, error) {
 		WasDelinquentDerog:                        r.WasDelinquentDerog,
 		BankcardUtilization:                       r.BankcardUtilization,
 		InstallmentBalance:                        r.InstallmentBalance,
-		InvestmentTypeID:                          r.InvestmentTypeid,
-		ListingCategoryID:                         r.ListingCategoryID,
+		InvestmentTypeID:                          investmentType,
+		ListingCategoryID:                         listingCategory,
 		BorrowerCity:                              r.BorrowerCity,
+		BorrowerListingDescription:                r.BorrowerListingDescription,
+		BorrowerMetropolitanArea:                  r.BorrowerMetropolitanArea,
+		ChannelCode:                               r.ChannelCode,
+		CoBorrowerApplication:                     r.CoBorrowerApplication,
+		CombinedDtiWprosperLoan:                   r.CombinedDtiWprosperLoan,
+		CombinedStatedMonthlyIncome:               r.CombinedStatedMonthlyIncome,
+		GroupIndicator:                            r.GroupIndicator,
+		GroupName:                                 r.GroupName,
+		Invested:                                  r.Invested,
+		ListingPurpose:                            r.ListingPurpose,
 		BorrowerState:                             r.BorrowerState,
 		IncomeRangeDescription:                    r.IncomeRangeDescription,
 		RevolvingAvailablePercent:                 r.RevolvingAvailablePercent,
@@ -117,7 +193,7 @@ func (p defaultListingParser) Parse(r thin.SearchResult) (Listing, error) {
 		MonthsEmployed:                            r.MonthsEmployed,
 		PartialFundingIndicator:                   r.PartialFundingIndicator,
 		Rating:                                    rating,
-		ProsperScore:                              r.ProsperScore,
+		ProsperScore:                              prosperScore,
 		BorrowerApr:                               r.BorrowerApr,
 		PriorProsperLoans31dpd:                    r.PriorProsperLoans31dpd,
 		PriorProsperLoansLatePaymentsOneMonthPlus: r.PriorProsperLoansLatePaymentsOneMonthPlus,
@@ -127,8 +203,8 @@ func (p defaultListingParser) Parse(r thin.SearchResult) (Listing, error) {
 		CreditPullDate:                            creditPullDate,
 		PublicRecordsLast10Years:                  r.PublicRecordsLast10Years,
 		RevolvingBalance:                          r.RevolvingBalance,
-		ScoreX:                                    r.Scorex,
-		ScoreXChange:                              r.ScorexChange,
+		ScoreX:                                    scoreX,
+		ScoreXChange:                              scoreXChange,
 		MinPriorProsperLoan:                       r.MinPriorProsperLoan,
 		AmountFunded:                              r.AmountFunded,
 		EffectiveYield:                            r.EffectiveYield,
@@ -148,13 +224,16 @@ func (p defaultListingParser) Parse(r thin.SearchResult) (Listing, error) {
 		PriorProsperLoansOntimePayments:           r.PriorProsperLoansOntimePayments,
 		EstimatedReturn:                           r.EstimatedReturn,
 		IncomeVerifiable:                          r.IncomeVerifiable,
-		LenderIndicator:                           r.LenderIndicator,
+		LenderIndicator:                           lenderIndicator,
 		MaxPriorProsperLoan:                       r.MaxPriorProsperLoan,
 		NowDelinquentDerog:                        r.NowDelinquentDerog,
 		StatedMonthlyIncome:                       r.StatedMonthlyIncome,
 		TotalInquiries:                            r.TotalInquiries,
 		WholeLoanStartDate:                        wholeLoanStartDate,
 		WholeLoanEndDate:                          wholeLoanEndDate,
+		UnknownEnumValues:                         nilIfEmpty(unknownValues),
+		Raw:                                       r.Raw,
+		amountText:                                r.Amounts,
 	}, nil
 }
 
@@ -165,6 +244,13 @@ func parseIncomeRange(incomeRange int64) (IncomeRange, error) {
 	return IncomeRange(incomeRange), nil
 }
 
+func parseListingCategory(categoryID int64) (ListingCategory, error) {
+	if categoryID < int64(ListingCategoryMin) || categoryID > int64(ListingCategoryMax) {
+		return ListingCategoryInvalid, fmt.Errorf("listing category out of range: %d, expected %d-%d", categoryID, ListingCategoryMin, ListingCategoryMax)
+	}
+	return ListingCategory(categoryID), nil
+}
+
 func parseListingStatus(listingStatus int64) (ListingStatus, error) {
 	if listingStatus < int64(ListingStatusMin) || listingStatus > int64(ListingStatusMax) {
 		return ListingStatusUnknown, fmt.Errorf("listing status out of range: %d, expected %d-%d", listingStatus, ListingStatusMin, ListingStatusMax)
@@ -194,3 +280,84 @@ func parseFicoScore(ficoScore string) (FicoScore, error) {
 	}
 	return parsed, nil
 }
+
+func parseProsperScore(prosperScore int64) (ProsperScore, error) {
+	if prosperScore == int64(ProsperScoreNotAvailable) {
+		return ProsperScoreNotAvailable, nil
+	}
+	if prosperScore < int64(ProsperScoreMin) || prosperScore > int64(ProsperScoreMax) {
+		return ProsperScoreInvalid, fmt.Errorf("prosper score out of range: %d, expected %d-%d", prosperScore, ProsperScoreMin, ProsperScoreMax)
+	}
+	return ProsperScore(prosperScore), nil
+}
+
+// parseScoreX parses a ScoreX range such as "702-723", "778+" or "<600". An
+// empty value parses to an unbounded range.
+func parseScoreX(scoreX string) (interval.Int32Range, error) {
+	if len(scoreX) == 0 {
+		return interval.Int32Range{}, nil
+	}
+	if strings.HasPrefix(scoreX, "<") {
+		max, err := strconv.ParseInt(scoreX[1:], 10, 32)
+		if err != nil {
+			return interval.Int32Range{}, fmt.Errorf("unrecognized ScoreX value: %s", scoreX)
+		}
+		return interval.Int32Range{Max: interval.CreateInt32(int32(max) - 1)}, nil
+	}
+	if strings.HasSuffix(scoreX, "+") {
+		min, err := strconv.ParseInt(scoreX[:len(scoreX)-1], 10, 32)
+		if err != nil {
+			return interval.Int32Range{}, fmt.Errorf("unrecognized ScoreX value: %s", scoreX)
+		}
+		return interval.Int32Range{Min: interval.CreateInt32(int32(min))}, nil
+	}
+	bounds := strings.Split(scoreX, "-")
+	if len(bounds) != 2 {
+		return interval.Int32Range{}, fmt.Errorf("unrecognized ScoreX value: %s", scoreX)
+	}
+	min, err := strconv.ParseInt(bounds[0], 10, 32)
+	if err != nil {
+		return interval.Int32Range{}, fmt.Errorf("unrecognized ScoreX value: %s", scoreX)
+	}
+	max, err := strconv.ParseInt(bounds[1], 10, 32)
+	if err != nil {
+		return interval.Int32Range{}, fmt.Errorf("unrecognized ScoreX value: %s", scoreX)
+	}
+	return interval.NewInt32Range(int32(min), int32(max)), nil
+}
+
+// parseScoreXChange parses a signed change in ScoreX such as "+12" or "-8". An
+// empty value means the change is unavailable and parses to nil.
+func parseScoreXChange(change string) (*int64, error) {
+	if len(change) == 0 {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseInt(change, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unrecognized ScoreX change value: %s", change)
+	}
+	return &parsed, nil
+}
+
+func parseVerificationStage(stage int64) (VerificationStage, error) {
+	if stage < int64(VerificationStageMin) || stage > int64(VerificationStageMax) {
+		return VerificationStageInvalid, fmt.Errorf("verification stage out of range: %d, expected %d-%d", stage, VerificationStageMin, VerificationStageMax)
+	}
+	return VerificationStage(stage), nil
+}
+
+func parseInvestmentType(investmentTypeID int64) (InvestmentType, error) {
+	switch investmentTypeID {
+	case int64(InvestmentFractional), int64(InvestmentWhole):
+		return InvestmentType(investmentTypeID), nil
+	}
+	return InvestmentTypeInvalid, fmt.Errorf("unrecognized investment type ID: %d", investmentTypeID)
+}
+
+func parseLenderIndicator(lenderIndicator int64) (LenderIndicator, error) {
+	switch lenderIndicator {
+	case int64(BorrowerIsNotLender), int64(BorrowerIsLender):
+		return LenderIndicator(lenderIndicator), nil
+	}
+	return LenderIndicatorInvalid, fmt.Errorf("unrecognized lender indicator: %d", lenderIndicator)
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/mtlynch/gofn-prosper/interval"
 	"github.com/mtlynch/gofn-prosper/prosper/thin"
 )
 
@@ -118,7 +119,7 @@ func TestListingParser(t *testing.T) {
 				AmountParticipation:                       0,
 				DelinquenciesOver60Days:                   3,
 				ListingMonthlyPayment:                     285.46,
-				OldestTradeOpenDate:                       time.Date(1991, 3, 22, 0, 0, 0, 0, time.UTC),
+				OldestTradeOpenDate:                       CreditAge{Kind: CreditAgeExact, OpenDate: Date{1991, 3, 22}},
 				PriorProsperLoansPrincipalOutstanding:     0,
 				PublicRecordsLast12Months:                 0,
 				TotalOpenRevolvingAccounts:                3,
@@ -143,6 +144,7 @@ func TestListingParser(t *testing.T) {
 				InvestmentTypeID:                          1,
 				ListingCategoryID:                         14,
 				BorrowerCity:                              "PASADENA",
+				BorrowerMetropolitanArea:                  "(Not Implemented)",
 				BorrowerState:                             "MD",
 				IncomeRange:                               Between25kAnd50k,
 				IncomeRangeDescription:                    "$25,000-49,999",
@@ -176,8 +178,7 @@ func TestListingParser(t *testing.T) {
 				CreditPullDate:                            time.Date(2015, 12, 4, 1, 3, 3, 0, time.UTC),
 				PublicRecordsLast10Years:                  0,
 				RevolvingBalance:                          978,
-				ScoreX:                                    "702-723",
-				ScoreXChange:                              "",
+				ScoreX:                                    interval.NewInt32Range(702, 723),
 				MinPriorProsperLoan:                       0,
 				AmountFunded:                              833.91,
 				EffectiveYield:                            0.1582,
@@ -307,7 +308,7 @@ func TestListingParser(t *testing.T) {
 				BorrowerApr:                 0.10531,
 				ListingTerm:                 36,
 				ListingMonthlyPayment:       624.33,
-				ScoreX:                      "778+",
+				ScoreX:                      interval.Int32Range{Min: interval.CreateInt32(778)},
 				FicoScore:                   Between780And799,
 				ListingCategoryID:           1,
 				ListingTitle:                "Debt consolidation",
@@ -321,6 +322,8 @@ func TestListingParser(t *testing.T) {
 				MonthsEmployed:              67,
 				BorrowerState:               "TX",
 				BorrowerCity:                "PLAINVIEW",
+				BorrowerMetropolitanArea:    "(Not Implemented)",
+				ChannelCode:                 "40000",
 				PriorProsperLoansActive:     0,
 				PriorProsperLoans:           0,
 				LenderIndicator:             0,
@@ -345,7 +348,7 @@ func TestListingParser(t *testing.T) {
 				SatisfactoryAccounts:        6,
 				NowDelinquentDerog:          0,
 				WasDelinquentDerog:          0,
-				OldestTradeOpenDate:         time.Date(1997, 8, 19, 0, 0, 0, 0, time.UTC),
+				OldestTradeOpenDate:         CreditAge{Kind: CreditAgeExact, OpenDate: Date{1997, 8, 19}},
 				DelinquenciesOver30Days:     0,
 				DelinquenciesOver60Days:     0,
 				DelinquenciesOver90Days:     0,
@@ -1672,3 +1675,207 @@ func TestListingParser(t *testing.T) {
 		}
 	}
 }
+
+func TestListingParserTypedFields(t *testing.T) {
+	validInput := func() thin.SearchResult {
+		return thin.SearchResult{
+			ListingNumber:       4247229,
+			ListingStatus:       2,
+			IncomeRange:         3,
+			FicoScore:           "660-679",
+			Rating:              "C",
+			OldestTradeOpenDate: "03221991",
+			ProsperScore:        4,
+			Scorex:              "702-723",
+			ScorexChange:        "-12",
+			VerificationStage:   2,
+			InvestmentTypeid:    2,
+			LenderIndicator:     1,
+			ListingCategoryID:   14,
+			ListingPurpose:      "Large Purchases",
+			GroupIndicator:      true,
+			GroupName:           "Mock Group",
+			ChannelCode:         "90000",
+			Invested:            true,
+		}
+	}
+	scoreXChange := int64(-12)
+
+	got, err := defaultListingParser{}.Parse(validInput())
+	if err != nil {
+		t.Fatalf("expected successful parsing, got error: %v", err)
+	}
+	if got.ProsperScore != 4 {
+		t.Errorf("unexpected ProsperScore. got %v, want %v", got.ProsperScore, 4)
+	}
+	if !interval.Int32RangeEqual(got.ScoreX, interval.NewInt32Range(702, 723)) {
+		t.Errorf("unexpected ScoreX. got %v, want %v", got.ScoreX, interval.NewInt32Range(702, 723))
+	}
+	if !reflect.DeepEqual(got.ScoreXChange, &scoreXChange) {
+		t.Errorf("unexpected ScoreXChange. got %v, want %v", got.ScoreXChange, scoreXChange)
+	}
+	if got.VerificationStage != VerificationStageTwo {
+		t.Errorf("unexpected VerificationStage. got %v, want %v", got.VerificationStage, VerificationStageTwo)
+	}
+	if got.InvestmentTypeID != InvestmentWhole {
+		t.Errorf("unexpected InvestmentTypeID. got %v, want %v", got.InvestmentTypeID, InvestmentWhole)
+	}
+	if got.LenderIndicator != BorrowerIsLender {
+		t.Errorf("unexpected LenderIndicator. got %v, want %v", got.LenderIndicator, BorrowerIsLender)
+	}
+	if got.ListingCategoryID != CategoryLargePurchases {
+		t.Errorf("unexpected ListingCategoryID. got %v, want %v", got.ListingCategoryID, CategoryLargePurchases)
+	}
+	if got.ListingPurpose != "Large Purchases" || !got.GroupIndicator || got.GroupName != "Mock Group" || got.ChannelCode != "90000" || !got.Invested {
+		t.Errorf("raw listing fields were not carried through to parsed listing: %+v", got)
+	}
+
+	var tests = []struct {
+		modify    func(*thin.SearchResult)
+		wantField string
+		msg       string
+	}{
+		{
+			modify:    func(r *thin.SearchResult) { r.ProsperScore = 12 },
+			wantField: "prosper_score",
+			msg:       "listing with Prosper score above 11 should fail parsing",
+		},
+		{
+			modify:    func(r *thin.SearchResult) { r.ProsperScore = -1 },
+			wantField: "prosper_score",
+			msg:       "listing with negative Prosper score should fail parsing",
+		},
+		{
+			modify:    func(r *thin.SearchResult) { r.Scorex = "high" },
+			wantField: "scorex",
+			msg:       "listing with invalid ScoreX should fail parsing",
+		},
+		{
+			modify:    func(r *thin.SearchResult) { r.ScorexChange = "up" },
+			wantField: "scorex_change",
+			msg:       "listing with invalid ScoreX change should fail parsing",
+		},
+		{
+			modify:    func(r *thin.SearchResult) { r.VerificationStage = 4 },
+			wantField: "verification_stage",
+			msg:       "listing with invalid verification stage should fail parsing",
+		},
+		{
+			modify:    func(r *thin.SearchResult) { r.InvestmentTypeid = 3 },
+			wantField: "investment_typeid",
+			msg:       "listing with invalid investment type ID should fail parsing",
+		},
+		{
+			modify:    func(r *thin.SearchResult) { r.LenderIndicator = 2 },
+			wantField: "lender_indicator",
+			msg:       "listing with invalid lender indicator should fail parsing",
+		},
+		{
+			modify:    func(r *thin.SearchResult) { r.ListingCategoryID = 21 },
+			wantField: "listing_category_id",
+			msg:       "listing with invalid listing category should fail parsing",
+		},
+	}
+	for _, tt := range tests {
+		input := validInput()
+		tt.modify(&input)
+		_, err := defaultListingParser{}.Parse(input)
+		if err == nil {
+			t.Errorf("%s - expected failure for %+v, got nil", tt.msg, input)
+			continue
+		}
+		parseErr, ok := err.(*ListingParseError)
+		if !ok {
+			t.Errorf("%s - expected *ListingParseError, got %T", tt.msg, err)
+			continue
+		}
+		if parseErr.Field != tt.wantField || parseErr.ListingNumber != 4247229 {
+			t.Errorf("%s - unexpected parse error. got %+v, want field %s of listing %d", tt.msg, parseErr, tt.wantField, 4247229)
+		}
+	}
+}
+
+func TestParseScoreX(t *testing.T) {
+	var tests = []struct {
+		input         string
+		want          interval.Int32Range
+		expectSuccess bool
+		msg           string
+	}{
+		{
+			input:         "702-723",
+			want:          interval.NewInt32Range(702, 723),
+			expectSuccess: true,
+			msg:           "bounded range should parse",
+		},
+		{
+			input:         "778+",
+			want:          interval.Int32Range{Min: interval.CreateInt32(778)},
+			expectSuccess: true,
+			msg:           "range with no upper bound should parse",
+		},
+		{
+			input:         "<600",
+			want:          interval.Int32Range{Max: interval.CreateInt32(599)},
+			expectSuccess: true,
+			msg:           "range with no lower bound should parse",
+		},
+		{
+			input:         "",
+			want:          interval.Int32Range{},
+			expectSuccess: true,
+			msg:           "empty value should parse as unbounded range",
+		},
+		{
+			input:         "702",
+			expectSuccess: false,
+			msg:           "single value should fail",
+		},
+		{
+			input:         "702-abc",
+			expectSuccess: false,
+			msg:           "non-numeric bound should fail",
+		},
+		{
+			input:         "abc+",
+			expectSuccess: false,
+			msg:           "non-numeric lower bound should fail",
+		},
+	}
+	for _, tt := range tests {
+		got, err := parseScoreX(tt.input)
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s - expected successful parsing of %+v, got error: %v", tt.msg, tt.input, err)
+		} else if !tt.expectSuccess && err == nil {
+			t.Errorf("%s - expected failure for %+v, got nil", tt.msg, tt.input)
+		}
+		if tt.expectSuccess && !interval.Int32RangeEqual(got, tt.want) {
+			t.Errorf("%s - parseScoreX got: %v, want: %v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestListingEnumStrings(t *testing.T) {
+	var tests = []struct {
+		got  string
+		want string
+	}{
+		{VerificationStageOne.String(), "Stage 1"},
+		{VerificationStageThree.String(), "Stage 3"},
+		{VerificationStageInvalid.String(), "Invalid"},
+		{InvestmentFractional.String(), "Fractional"},
+		{InvestmentWhole.String(), "Whole"},
+		{InvestmentTypeInvalid.String(), "Invalid"},
+		{BorrowerIsNotLender.String(), "Not a lender"},
+		{BorrowerIsLender.String(), "Lender"},
+		{LenderIndicatorInvalid.String(), "Invalid"},
+		{CategoryDebtConsolidation.String(), "Debt Consolidation"},
+		{CategoryMedicalDental.String(), "Medical/Dental"},
+		{ListingCategoryInvalid.String(), "Invalid"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("String() got %s, want %s", tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package prosper
+
+// listingsPageSize is the maximum number of listing numbers to request in a
+// single Search call when looking up listings by number.
+const listingsPageSize = 100
+
+// allListingStatuses is the set of every ListingStatus value, used to look up
+// listings regardless of whether they are still active.
+var allListingStatuses = []ListingStatus{
+	ListingActive,
+	ListingWithdrawn,
+	ListingExpired,
+	ListingCompleted,
+	ListingCancelled,
+	ListingPendingReviewOrAcceptance,
+}
+
+// ListingResult represents the result of looking up a single listing by its
+// listing number. If Prosper has no listing with the requested number, Found is
+// false and Listing is the zero value.
+type ListingResult struct {
+	ListingNumber ListingNumber
+	Listing       Listing
+	Found         bool
+}
+
+// ListingFetcher supports retrieving specific listings by listing number.
+type ListingFetcher interface {
+	Listings([]ListingNumber) ([]ListingResult, error)
+}
+
+// Listings retrieves the listings with the given listing numbers, regardless of
+// their listing status. The results are in the same order as the requested
+// listing numbers, with one ListingResult per requested number. Large sets of
+// listing numbers are split across multiple Search requests.
+func (c defaultClient) Listings(listingNumbers []ListingNumber) ([]ListingResult, error) {
+	found := map[ListingNumber]Listing{}
+	for start := 0; start < len(listingNumbers); start += listingsPageSize {
+		end := start + listingsPageSize
+		if end > len(listingNumbers) {
+			end = len(listingNumbers)
+		}
+		page := listingNumbers[start:end]
+		response, err := c.Search(SearchParams{
+			Limit: len(page),
+			Filter: SearchFilter{
+				ListingNumber: page,
+				ListingStatus: allListingStatuses,
+			},
+		})
+		if err != nil {
+			return []ListingResult{}, err
+		}
+		for _, l := range response.Results {
+			found[l.ListingNumber] = l
+		}
+	}
+	results := make([]ListingResult, len(listingNumbers))
+	for i, listingNumber := range listingNumbers {
+		l, ok := found[listingNumber]
+		results[i] = ListingResult{
+			ListingNumber: listingNumber,
+			Listing:       l,
+			Found:         ok,
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,140 @@
+package prosper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mtlynch/gofn-prosper/prosper/thin"
+)
+
+// mockListingsRawClient serves Search requests from a fixed set of raw
+// listings, returning only the listings whose numbers appear in the
+// listing_number filter.
+type mockListingsRawClient struct {
+	mockRawClient
+	listings        []thin.SearchResult
+	searchParamsGot []thin.SearchParams
+}
+
+func (c *mockListingsRawClient) Search(p thin.SearchParams) (thin.SearchResponse, error) {
+	c.searchParamsGot = append(c.searchParamsGot, p)
+	if c.err != nil {
+		return thin.SearchResponse{}, c.err
+	}
+	var results []thin.SearchResult
+	for _, l := range c.listings {
+		for _, n := range p.Filter.ListingNumber {
+			if l.ListingNumber == n {
+				results = append(results, l)
+				break
+			}
+		}
+	}
+	return thin.SearchResponse{
+		Results:     results,
+		ResultCount: len(results),
+		TotalCount:  len(results),
+	}, nil
+}
+
+type listingNumberParser struct{}
+
+func (p listingNumberParser) Parse(r thin.SearchResult) (Listing, error) {
+	return Listing{ListingNumber: ListingNumber(r.ListingNumber)}, nil
+}
+
+func TestListings(t *testing.T) {
+	manyListingNumbers := []ListingNumber{}
+	manyRawListings := []thin.SearchResult{}
+	manyWant := []ListingResult{}
+	for i := 1; i <= 250; i++ {
+		manyListingNumbers = append(manyListingNumbers, ListingNumber(i))
+		manyRawListings = append(manyRawListings, thin.SearchResult{ListingNumber: int64(i)})
+		manyWant = append(manyWant, ListingResult{
+			ListingNumber: ListingNumber(i),
+			Listing:       Listing{ListingNumber: ListingNumber(i)},
+			Found:         true,
+		})
+	}
+	var tests = []struct {
+		listingNumbers []ListingNumber
+		rawListings    []thin.SearchResult
+		rawClientErr   error
+		want           []ListingResult
+		wantSearches   int
+		wantErr        error
+		msg            string
+	}{
+		{
+			listingNumbers: []ListingNumber{},
+			want:           []ListingResult{},
+			wantSearches:   0,
+			msg:            "requesting no listings should not search",
+		},
+		{
+			listingNumbers: []ListingNumber{1234, 4567},
+			rawClientErr:   errMockRawClientFail,
+			wantSearches:   1,
+			wantErr:        errMockRawClientFail,
+			msg:            "listings should fail when raw client fails",
+		},
+		{
+			listingNumbers: []ListingNumber{4567, 1234},
+			rawListings:    []thin.SearchResult{rawListingA, rawListingB},
+			want: []ListingResult{
+				{ListingNumber: 4567, Listing: listingB, Found: true},
+				{ListingNumber: 1234, Listing: listingA, Found: true},
+			},
+			wantSearches: 1,
+			msg:          "results should be in requested order",
+		},
+		{
+			listingNumbers: []ListingNumber{1234, 9999, 4567},
+			rawListings:    []thin.SearchResult{rawListingA, rawListingB},
+			want: []ListingResult{
+				{ListingNumber: 1234, Listing: listingA, Found: true},
+				{ListingNumber: 9999, Found: false},
+				{ListingNumber: 4567, Listing: listingB, Found: true},
+			},
+			wantSearches: 1,
+			msg:          "missing listings should be marked as not found",
+		},
+		{
+			listingNumbers: manyListingNumbers,
+			rawListings:    manyRawListings,
+			want:           manyWant,
+			wantSearches:   3,
+			msg:            "large sets of listing numbers should be split across pages",
+		},
+	}
+	for _, tt := range tests {
+		rawClient := mockListingsRawClient{
+			mockRawClient: mockRawClient{err: tt.rawClientErr},
+			listings:      tt.rawListings,
+		}
+		c := defaultClient{
+			rawClient:     &rawClient,
+			listingParser: listingNumberParser{},
+		}
+		got, err := c.Listings(tt.listingNumbers)
+		if err != tt.wantErr {
+			t.Errorf("%s: defaultClient.Listings got unexpected error. got %v, want %v", tt.msg, err, tt.wantErr)
+		} else if err == nil && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: defaultClient.Listings got %+v, want %+v", tt.msg, got, tt.want)
+		}
+		if len(rawClient.searchParamsGot) != tt.wantSearches {
+			t.Errorf("%s: unexpected number of searches. got %d, want %d", tt.msg, len(rawClient.searchParamsGot), tt.wantSearches)
+		}
+		for _, p := range rawClient.searchParamsGot {
+			if len(p.Filter.ListingNumber) > listingsPageSize {
+				t.Errorf("%s: search requested %d listings, want at most %d", tt.msg, len(p.Filter.ListingNumber), listingsPageSize)
+			}
+			if p.Limit != len(p.Filter.ListingNumber) {
+				t.Errorf("%s: search limit %d does not match listing count %d", tt.msg, p.Limit, len(p.Filter.ListingNumber))
+			}
+			if len(p.Filter.ListingStatus) != len(allListingStatuses) {
+				t.Errorf("%s: search should include listings of every status, got %v", tt.msg, p.Filter.ListingStatus)
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"testing"
+)
+
+func TestStdLogger(t *testing.T) {
+	var tests = []struct {
+		minLevel Level
+		level    Level
+		msg      string
+		fields   []Field
+		want     string
+	}{
+		{
+			minLevel: Debug,
+			level:    Info,
+			msg:      "request completed",
+			fields:   []Field{F("method", "GET"), F("status", 200)},
+			want:     "INFO request completed method=GET status=200\n",
+		},
+		{
+			minLevel: Debug,
+			level:    Warn,
+			msg:      "parse failed",
+			fields:   []Field{Redacted("value")},
+			want:     "WARN parse failed value=[REDACTED]\n",
+		},
+		{
+			minLevel: Warn,
+			level:    Info,
+			msg:      "request completed",
+			want:     "",
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		NewStdLogger(log.New(&buf, "", 0), tt.minLevel).Log(tt.level, tt.msg, tt.fields...)
+		if got := buf.String(); got != tt.want {
+			t.Errorf("stdLogger.Log got %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestOrDiscard(t *testing.T) {
+	if OrDiscard(nil) != Discard {
+		t.Errorf("OrDiscard(nil) should return Discard")
+	}
+	l := NewStdLogger(log.New(&bytes.Buffer{}, "", 0), Debug)
+	if OrDiscard(l) != l {
+		t.Errorf("OrDiscard should return non-nil logger unchanged")
+	}
+}
+
+func TestFromSlog(t *testing.T) {
+	var tests = []struct {
+		level  Level
+		msg    string
+		fields []Field
+		want   string
+	}{
+		{
+			level:  Info,
+			msg:    "request completed",
+			fields: []Field{F("method", "GET"), F("status", 200)},
+			want:   `{"level":"INFO","msg":"request completed","method":"GET","status":200}` + "\n",
+		},
+		{
+			level:  Error,
+			msg:    "parse failed",
+			fields: []Field{Redacted("value")},
+			want:   `{"level":"ERROR","msg":"parse failed","value":"[REDACTED]"}` + "\n",
+		},
+		{
+			level: Warn,
+			msg:   "token expired",
+			want:  `{"level":"WARN","msg":"token expired"}` + "\n",
+		},
+		{
+			level: Debug,
+			msg:   "below handler level",
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+			Level: slog.LevelInfo,
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey && len(groups) == 0 {
+					return slog.Attr{}
+				}
+				return a
+			},
+		})
+		FromSlog(slog.New(handler)).Log(tt.level, tt.msg, tt.fields...)
+		if got := buf.String(); got != tt.want {
+			t.Errorf("slogLogger.Log got %q, want %q", got, tt.want)
+		}
+	}
+}
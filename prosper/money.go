@@ -0,0 +1,193 @@
+package prosper
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money represents an exact amount of US dollars as an integer number of
+// micro-dollars (millionths of a dollar). Micro-dollar precision is enough to
+// hold the pro-rata share amounts Prosper reports without rounding, and sums of
+// Money values never drift the way sums of float64 values do.
+type Money int64
+
+// Common Money units.
+const (
+	Microdollar Money = 1
+	Cent        Money = 10000
+	Dollar      Money = 1000000
+)
+
+const microdollarDigits = 6
+
+// MoneyFromCents returns the Money value for a whole number of cents.
+func MoneyFromCents(cents int64) Money {
+	return Money(cents) * Cent
+}
+
+// Range of Money values.
+const (
+	MaxMoney Money = math.MaxInt64
+	MinMoney Money = -MaxMoney
+)
+
+// MoneyFromFloat64 converts a dollar amount to Money, rounding to the nearest
+// micro-dollar. Amounts decoded from Prosper's JSON convert exactly, as
+// float64 has more than enough precision for the six decimal places Money
+// keeps. NaN converts to zero, and amounts beyond the range of Money saturate
+// at MinMoney or MaxMoney; use CheckedMoneyFromFloat64 to reject them instead.
+func MoneyFromFloat64(dollars float64) Money {
+	m, err := CheckedMoneyFromFloat64(dollars)
+	if err == nil {
+		return m
+	}
+	switch {
+	case dollars > 0:
+		return MaxMoney
+	case dollars < 0:
+		return MinMoney
+	}
+	return 0
+}
+
+// CheckedMoneyFromFloat64 converts a dollar amount to Money as
+// MoneyFromFloat64 does, but fails if dollars is NaN, infinite, or beyond the
+// range of Money.
+func CheckedMoneyFromFloat64(dollars float64) (Money, error) {
+	if math.IsNaN(dollars) || math.IsInf(dollars, 0) {
+		return 0, fmt.Errorf("invalid money value: %v", dollars)
+	}
+	micros := math.Floor(math.Abs(dollars)*float64(Dollar) + 0.5)
+	// float64(MaxMoney) rounds up to 2^63, the first value out of range.
+	if micros >= float64(MaxMoney) {
+		return 0, fmt.Errorf("money value out of range: %v", dollars)
+	}
+	if dollars < 0 {
+		return -Money(micros), nil
+	}
+	return Money(micros), nil
+}
+
+// ParseMoney parses a decimal dollar amount such as "25", "-3.5" or "0.012345"
+// without passing through float64. Values with more than six decimal places or
+// in exponent notation are rounded to the nearest micro-dollar.
+func ParseMoney(s string) (Money, error) {
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid money value: %s", s)
+		}
+		return CheckedMoneyFromFloat64(f)
+	}
+	negative := strings.HasPrefix(s, "-")
+	digits := strings.TrimPrefix(s, "-")
+	whole, fraction := digits, ""
+	if i := strings.Index(digits, "."); i >= 0 {
+		whole, fraction = digits[:i], digits[i+1:]
+	}
+	if (len(whole) == 0 && len(fraction) == 0) || !isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("invalid money value: %s", s)
+	}
+	roundUp := false
+	if len(fraction) > microdollarDigits {
+		roundUp = fraction[microdollarDigits] >= '5'
+		fraction = fraction[:microdollarDigits]
+	}
+	fraction += strings.Repeat("0", microdollarDigits-len(fraction))
+	micros, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid money value: %s", s)
+	}
+	if roundUp {
+		if micros == int64(MaxMoney) {
+			return 0, fmt.Errorf("money value out of range: %s", s)
+		}
+		micros++
+	}
+	if negative {
+		micros = -micros
+	}
+	return Money(micros), nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Add returns the sum of m and o.
+func (m Money) Add(o Money) Money {
+	return m + o
+}
+
+// Sub returns the difference of m and o.
+func (m Money) Sub(o Money) Money {
+	return m - o
+}
+
+// Mul returns m multiplied by n.
+func (m Money) Mul(n int64) Money {
+	return m * Money(n)
+}
+
+// RoundToCents returns m rounded to the nearest cent, with half-cent amounts
+// rounded away from zero.
+func (m Money) RoundToCents() Money {
+	if m < 0 {
+		return -(-m).RoundToCents()
+	}
+	return (m + Cent/2) / Cent * Cent
+}
+
+// Cents returns m as a whole number of cents, rounded as in RoundToCents.
+func (m Money) Cents() int64 {
+	return int64(m.RoundToCents() / Cent)
+}
+
+// Float64 returns m as a float64 number of dollars.
+func (m Money) Float64() float64 {
+	return float64(m) / float64(Dollar)
+}
+
+// String returns m as a decimal dollar amount with at least two decimal
+// places, such as "25.00" or "0.012345".
+func (m Money) String() string {
+	sign := ""
+	micros := int64(m)
+	if micros < 0 {
+		sign = "-"
+		micros = -micros
+	}
+	fraction := fmt.Sprintf("%06d", micros%int64(Dollar))
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) < 2 {
+		fraction += strings.Repeat("0", 2-len(fraction))
+	}
+	return fmt.Sprintf("%s%d.%s", sign, micros/int64(Dollar), fraction)
+}
+
+// MarshalJSON implements json.Marshaler, encoding m as a JSON number.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a JSON number or a
+// quoted decimal string and parses it exactly, without passing through float64.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	s := strings.Trim(string(data), `"`)
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
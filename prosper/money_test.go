@@ -0,0 +1,226 @@
+package prosper
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/mtlynch/gofn-prosper/prosper/thin"
+)
+
+func TestParseMoney(t *testing.T) {
+	var tests = []struct {
+		s             string
+		want          Money
+		expectSuccess bool
+		msg           string
+	}{
+		{"25", 25 * Dollar, true, "whole dollars should parse"},
+		{"25.01", 25*Dollar + Cent, true, "dollars and cents should parse"},
+		{"-3.5", -(3*Dollar + 50*Cent), true, "negative amount should parse"},
+		{"0.012345", 12345, true, "micro-dollar amount should parse"},
+		{".5", 50 * Cent, true, "missing whole part should parse"},
+		{"0.0000005", 1, true, "seventh decimal place should round up"},
+		{"0.0000004", 0, true, "seventh decimal place should round down"},
+		{"1.5e1", 15 * Dollar, true, "exponent notation should parse"},
+		{"", 0, false, "empty string should fail"},
+		{"-", 0, false, "lone sign should fail"},
+		{"1.2.3", 0, false, "multiple decimal points should fail"},
+		{"$5", 0, false, "currency symbol should fail"},
+		{"1e300", 0, false, "exponent beyond the range of Money should fail"},
+		{"9223372036854.7758075", 0, false, "rounding beyond the range of Money should fail"},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.s)
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s: ParseMoney(%q) failed: %v", tt.msg, tt.s, err)
+		} else if !tt.expectSuccess && err == nil {
+			t.Errorf("%s: expected ParseMoney(%q) to fail", tt.msg, tt.s)
+		} else if got != tt.want {
+			t.Errorf("%s: ParseMoney(%q) = %d, want %d", tt.msg, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	var tests = []struct {
+		m    Money
+		want string
+	}{
+		{0, "0.00"},
+		{25 * Dollar, "25.00"},
+		{25*Dollar + 10*Cent, "25.10"},
+		{12345, "0.012345"},
+		{-(3*Dollar + 50*Cent), "-3.50"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("Money(%d).String() = %q, want %q", tt.m, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyFromFloat64(t *testing.T) {
+	var tests = []struct {
+		f    float64
+		want Money
+	}{
+		{25.01, 25*Dollar + Cent},
+		{0.1 + 0.2, 30 * Cent},
+		{-12.345678, -12345678},
+		{25.000000001, 25 * Dollar},
+	}
+	for _, tt := range tests {
+		if got := MoneyFromFloat64(tt.f); got != tt.want {
+			t.Errorf("MoneyFromFloat64(%v) = %d, want %d", tt.f, got, tt.want)
+		}
+	}
+}
+
+func TestCheckedMoneyFromFloat64(t *testing.T) {
+	var tests = []struct {
+		f             float64
+		want          Money
+		saturated     Money
+		expectSuccess bool
+		msg           string
+	}{
+		{25.01, 25*Dollar + Cent, 25*Dollar + Cent, true, "finite amount should convert"},
+		{-12.345678, -12345678, -12345678, true, "negative amount should convert"},
+		{math.NaN(), 0, 0, false, "NaN should fail"},
+		{math.Inf(1), 0, MaxMoney, false, "positive infinity should fail"},
+		{math.Inf(-1), 0, MinMoney, false, "negative infinity should fail"},
+		{1e13, 0, MaxMoney, false, "amount above the range of Money should fail"},
+		{-1e13, 0, MinMoney, false, "amount below the range of Money should fail"},
+	}
+	for _, tt := range tests {
+		got, err := CheckedMoneyFromFloat64(tt.f)
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s: CheckedMoneyFromFloat64(%v) failed: %v", tt.msg, tt.f, err)
+		} else if !tt.expectSuccess && err == nil {
+			t.Errorf("%s: expected CheckedMoneyFromFloat64(%v) to fail", tt.msg, tt.f)
+		} else if got != tt.want {
+			t.Errorf("%s: CheckedMoneyFromFloat64(%v) = %d, want %d", tt.msg, tt.f, got, tt.want)
+		}
+		if got := MoneyFromFloat64(tt.f); got != tt.saturated {
+			t.Errorf("%s: MoneyFromFloat64(%v) = %d, want %d", tt.msg, tt.f, got, tt.saturated)
+		}
+	}
+}
+
+func TestMoneySumDoesNotDrift(t *testing.T) {
+	var sum Money
+	for i := 0; i < 10000; i++ {
+		sum = sum.Add(MoneyFromFloat64(0.01))
+	}
+	if sum != 100*Dollar {
+		t.Errorf("sum of 10000 cents = %v, want 100.00", sum)
+	}
+}
+
+func TestMoneyRoundToCents(t *testing.T) {
+	var tests = []struct {
+		m    Money
+		want Money
+	}{
+		{25*Dollar + 4999, 25 * Dollar},
+		{25*Dollar + 5000, 25*Dollar + Cent},
+		{-(25*Dollar + 5000), -(25*Dollar + Cent)},
+	}
+	for _, tt := range tests {
+		if got := tt.m.RoundToCents(); got != tt.want {
+			t.Errorf("Money(%d).RoundToCents() = %d, want %d", tt.m, got, tt.want)
+		}
+	}
+	if got := (25*Dollar + 5000).Cents(); got != 2501 {
+		t.Errorf("Cents() = %d, want 2501", got)
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var v struct {
+		Amount Money
+		Quoted Money
+	}
+	if err := json.Unmarshal([]byte(`{"Amount": 0.30000000, "Quoted": "12.5"}`), &v); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if v.Amount != 30*Cent || v.Quoted != 12*Dollar+50*Cent {
+		t.Errorf("json.Unmarshal got %+v", v)
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	want := `{"Amount":0.30,"Quoted":12.50}`
+	if string(encoded) != want {
+		t.Errorf("json.Marshal = %s, want %s", encoded, want)
+	}
+}
+
+func TestNewBidRequest(t *testing.T) {
+	got := NewBidRequest(ListingNumber(1234), 25*Dollar+1)
+	if got.BidAmount != 25.0 || got.ListingID != 1234 {
+		t.Errorf("NewBidRequest returned %+v, want BidAmount 25", got)
+	}
+}
+
+func TestNoteAmounts(t *testing.T) {
+	notes := []Note{
+		{PrincipalPaidProRataShare: 0.1},
+		{PrincipalPaidProRataShare: 0.2},
+	}
+	var sum Money
+	for _, n := range notes {
+		sum = sum.Add(n.Amounts().PrincipalPaidProRataShare)
+	}
+	if sum != 30*Cent {
+		t.Errorf("sum of note amounts = %v, want 0.30", sum)
+	}
+}
+
+func TestListingAmountsUseExactText(t *testing.T) {
+	var tests = []struct {
+		text          json.Number
+		dollars       float64
+		want          Money
+		expectSuccess bool
+		msg           string
+	}{
+		{
+			text:          "12345678901.234567",
+			dollars:       12345678901.234567,
+			want:          12345678901*Dollar + 234567,
+			expectSuccess: true,
+			msg:           "amount beyond float64 precision should convert exactly from its text",
+		},
+		{
+			dollars:       25.5,
+			want:          25*Dollar + 50*Cent,
+			expectSuccess: true,
+			msg:           "amount without text should convert from the float64 field",
+		},
+		{
+			text:          "bogus",
+			dollars:       25.5,
+			want:          25*Dollar + 50*Cent,
+			expectSuccess: false,
+			msg:           "malformed text should fall back to the float64 field",
+		},
+	}
+	for _, tt := range tests {
+		l := Listing{
+			ListingAmount: tt.dollars,
+			amountText:    thin.SearchResultAmounts{ListingAmount: tt.text},
+		}
+		if got := l.Amounts().ListingAmount; got != tt.want {
+			t.Errorf("%s: Amounts().ListingAmount = %v, want %v", tt.msg, got, tt.want)
+		}
+		_, err := l.CheckedAmounts()
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s: CheckedAmounts failed: %v", tt.msg, err)
+		} else if !tt.expectSuccess && err == nil {
+			t.Errorf("%s: expected CheckedAmounts to fail", tt.msg)
+		}
+	}
+}
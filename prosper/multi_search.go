@@ -0,0 +1,120 @@
+package prosper
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultMultiSearchConcurrency = 4
+
+type (
+	// MultiSearchParams contains the parameters to MultiSearch. A listing
+	// matches if it matches any of Searches, so together they express an OR of
+	// filters that a single Search, which ANDs its filter clauses, cannot.
+	MultiSearchParams struct {
+		Searches []SearchParams
+		// Concurrency is the maximum number of searches to run at once.
+		// Defaults to 4 if not positive.
+		Concurrency int
+	}
+
+	// MultiSearchResult is a listing that matched one or more searches, along
+	// with the indexes in MultiSearchParams.Searches of the searches it
+	// matched, in ascending order.
+	MultiSearchResult struct {
+		Listing         Listing
+		MatchedSearches []int
+	}
+
+	// MultiSearchResponse contains the merged results of MultiSearch, with one
+	// result per distinct listing. Results are ordered by the first search that
+	// matched each listing, then by that search's result order. ParseErrors
+	// collects the parse errors of searches that use ParseLenient.
+	MultiSearchResponse struct {
+		Results     []MultiSearchResult
+		ParseErrors []*ListingParseError
+	}
+
+	// MultiSearcher supports running several searches as a single OR query.
+	MultiSearcher interface {
+		MultiSearch(MultiSearchParams) (MultiSearchResponse, error)
+	}
+)
+
+// MultiSearch runs each of p.Searches concurrently, subject to the client's
+// rate limiter, and merges their results, removing duplicate listings. Each
+// search pages through every matching listing as AllListings does, starting at
+// its Offset and using its Limit as the page size. It fails if any search
+// fails.
+func (c defaultClient) MultiSearch(p MultiSearchParams) (MultiSearchResponse, error) {
+	if p.Concurrency <= 0 {
+		p.Concurrency = defaultMultiSearchConcurrency
+	}
+
+	responses := make([]SearchResponse, len(p.Searches))
+	errs := make([]error, len(p.Searches))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < p.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				responses[i], errs[i] = searchAll(c, p.Searches[i])
+			}
+		}()
+	}
+	for i := range p.Searches {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return MultiSearchResponse{}, err
+		}
+	}
+	return mergeSearchResponses(responses), nil
+}
+
+// searchAll collects every listing that matches p into a single
+// SearchResponse.
+func searchAll(s ListingSearcher, p SearchParams) (SearchResponse, error) {
+	var response SearchResponse
+	it := AllListings(context.Background(), s, p)
+	for it.Next() {
+		response.Results = append(response.Results, it.Listing())
+	}
+	if err := it.Err(); err != nil {
+		return SearchResponse{}, err
+	}
+	response.ParseErrors = it.ParseErrors()
+	return response, nil
+}
+
+// mergeSearchResponses combines the responses of several searches, in search
+// order, into a single MultiSearchResponse with one result per listing number.
+func mergeSearchResponses(responses []SearchResponse) MultiSearchResponse {
+	var merged MultiSearchResponse
+	positions := map[ListingNumber]int{}
+	for i, response := range responses {
+		for _, l := range response.Results {
+			pos, ok := positions[l.ListingNumber]
+			if !ok {
+				positions[l.ListingNumber] = len(merged.Results)
+				merged.Results = append(merged.Results, MultiSearchResult{
+					Listing:         l,
+					MatchedSearches: []int{i},
+				})
+				continue
+			}
+			matched := merged.Results[pos].MatchedSearches
+			if matched[len(matched)-1] != i {
+				merged.Results[pos].MatchedSearches = append(matched, i)
+			}
+		}
+		merged.ParseErrors = append(merged.ParseErrors, response.ParseErrors...)
+	}
+	return merged
+}
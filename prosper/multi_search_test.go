@@ -0,0 +1,170 @@
+package prosper
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mtlynch/gofn-prosper/prosper/thin"
+)
+
+// mockMultiSearchRawClient serves the listings registered for each Prosper
+// rating filter, one page at a time, failing searches for ratings in
+// failRatings.
+type mockMultiSearchRawClient struct {
+	mockRawClient
+	listingsByRating map[string][]int64
+	failRatings      map[string]bool
+	lock             sync.Mutex
+}
+
+func (c *mockMultiSearchRawClient) Search(p thin.SearchParams) (thin.SearchResponse, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	key := strings.Join(p.Filter.Rating, ",")
+	if c.failRatings[key] {
+		return thin.SearchResponse{}, errMockRawClientFail
+	}
+	listings := c.listingsByRating[key]
+	page := listings[min(p.Offset, len(listings)):]
+	if p.Limit > 0 && p.Limit < len(page) {
+		page = page[:p.Limit]
+	}
+	var results []thin.SearchResult
+	for _, n := range page {
+		results = append(results, thin.SearchResult{ListingNumber: n})
+	}
+	return thin.SearchResponse{Results: results, ResultCount: len(results), TotalCount: len(listings)}, nil
+}
+
+// mockListingNumberParser parses only the listing number of a raw listing.
+type mockListingNumberParser struct{}
+
+func (p mockListingNumberParser) Parse(r thin.SearchResult) (Listing, error) {
+	return Listing{ListingNumber: ListingNumber(r.ListingNumber)}, nil
+}
+
+type countingRateLimiter struct {
+	waits int
+	lock  sync.Mutex
+}
+
+func (r *countingRateLimiter) Wait() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.waits++
+}
+
+func TestMultiSearch(t *testing.T) {
+	searchFor := func(ratings ...Rating) SearchParams {
+		return SearchParams{Filter: SearchFilter{Rating: ratings}}
+	}
+	var tests = []struct {
+		params      MultiSearchParams
+		failRatings map[string]bool
+		want        []MultiSearchResult
+		wantErr     error
+		wantWaits   int
+		msg         string
+	}{
+		{
+			params: MultiSearchParams{},
+			want:   nil,
+			msg:    "no searches should return no results",
+		},
+		{
+			params: MultiSearchParams{
+				Searches: []SearchParams{
+					{Limit: 1, Filter: SearchFilter{Rating: []Rating{RatingA}}},
+					{Limit: 2, Filter: SearchFilter{Rating: []Rating{RatingB}}},
+				},
+			},
+			want: []MultiSearchResult{
+				{Listing: Listing{ListingNumber: 1}, MatchedSearches: []int{0}},
+				{Listing: Listing{ListingNumber: 2}, MatchedSearches: []int{0, 1}},
+				{Listing: Listing{ListingNumber: 3}, MatchedSearches: []int{1}},
+				{Listing: Listing{ListingNumber: 4}, MatchedSearches: []int{1}},
+			},
+			wantWaits: 4,
+			msg:       "each search should page through all of its results",
+		},
+		{
+			params: MultiSearchParams{
+				Searches:    []SearchParams{searchFor(RatingA), searchFor(RatingB)},
+				Concurrency: -1,
+			},
+			want: []MultiSearchResult{
+				{Listing: Listing{ListingNumber: 1}, MatchedSearches: []int{0}},
+				{Listing: Listing{ListingNumber: 2}, MatchedSearches: []int{0, 1}},
+				{Listing: Listing{ListingNumber: 3}, MatchedSearches: []int{1}},
+				{Listing: Listing{ListingNumber: 4}, MatchedSearches: []int{1}},
+			},
+			wantWaits: 2,
+			msg:       "negative concurrency should use the default",
+		},
+		{
+			params: MultiSearchParams{
+				Searches: []SearchParams{searchFor(RatingA), searchFor(RatingB), searchFor(RatingAA)},
+			},
+			want: []MultiSearchResult{
+				{Listing: Listing{ListingNumber: 1}, MatchedSearches: []int{0}},
+				{Listing: Listing{ListingNumber: 2}, MatchedSearches: []int{0, 1}},
+				{Listing: Listing{ListingNumber: 3}, MatchedSearches: []int{1}},
+				{Listing: Listing{ListingNumber: 4}, MatchedSearches: []int{1}},
+			},
+			wantWaits: 3,
+			msg:       "overlapping searches should be merged and de-duplicated",
+		},
+		{
+			params: MultiSearchParams{
+				Searches:    []SearchParams{searchFor(RatingA), searchFor(RatingA)},
+				Concurrency: 1,
+			},
+			want: []MultiSearchResult{
+				{Listing: Listing{ListingNumber: 1}, MatchedSearches: []int{0, 1}},
+				{Listing: Listing{ListingNumber: 2}, MatchedSearches: []int{0, 1}},
+			},
+			wantWaits: 2,
+			msg:       "identical searches should both be reported as matches",
+		},
+		{
+			params: MultiSearchParams{
+				Searches: []SearchParams{searchFor(RatingA), searchFor(RatingHR)},
+			},
+			failRatings: map[string]bool{"HR": true},
+			wantErr:     errMockRawClientFail,
+			wantWaits:   2,
+			msg:         "failure of any search should fail",
+		},
+	}
+	for _, tt := range tests {
+		rawClient := mockMultiSearchRawClient{
+			listingsByRating: map[string][]int64{
+				"A": {1, 2},
+				"B": {2, 3, 4},
+			},
+			failRatings: tt.failRatings,
+		}
+		rateLimiter := countingRateLimiter{}
+		c := defaultClient{
+			rawClient:     &rawClient,
+			listingParser: mockListingNumberParser{},
+			rateLimiter:   &rateLimiter,
+		}
+		got, err := c.MultiSearch(tt.params)
+		if err != tt.wantErr {
+			t.Errorf("%s: unexpected error. got %v, want %v", tt.msg, err, tt.wantErr)
+			continue
+		}
+		if rateLimiter.waits != tt.wantWaits {
+			t.Errorf("%s: rate limiter waited %d times, want %d", tt.msg, rateLimiter.waits, tt.wantWaits)
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(got.Results, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.msg, got.Results, tt.want)
+		}
+	}
+}
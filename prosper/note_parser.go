@@ -10,28 +10,42 @@ type noteParser interface {
 	Parse(thin.NoteResult) (Note, error)
 }
 
-type defaultNoteParser struct{}
+type defaultNoteParser struct {
+	// unknownEnums controls whether unrecognized enum values are accepted.
+	unknownEnums unknownEnumPolicy
+}
 
 func (p defaultNoteParser) Parse(r thin.NoteResult) (Note, error) {
+	unknownValues := map[string]string{}
 	originationDate, err := parseProsperDate(r.OriginationDate)
 	if err != nil {
-		return Note{}, err
+		return Note{}, newNoteParseError(r.LoanNoteID, "origination_date", r.OriginationDate, err)
 	}
 	nextPaymentDueDate, err := parseProsperDate(r.NextPaymentDueDate)
 	if err != nil {
-		return Note{}, err
+		return Note{}, newNoteParseError(r.LoanNoteID, "next_payment_due_date", r.NextPaymentDueDate, err)
 	}
 	defaultReason, err := parseDefaultReason(r.NoteDefaultReason)
 	if err != nil {
-		return Note{}, err
+		if !p.unknownEnums.acceptUnknown("note_default_reason", r.NoteDefaultReason, unknownValues) {
+			return Note{}, newNoteParseError(r.LoanNoteID, "note_default_reason", r.NoteDefaultReason, err)
+		}
+		unknownReason := DefaultReasonUnknown
+		defaultReason = &unknownReason
 	}
 	rating, err := parseRating(r.Rating)
 	if err != nil {
-		return Note{}, err
+		if !p.unknownEnums.acceptUnknown("prosper_rating", r.Rating, unknownValues) {
+			return Note{}, newNoteParseError(r.LoanNoteID, "prosper_rating", r.Rating, err)
+		}
+		rating = RatingUnknown
 	}
 	noteStatus, err := parseNoteStatus(r.NoteStatus)
 	if err != nil {
-		return Note{}, err
+		if !p.unknownEnums.acceptUnknown("note_status", r.NoteStatus, unknownValues) {
+			return Note{}, newNoteParseError(r.LoanNoteID, "note_status", r.NoteStatus, err)
+		}
+		noteStatus = NoteStatusUnknown
 	}
 	return Note{
 		AgeInMonths:                          r.AgeInMonths,
@@ -40,27 +54,30 @@ func (p defaultNoteParser) Parse(r thin.NoteResult) (Note, error) {
 		DaysPastDue:                          r.DaysPastDue,
 		DebtSaleProceedsReceivedProRataShare: r.DebtSaleProceedsReceivedProRataShare,
 		InterestPaidProRataShare:             r.InterestPaidProRataShare,
-		IsSold: r.IsSold,
-		LateFeesPaidProRataShare:         r.LateFeesPaidProRataShare,
-		ListingNumber:                    ListingNumber(r.ListingNumber),
-		LoanNoteID:                       r.LoanNoteID,
-		LoanNumber:                       r.LoanNumber,
-		NextPaymentDueAmountProRataShare: r.NextPaymentDueAmountProRataShare,
-		NextPaymentDueDate:               nextPaymentDueDate,
-		NoteDefaultReasonDescription:     r.NoteDefaultReasonDescription,
-		NoteDefaultReason:                defaultReason,
-		NoteOwnershipAmount:              r.NoteOwnershipAmount,
-		NoteSaleFeesPaid:                 r.NoteSaleFeesPaid,
-		NoteSaleGrossAmountReceived:      r.NoteSaleGrossAmountReceived,
-		NoteStatusDescription:            r.NoteStatusDescription,
-		NoteStatus:                       noteStatus,
-		OriginationDate:                  originationDate,
-		PrincipalBalanceProRataShare:     r.PrincipalBalanceProRataShare,
-		PrincipalPaidProRataShare:        r.PrincipalPaidProRataShare,
-		ProsperFeesPaidProRataShare:      r.ProsperFeesPaidProRataShare,
-		Rating: rating,
-		ServiceFeesPaidProRataShare: r.ServiceFeesPaidProRataShare,
-		Term: r.Term,
+		IsSold:                               r.IsSold,
+		LateFeesPaidProRataShare:             r.LateFeesPaidProRataShare,
+		ListingNumber:                        ListingNumber(r.ListingNumber),
+		LoanNoteID:                           r.LoanNoteID,
+		LoanNumber:                           r.LoanNumber,
+		NextPaymentDueAmountProRataShare:     r.NextPaymentDueAmountProRataShare,
+		NextPaymentDueDate:                   nextPaymentDueDate,
+		NoteDefaultReasonDescription:         r.NoteDefaultReasonDescription,
+		NoteDefaultReason:                    defaultReason,
+		NoteOwnershipAmount:                  r.NoteOwnershipAmount,
+		NoteSaleFeesPaid:                     r.NoteSaleFeesPaid,
+		NoteSaleGrossAmountReceived:          r.NoteSaleGrossAmountReceived,
+		NoteStatusDescription:                r.NoteStatusDescription,
+		NoteStatus:                           noteStatus,
+		OriginationDate:                      originationDate,
+		PrincipalBalanceProRataShare:         r.PrincipalBalanceProRataShare,
+		PrincipalPaidProRataShare:            r.PrincipalPaidProRataShare,
+		ProsperFeesPaidProRataShare:          r.ProsperFeesPaidProRataShare,
+		Rating:                               rating,
+		ServiceFeesPaidProRataShare:          r.ServiceFeesPaidProRataShare,
+		Term:                                 r.Term,
+		UnknownEnumValues:                    nilIfEmpty(unknownValues),
+		Raw:                                  r.Raw,
+		amountText:                           r.Amounts,
 	}, nil
 }
 
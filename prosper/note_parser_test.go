@@ -3,7 +3,6 @@ package prosper
 import (
 	"reflect"
 	"testing"
-	"time"
 
 	"github.com/mtlynch/gofn-prosper/prosper/thin"
 )
@@ -45,7 +44,7 @@ func TestNoteParser(t *testing.T) {
 				NoteStatusDescription:                "DEFAULTED",
 				NoteDefaultReason:                    2,
 				NoteDefaultReasonDescription:         "Bankruptcy",
-				IsSold: false,
+				IsSold:                               false,
 			},
 			want: Note{
 				LoanNumber:                           7735,
@@ -54,11 +53,11 @@ func TestNoteParser(t *testing.T) {
 				Rating:                               RatingNA,
 				Term:                                 36,
 				AgeInMonths:                          100,
-				OriginationDate:                      time.Date(2014, 02, 22, 0, 0, 0, 0, time.UTC),
+				OriginationDate:                      Date{2014, 2, 22},
 				DaysPastDue:                          252,
 				PrincipalBalanceProRataShare:         42.17326,
 				InterestPaidProRataShare:             4.7373,
-				NextPaymentDueDate:                   time.Date(2015, 07, 23, 0, 0, 0, 0, time.UTC),
+				NextPaymentDueDate:                   Date{2015, 7, 23},
 				DebtSaleProceedsReceivedProRataShare: 0,
 				LateFeesPaidProRataShare:             0,
 				NextPaymentDueAmountProRataShare:     0,
@@ -74,7 +73,7 @@ func TestNoteParser(t *testing.T) {
 				NoteStatusDescription:                "DEFAULTED",
 				NoteDefaultReason:                    &defaultReasonBankruptcy,
 				NoteDefaultReasonDescription:         "Bankruptcy",
-				IsSold: false,
+				IsSold:                               false,
 			},
 			expectSuccess: true,
 			msg:           "valid note should parse successfully",
@@ -115,11 +114,11 @@ func TestNoteParser(t *testing.T) {
 				Rating:                               RatingNA,
 				Term:                                 36,
 				AgeInMonths:                          100,
-				OriginationDate:                      time.Date(2014, 02, 22, 0, 0, 0, 0, time.UTC),
+				OriginationDate:                      Date{2014, 2, 22},
 				DaysPastDue:                          252,
 				PrincipalBalanceProRataShare:         42.17326,
 				InterestPaidProRataShare:             4.7373,
-				NextPaymentDueDate:                   time.Date(2015, 07, 23, 0, 0, 0, 0, time.UTC),
+				NextPaymentDueDate:                   Date{2015, 7, 23},
 				DebtSaleProceedsReceivedProRataShare: 0,
 				LateFeesPaidProRataShare:             0,
 				NextPaymentDueAmountProRataShare:     0,
@@ -167,7 +166,7 @@ func TestNoteParser(t *testing.T) {
 				NoteStatusDescription:                "DEFAULTED",
 				NoteDefaultReason:                    999999,
 				NoteDefaultReasonDescription:         "Bankruptcy",
-				IsSold: false,
+				IsSold:                               false,
 			},
 			expectSuccess: false,
 			msg:           "invalid NoteDefaultReason should cause error",
@@ -201,7 +200,7 @@ func TestNoteParser(t *testing.T) {
 				NoteStatusDescription:                "DEFAULTED",
 				NoteDefaultReason:                    2,
 				NoteDefaultReasonDescription:         "Bankruptcy",
-				IsSold: false,
+				IsSold:                               false,
 			},
 			expectSuccess: false,
 			msg:           "invalid NoteStatus should cause error",
@@ -235,7 +234,7 @@ func TestNoteParser(t *testing.T) {
 				NoteStatusDescription:                "DEFAULTED",
 				NoteDefaultReason:                    2,
 				NoteDefaultReasonDescription:         "Bankruptcy",
-				IsSold: false,
+				IsSold:                               false,
 			},
 			expectSuccess: false,
 			msg:           "invalid Rating should cause error",
@@ -269,7 +268,7 @@ func TestNoteParser(t *testing.T) {
 				NoteStatusDescription:                "DEFAULTED",
 				NoteDefaultReason:                    2,
 				NoteDefaultReasonDescription:         "Bankruptcy",
-				IsSold: false,
+				IsSold:                               false,
 			},
 			expectSuccess: false,
 			msg:           "invalid OriginationDate should cause error",
@@ -303,7 +302,7 @@ func TestNoteParser(t *testing.T) {
 				NoteStatusDescription:                "DEFAULTED",
 				NoteDefaultReason:                    2,
 				NoteDefaultReasonDescription:         "Bankruptcy",
-				IsSold: false,
+				IsSold:                               false,
 			},
 			expectSuccess: false,
 			msg:           "invalid NextPaymentDueDate should cause error",
@@ -321,3 +320,45 @@ func TestNoteParser(t *testing.T) {
 		}
 	}
 }
+
+func TestNoteParserReportsInvalidField(t *testing.T) {
+	input := thin.NoteResult{
+		LoanNoteID: "7735-1",
+		Rating:     "Z",
+	}
+	_, err := defaultNoteParser{}.Parse(input)
+	parseErr, ok := err.(*NoteParseError)
+	if !ok {
+		t.Fatalf("expected *NoteParseError, got %T: %v", err, err)
+	}
+	want := NoteParseError{
+		LoanNoteID: "7735-1",
+		Field:      "prosper_rating",
+		Value:      "Z",
+	}
+	if parseErr.LoanNoteID != want.LoanNoteID || parseErr.Field != want.Field || parseErr.Value != want.Value || parseErr.Err == nil {
+		t.Errorf("unexpected parse error. got %+v, want %+v", parseErr, want)
+	}
+}
+
+func TestNoteParserAcceptsAmountOutOfRange(t *testing.T) {
+	input := thin.NoteResult{
+		LoanNoteID:                   "7735-1",
+		Rating:                       "A",
+		NoteStatus:                   1,
+		PrincipalBalanceProRataShare: 1e15,
+		Amounts: thin.NoteResultAmounts{
+			PrincipalBalanceProRataShare: "1e15",
+		},
+	}
+	got, err := defaultNoteParser{}.Parse(input)
+	if err != nil {
+		t.Fatalf("expected successful parsing, got error: %v", err)
+	}
+	if got.PrincipalBalanceProRataShare != 1e15 {
+		t.Errorf("unexpected PrincipalBalanceProRataShare. got %v, want %v", got.PrincipalBalanceProRataShare, 1e15)
+	}
+	if _, err := got.CheckedAmounts(); err == nil {
+		t.Errorf("CheckedAmounts should fail for an amount beyond the range of Money")
+	}
+}
@@ -1,15 +1,16 @@
 package prosper
 
 import (
-	"time"
+	"encoding/json"
 
 	"github.com/mtlynch/gofn-prosper/prosper/thin"
 )
 
 // NotesParams contains the parameters to the Notes API.
 type NotesParams struct {
-	Offset int
-	Limit  int
+	Offset    int
+	Limit     int
+	ParseMode ParseMode
 	// TODO(mtlynch): Implement support for the sort_by parameter.
 }
 
@@ -29,6 +30,10 @@ const (
 	Sold             DefaultReason = 7
 	DefaultReasonMin DefaultReason = Delinquency
 	DefaultReasonMax DefaultReason = Sold
+	// DefaultReasonUnknown stands for a note_default_reason value this package
+	// does not recognize. It encodes as "Unknown", so the raw value Prosper sent
+	// is kept only in the UnknownEnumValues field of the parsed Note.
+	DefaultReasonUnknown DefaultReason = -1
 )
 
 // NoteStatus represents the status of an owned note. The values correspond to
@@ -48,6 +53,10 @@ const (
 	NoteStatusMin          NoteStatus = OriginationDelayed
 	NoteStatusMax          NoteStatus = Cancelled
 	NoteStatusInvalid      NoteStatus = -1
+	// NoteStatusUnknown stands for a note_status value this package does not
+	// recognize. It encodes as "Unknown", so the raw value Prosper sent is kept
+	// only in the UnknownEnumValues field of the parsed Note.
+	NoteStatusUnknown NoteStatus = -2
 )
 
 // Rating represents the Prosper-assigned credit rating of a Prosper
@@ -65,6 +74,10 @@ const (
 	RatingE
 	RatingHR
 	RatingNA
+	// RatingUnknown stands for a prosper_rating value this package does not
+	// recognize. It encodes as "Unknown", so the raw value Prosper sent is kept
+	// only in the UnknownEnumValues field of the parsed Note or Listing.
+	RatingUnknown Rating = -1
 )
 
 // Note represents the information about an owned Prosper note, returned by the
@@ -83,7 +96,7 @@ type Note struct {
 	LoanNoteID                           string
 	LoanNumber                           int64
 	NextPaymentDueAmountProRataShare     float64
-	NextPaymentDueDate                   time.Time
+	NextPaymentDueDate                   Date
 	NoteDefaultReasonDescription         string
 	NoteDefaultReason                    *DefaultReason
 	NoteOwnershipAmount                  float64
@@ -91,21 +104,36 @@ type Note struct {
 	NoteSaleGrossAmountReceived          float64
 	NoteStatusDescription                string
 	NoteStatus                           NoteStatus
-	OriginationDate                      time.Time
+	OriginationDate                      Date
 	PrincipalBalanceProRataShare         float64
 	PrincipalPaidProRataShare            float64
 	ProsperFeesPaidProRataShare          float64
 	Rating                               Rating
 	ServiceFeesPaidProRataShare          float64
 	Term                                 int64
+
+	// UnknownEnumValues holds the raw values of enum attributes that parsed to
+	// an Unknown variant, keyed by Prosper attribute name. It is only populated
+	// when the client accepts unknown enum values.
+	UnknownEnumValues map[string]string
+	// Raw holds the JSON Prosper sent for this note. It is only populated when
+	// the client keeps raw JSON.
+	Raw json.RawMessage `json:",omitempty"`
+
+	// amountText holds the decimal text Prosper sent for the currency fields.
+	amountText thin.NoteResultAmounts
 }
 
 // NotesResponse represents the full response from the Notes API, described at:
 // https://developers.prosper.com/docs/investor/notes-api/
+// ResultCount is the number of notes Prosper returned, including any that
+// failed to parse. In ParseLenient mode, ParseErrors describes each note that
+// was omitted from Result because it failed to parse.
 type NotesResponse struct {
 	Result      []Note
 	ResultCount int
 	TotalCount  int
+	ParseErrors []*NoteParseError
 }
 
 // NoteFetcher supports the Notes API for retrieving the user's notes.
@@ -117,11 +145,22 @@ type NoteFetcher interface {
 // implements the REST API described at:
 // https://developers.prosper.com/docs/investor/notes-api/
 func (c defaultClient) Notes(p NotesParams) (NotesResponse, error) {
+	c.wait()
 	notesResponseRaw, err := c.rawClient.Notes(notesParamsToThinType(p))
 	if err != nil {
 		return NotesResponse{}, err
 	}
-	return c.notesResponseParser.Parse(notesResponseRaw)
+	response, err := c.notesResponseParser.Parse(notesResponseRaw, p.ParseMode)
+	if err != nil {
+		if parseErr, ok := err.(*NoteParseError); ok {
+			logNoteParseError(c.log(), parseErr)
+		}
+		return NotesResponse{}, err
+	}
+	for _, parseErr := range response.ParseErrors {
+		logNoteParseError(c.log(), parseErr)
+	}
+	return response, nil
 }
 
 func notesParamsToThinType(p NotesParams) thin.NotesParams {
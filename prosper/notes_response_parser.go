@@ -4,26 +4,34 @@ import "github.com/mtlynch/gofn-prosper/prosper/thin"
 
 // notesResponseParser parses Prosper notes into native typed Notes.
 type notesResponseParser interface {
-	Parse(thin.NotesResponse) (NotesResponse, error)
+	Parse(thin.NotesResponse, ParseMode) (NotesResponse, error)
 }
 
 type defaultNotesResponseParser struct {
 	np noteParser
 }
 
-// newNotesResponseParser creates a new parser for Notes API responses.
-func newNotesResponseParser() notesResponseParser {
+// newNotesResponseParser creates a new parser for Notes API responses that
+// parses each note with np.
+func newNotesResponseParser(np noteParser) notesResponseParser {
 	return defaultNotesResponseParser{
-		np: defaultNoteParser{},
+		np: np,
 	}
 }
 
-// Parse parses a thin.NotesResponse into the richer NotesResponse.
-func (p defaultNotesResponseParser) Parse(r thin.NotesResponse) (NotesResponse, error) {
+// Parse parses a thin.NotesResponse into the richer NotesResponse. In
+// ParseStrict mode, Parse fails if any note fails to parse. In ParseLenient
+// mode, Parse omits notes that fail to parse and reports them in ParseErrors.
+func (p defaultNotesResponseParser) Parse(r thin.NotesResponse, mode ParseMode) (NotesResponse, error) {
 	var notes []Note
+	var parseErrors []*NoteParseError
 	for _, nRaw := range r.Result {
 		note, err := p.np.Parse(nRaw)
 		if err != nil {
+			if mode == ParseLenient {
+				parseErrors = append(parseErrors, toNoteParseError(nRaw, err))
+				continue
+			}
 			return NotesResponse{}, err
 		}
 		notes = append(notes, note)
@@ -32,5 +40,16 @@ func (p defaultNotesResponseParser) Parse(r thin.NotesResponse) (NotesResponse,
 		Result:      notes,
 		ResultCount: r.ResultCount,
 		TotalCount:  r.TotalCount,
+		ParseErrors: parseErrors,
 	}, nil
 }
+
+func toNoteParseError(r thin.NoteResult, err error) *NoteParseError {
+	if parseErr, ok := err.(*NoteParseError); ok {
+		return parseErr
+	}
+	return &NoteParseError{
+		LoanNoteID: r.LoanNoteID,
+		Err:        err,
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/mtlynch/gofn-prosper/prosper/thin"
 )
 
+var errMockNoteParseFail = errors.New("mock note parsing error")
+
 type mockParseResult struct {
 	parsed Note
 	err    error
@@ -28,6 +30,7 @@ func (p *mockNoteParser) Parse(r thin.NoteResult) (Note, error) {
 func TestNotesResponseParser(t *testing.T) {
 	var tests = []struct {
 		input         thin.NotesResponse
+		mode          ParseMode
 		parseResults  []mockParseResult
 		want          NotesResponse
 		expectSuccess bool
@@ -113,12 +116,48 @@ func TestNotesResponseParser(t *testing.T) {
 			expectSuccess: false,
 			msg:           "parsing should fail if a note can't be parsed",
 		},
+		{
+			input: thin.NotesResponse{
+				Result: []thin.NoteResult{
+					{LoanNumber: 123},
+					{LoanNumber: 124, LoanNoteID: "124-1"},
+					{LoanNumber: 125},
+				},
+				ResultCount: 3,
+				TotalCount:  3,
+			},
+			mode: ParseLenient,
+			parseResults: []mockParseResult{
+				{
+					parsed: Note{LoanNumber: 123},
+				},
+				{
+					err: errMockNoteParseFail,
+				},
+				{
+					parsed: Note{LoanNumber: 125},
+				},
+			},
+			want: NotesResponse{
+				Result: []Note{
+					{LoanNumber: 123},
+					{LoanNumber: 125},
+				},
+				ResultCount: 3,
+				TotalCount:  3,
+				ParseErrors: []*NoteParseError{
+					{LoanNoteID: "124-1", Err: errMockNoteParseFail},
+				},
+			},
+			expectSuccess: true,
+			msg:           "lenient parsing should skip notes that can't be parsed",
+		},
 	}
 	for _, tt := range tests {
 		noteParser := mockNoteParser{returns: tt.parseResults}
 		got, err := defaultNotesResponseParser{
 			np: &noteParser,
-		}.Parse(tt.input)
+		}.Parse(tt.input, tt.mode)
 		if tt.expectSuccess && err != nil {
 			t.Errorf("%s - expected successful parsing of %+v, got error: %v", tt.msg, tt.input, err)
 		} else if !tt.expectSuccess && err == nil {
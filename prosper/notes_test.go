@@ -28,7 +28,7 @@ type mockNotesResponseParser struct {
 	err              error
 }
 
-func (p *mockNotesResponseParser) Parse(r thin.NotesResponse) (NotesResponse, error) {
+func (p *mockNotesResponseParser) Parse(r thin.NotesResponse, mode ParseMode) (NotesResponse, error) {
 	p.gotNotesResponse = r
 	return p.notesResponse, p.err
 }
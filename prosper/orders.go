@@ -1,6 +1,7 @@
 package prosper
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/mtlynch/gofn-prosper/prosper/thin"
@@ -16,6 +17,10 @@ const (
 	Pending BidStatusValue = iota
 	Invested
 	Expired
+	// BidStatusUnknown stands for a bid_status value this package does not
+	// recognize. It encodes as "Unknown", so the raw value Prosper sent is kept
+	// only in the UnknownEnumValues field of the parsed BidStatus.
+	BidStatusUnknown BidStatusValue = -1
 )
 
 // BidResult represents the result status of an order. The values correspond to
@@ -38,6 +43,10 @@ const (
 	ListingNotBiddable
 	SuitabilityRequirementsNotMet
 	PartialBidSucceeded
+	// BidResultUnknown stands for a bid_result value this package does not
+	// recognize. It encodes as "Unknown", so the raw value Prosper sent is kept
+	// only in the UnknownEnumValues field of the parsed BidStatus.
+	BidResultUnknown BidResult = -1
 )
 
 // BidRequest represents an order for a given Prosper listing.
@@ -52,6 +61,14 @@ type BidStatus struct {
 	Status          BidStatusValue
 	Result          BidResult
 	BidAmountPlaced float64
+
+	// UnknownEnumValues holds the raw values of enum attributes that parsed to
+	// an Unknown variant, keyed by Prosper attribute name. It is only populated
+	// when the client accepts unknown enum values.
+	UnknownEnumValues map[string]string
+
+	// amountText holds the decimal text Prosper sent for the currency fields.
+	amountText thin.BidStatusAmounts
 }
 
 // OrderStatus represents the status of an order the user has placed for one or
@@ -64,6 +81,10 @@ type OrderStatus int8
 const (
 	OrderInProgress OrderStatus = iota
 	OrderCompleted
+	// OrderStatusUnknown stands for a order_status value this package does not
+	// recognize. It encodes as "Unknown", so the raw value Prosper sent is kept
+	// only in the UnknownEnumValues field of the parsed OrderResponse.
+	OrderStatusUnknown OrderStatus = -1
 )
 
 // OrderID is the unique identifier associated with a Prosper order request.
@@ -94,6 +115,14 @@ type OrderResponse struct {
 	BidStatus   []BidStatus
 	OrderStatus OrderStatus
 	OrderDate   time.Time
+
+	// UnknownEnumValues holds the raw values of enum attributes that parsed to
+	// an Unknown variant, keyed by Prosper attribute name. It is only populated
+	// when the client accepts unknown enum values.
+	UnknownEnumValues map[string]string
+	// Raw holds the JSON Prosper sent for this order. It is only populated when
+	// the client keeps raw JSON.
+	Raw json.RawMessage `json:",omitempty"`
 }
 
 // BidPlacer places a bid on the given listing for the requested amount.
@@ -103,6 +132,7 @@ type BidPlacer interface {
 
 // PlaceBid places a bid for the given listing at the given bid amount.
 func (c defaultClient) PlaceBid(b BidRequest) (OrderResponse, error) {
+	c.wait()
 	rawResponse, err := c.rawClient.PlaceBid([]thin.BidRequest{
 		{
 			ListingID: int64(b.ListingID),
@@ -122,6 +152,7 @@ type OrderStatusQuerier interface {
 
 // OrderStatus retrieves the status of the given Propser Order ID.
 func (c defaultClient) OrderStatus(orderID OrderID) (OrderResponse, error) {
+	c.wait()
 	rawResponse, err := c.rawClient.OrderStatus(string(orderID))
 	if err != nil {
 		return OrderResponse{}, err
@@ -2,6 +2,7 @@ package prosper
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mtlynch/gofn-prosper/prosper/thin"
 )
@@ -10,32 +11,44 @@ type orderParser interface {
 	Parse(thin.OrderResponse) (OrderResponse, error)
 }
 
-type defaultOrderParser struct{}
+type defaultOrderParser struct {
+	// location is the location in which parsed timestamps are expressed. If
+	// nil, timestamps keep the offset Prosper sent.
+	location *time.Location
+	// unknownEnums controls whether unrecognized enum values are accepted.
+	unknownEnums unknownEnumPolicy
+}
 
 func (p defaultOrderParser) Parse(r thin.OrderResponse) (OrderResponse, error) {
-	orderDate, err := parseProsperTime(r.OrderDate)
+	unknownValues := map[string]string{}
+	orderDate, err := parseProsperTime(r.OrderDate, p.location)
 	if err != nil {
 		return OrderResponse{}, err
 	}
-	bidStatus, err := parseBidStatusSlice(r.BidStatus)
+	bidStatus, err := parseBidStatusSlice(r.BidStatus, p.unknownEnums)
 	if err != nil {
 		return OrderResponse{}, err
 	}
 	orderStatus, err := parseOrderStatus(r.OrderStatus)
 	if err != nil {
-		return OrderResponse{}, err
+		if !p.unknownEnums.acceptUnknown("order_status", r.OrderStatus, unknownValues) {
+			return OrderResponse{}, err
+		}
+		orderStatus = OrderStatusUnknown
 	}
 	return OrderResponse{
-		OrderID:     OrderID(r.OrderID),
-		BidStatus:   bidStatus,
-		OrderStatus: orderStatus,
-		OrderDate:   orderDate,
+		OrderID:           OrderID(r.OrderID),
+		BidStatus:         bidStatus,
+		OrderStatus:       orderStatus,
+		OrderDate:         orderDate,
+		UnknownEnumValues: nilIfEmpty(unknownValues),
+		Raw:               r.Raw,
 	}, nil
 }
 
-func parseBidStatusSlice(status []thin.BidStatus) (parsed []BidStatus, err error) {
+func parseBidStatusSlice(status []thin.BidStatus, unknownEnums unknownEnumPolicy) (parsed []BidStatus, err error) {
 	for _, s := range status {
-		sParsed, err := parseBidStatus(s)
+		sParsed, err := parseBidStatus(s, unknownEnums)
 		if err != nil {
 			return []BidStatus{}, err
 		}
@@ -44,23 +57,32 @@ func parseBidStatusSlice(status []thin.BidStatus) (parsed []BidStatus, err error
 	return parsed, nil
 }
 
-func parseBidStatus(s thin.BidStatus) (BidStatus, error) {
+func parseBidStatus(s thin.BidStatus, unknownEnums unknownEnumPolicy) (BidStatus, error) {
+	unknownValues := map[string]string{}
 	bidStatus, err := parseBidStatusValue(s.Status)
 	if err != nil {
-		return BidStatus{}, err
+		if !unknownEnums.acceptUnknown("bid_status", s.Status, unknownValues) {
+			return BidStatus{}, err
+		}
+		bidStatus = BidStatusUnknown
 	}
 	result, err := parseBidResult(s.BidResult)
 	if err != nil {
-		return BidStatus{}, err
+		if !unknownEnums.acceptUnknown("bid_result", s.BidResult, unknownValues) {
+			return BidStatus{}, err
+		}
+		result = BidResultUnknown
 	}
 	return BidStatus{
 		BidRequest: BidRequest{
 			ListingID: ListingNumber(s.ListingID),
 			BidAmount: s.BidAmount,
 		},
-		Status:          bidStatus,
-		Result:          result,
-		BidAmountPlaced: s.BidAmountPlaced,
+		Status:            bidStatus,
+		Result:            result,
+		BidAmountPlaced:   s.BidAmountPlaced,
+		UnknownEnumValues: nilIfEmpty(unknownValues),
+		amountText:        s.Amounts,
 	}, nil
 }
 
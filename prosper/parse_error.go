@@ -0,0 +1,98 @@
+package prosper
+
+import (
+	"fmt"
+
+	"github.com/mtlynch/gofn-prosper/prosper/logging"
+)
+
+// ParseMode specifies how the client handles results that fail to parse.
+type ParseMode int8
+
+// Set of possible ParseMode values.
+const (
+	// ParseStrict fails the entire request if any single result fails to
+	// parse.
+	ParseStrict ParseMode = iota
+	// ParseLenient omits results that fail to parse and reports each failure
+	// alongside the results that parsed successfully.
+	ParseLenient
+)
+
+// ListingParseError describes a failure to parse a single field of a listing
+// returned by the Search API.
+type ListingParseError struct {
+	ListingNumber ListingNumber
+	Field         string
+	Value         string
+	Err           error
+}
+
+func (e *ListingParseError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("failed to parse listing %d: %v", e.ListingNumber, e.Err)
+	}
+	return fmt.Sprintf("failed to parse %s of listing %d (value %q): %v", e.Field, e.ListingNumber, e.Value, e.Err)
+}
+
+// Unwrap returns the underlying parse failure.
+func (e *ListingParseError) Unwrap() error {
+	return e.Err
+}
+
+// NoteParseError describes a failure to parse a single field of a note
+// returned by the Notes API.
+type NoteParseError struct {
+	LoanNoteID string
+	Field      string
+	Value      string
+	Err        error
+}
+
+func (e *NoteParseError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("failed to parse note %s: %v", e.LoanNoteID, e.Err)
+	}
+	return fmt.Sprintf("failed to parse %s of note %s (value %q): %v", e.Field, e.LoanNoteID, e.Value, e.Err)
+}
+
+// Unwrap returns the underlying parse failure.
+func (e *NoteParseError) Unwrap() error {
+	return e.Err
+}
+
+func newListingParseError(listingNumber int64, field string, value interface{}, err error) *ListingParseError {
+	return &ListingParseError{
+		ListingNumber: ListingNumber(listingNumber),
+		Field:         field,
+		Value:         fmt.Sprint(value),
+		Err:           err,
+	}
+}
+
+func newNoteParseError(loanNoteID, field string, value interface{}, err error) *NoteParseError {
+	return &NoteParseError{
+		LoanNoteID: loanNoteID,
+		Field:      field,
+		Value:      fmt.Sprint(value),
+		Err:        err,
+	}
+}
+
+// logListingParseError reports a listing parse failure without logging the
+// raw field value, which may contain borrower data.
+func logListingParseError(l logging.Logger, e *ListingParseError) {
+	l.Log(logging.Warn, "failed to parse listing",
+		logging.F("listing_number", e.ListingNumber),
+		logging.F("field", e.Field),
+		logging.Redacted("value"))
+}
+
+// logNoteParseError reports a note parse failure without logging the raw field
+// value.
+func logNoteParseError(l logging.Logger, e *NoteParseError) {
+	l.Log(logging.Warn, "failed to parse note",
+		logging.F("loan_note_id", e.LoanNoteID),
+		logging.F("field", e.Field),
+		logging.Redacted("value"))
+}
@@ -0,0 +1,28 @@
+package prosper
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParseErrorUnwrap(t *testing.T) {
+	var tests = []struct {
+		err error
+		msg string
+	}{
+		{
+			err: fmt.Errorf("search failed: %w", newListingParseError(1234, "prosper_rating", "Z", errMockParserFail)),
+			msg: "listing parse error should wrap its cause",
+		},
+		{
+			err: fmt.Errorf("notes failed: %w", newNoteParseError("1-2", "note_status", 99, errMockParserFail)),
+			msg: "note parse error should wrap its cause",
+		},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, errMockParserFail) {
+			t.Errorf("%s: errors.Is should find the underlying failure in %v", tt.msg, tt.err)
+		}
+	}
+}
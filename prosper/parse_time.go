@@ -10,12 +10,27 @@ const (
 	dateOlderThanTenYears  = "2"
 )
 
-func parseProsperDate(dateSerialized string) (time.Time, error) {
-	return parseTimeAllowEmpty(dateSerialized, prosperDateFormat)
+// parseProsperDate parses a Prosper calendar date. An empty value parses to the
+// zero Date.
+func parseProsperDate(dateSerialized string) (Date, error) {
+	if len(dateSerialized) == 0 {
+		return Date{}, nil
+	}
+	t, err := time.Parse(prosperDateFormat, dateSerialized)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
 }
 
-func parseProsperTime(timeSerialized string) (time.Time, error) {
-	return parseTimeAllowEmpty(timeSerialized, prosperTimeFormat)
+// parseProsperTime parses a Prosper timestamp and expresses it in loc. If loc
+// is nil, the timestamp keeps the offset Prosper sent.
+func parseProsperTime(timeSerialized string, loc *time.Location) (time.Time, error) {
+	t, err := parseTimeAllowEmpty(timeSerialized, prosperTimeFormat)
+	if err != nil || t.IsZero() || loc == nil {
+		return t, err
+	}
+	return t.In(loc), nil
 }
 
 func parseTimeAllowEmpty(timeSerialized, format string) (time.Time, error) {
@@ -24,11 +39,3 @@ func parseTimeAllowEmpty(timeSerialized, format string) (time.Time, error) {
 	}
 	return time.Parse(format, timeSerialized)
 }
-
-// we treat "1" (older than 5 years) and "2" (older than 10 years) as nil
-func parseProsperOldTime(timeSerialized string) (time.Time, error) {
-	if timeSerialized == dateOlderThanFiveYears || timeSerialized == dateOlderThanTenYears {
-		return time.Time{}, nil
-	}
-	return time.Parse(prosperOldTimeFormat, timeSerialized)
-}
@@ -5,46 +5,44 @@ import (
 	"time"
 )
 
-func TestParseProsperOldTime(t *testing.T) {
+func TestParseProsperTimeUsesLocation(t *testing.T) {
+	pacific := time.FixedZone("PST", -8*60*60)
 	var tests = []struct {
-		input         string
-		want          time.Time
-		expectSuccess bool
-		msg           string
+		input string
+		loc   *time.Location
+		want  time.Time
+		msg   string
 	}{
 		{
-			input:         "03221991",
-			want:          time.Date(1991, 3, 22, 0, 0, 0, 0, time.UTC),
-			expectSuccess: true,
-			msg:           "normal time should parse normally",
+			input: "2016-03-25 00:18:04 +0000",
+			loc:   pacific,
+			want:  time.Date(2016, 3, 24, 16, 18, 4, 0, pacific),
+			msg:   "timestamp should be expressed in the given location",
 		},
 		{
-			input:         "27051991",
-			expectSuccess: false,
-			msg:           "date with invalid month should fail",
+			input: "2016-03-25 00:18:04 +0000",
+			loc:   nil,
+			want:  time.Date(2016, 3, 25, 0, 18, 4, 0, time.UTC),
+			msg:   "nil location should keep the offset Prosper sent",
 		},
 		{
-			input:         "1",
-			want:          time.Time{},
-			expectSuccess: true,
-			msg:           "'1' value should be treated as nil time",
-		},
-		{
-			input:         "2",
-			want:          time.Time{},
-			expectSuccess: true,
-			msg:           "'2' value should be treated as nil time",
+			input: "",
+			loc:   pacific,
+			want:  time.Time{},
+			msg:   "empty timestamp should parse to the zero time",
 		},
 	}
 	for _, tt := range tests {
-		got, err := parseProsperOldTime(tt.input)
-		if tt.expectSuccess && err != nil {
-			t.Errorf("%s - expected successful parsing of %+v, got error: %v", tt.msg, tt.input, err)
-		} else if !tt.expectSuccess && err == nil {
-			t.Errorf("%s - expected failure for %+v, got nil", tt.msg, tt.input)
+		got, err := parseProsperTime(tt.input, tt.loc)
+		if err != nil {
+			t.Errorf("%s - parseProsperTime failed: %v", tt.msg, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s - parseProsperTime got: %v, want: %v", tt.msg, got, tt.want)
 		}
-		if tt.expectSuccess && !got.Equal(tt.want) {
-			t.Errorf("%s - parseProsperOldTime got: %#v, want: %#v", tt.msg, got, tt.want)
+		if tt.loc != nil && !got.IsZero() && got.Location() != tt.loc {
+			t.Errorf("%s - parseProsperTime location got: %v, want: %v", tt.msg, got.Location(), tt.loc)
 		}
 	}
 }
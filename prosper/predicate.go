@@ -0,0 +1,637 @@
+package prosper
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mtlynch/gofn-prosper/interval"
+)
+
+type (
+	// Verdict is the outcome of evaluating a Predicate against a listing.
+	// Reasons lists the facts about the listing that determined the outcome,
+	// such as "bankcard_utilization is 0.72, which is not < 0.6".
+	Verdict struct {
+		Accepted bool
+		Reasons  []string
+	}
+
+	// Predicate is a condition on a Listing, for criteria that Prosper's
+	// search API cannot filter on.
+	Predicate interface {
+		// Evaluate reports whether l satisfies the predicate and why.
+		Evaluate(l Listing) Verdict
+		// String describes the predicate, for example
+		// "(bankcard_utilization < 0.6 AND months_employed > 24)".
+		String() string
+	}
+
+	// SearchFilterer is implemented by predicates that Prosper can partially
+	// evaluate on the server.
+	SearchFilterer interface {
+		// RestrictSearchFilter narrows f so that Prosper omits listings that
+		// cannot satisfy the predicate. It must never exclude a listing that
+		// satisfies the predicate. It returns an error if the restriction
+		// leaves no listing that could match.
+		RestrictSearchFilter(f *SearchFilter) error
+	}
+)
+
+// SearchFilterFor returns a SearchFilter containing every part of p that
+// Prosper can evaluate on the server. Listings that Search returns for the
+// filter may still fail p, so callers should evaluate p against each result.
+// It returns an error if the server-side parts of p contradict each other.
+func SearchFilterFor(p Predicate) (SearchFilter, error) {
+	var f SearchFilter
+	if sf, ok := p.(SearchFilterer); ok {
+		if err := sf.RestrictSearchFilter(&f); err != nil {
+			return SearchFilter{}, err
+		}
+	}
+	return f, nil
+}
+
+// SearchFilterPredicate returns a Predicate that accepts the listings that
+// match f, for checking listings that did not come from a search with f.
+func SearchFilterPredicate(f SearchFilter) Predicate {
+	var ps []Predicate
+	ps = appendRange(ps, FieldEstimatedReturn, f.EstimatedReturn)
+	ps = appendSet(ps, FieldIncomeRange, f.IncomeRange)
+	ps = appendRange(ps, FieldInquiriesLast6Months, f.InquiriesLast6Months)
+	ps = appendRange(ps, FieldPriorProsperLoansLatePaymentsOneMonthPlus, f.PriorProsperLoansLatePaymentsOneMonthPlus)
+	ps = appendRange(ps, FieldPriorProsperLoansBalanceOutstanding, f.PriorProsperLoansBalanceOutstanding)
+	ps = appendRange(ps, FieldDtiWprosperLoan, f.DtiWprosperLoan)
+	ps = appendSet(ps, FieldRating, f.Rating)
+	if !f.ListingStartDate.IsZero() {
+		r := f.ListingStartDate
+		ps = append(ps, Func("listing_start_date IN "+r.String(), func(l Listing) bool {
+			return r.Contains(l.ListingStartDate)
+		}))
+	}
+	ps = appendSet(ps, FieldListingStatus, f.ListingStatus)
+	ps = appendSet(ps, FieldListingNumber, f.ListingNumber)
+	ps = appendSet(ps, FieldListingTerm, f.ListingTerm)
+	ps = appendRange(ps, FieldListingAmount, f.ListingAmount)
+	ps = appendRange(ps, FieldAmountRemaining, f.AmountRemaining)
+	ps = appendRange(ps, FieldPercentFunded, f.PercentFunded)
+	ps = appendRange(ps, FieldLenderYield, f.LenderYield)
+	ps = appendRange(ps, FieldBorrowerRate, f.BorrowerRate)
+	ps = appendRange(ps, FieldEffectiveYield, f.EffectiveYield)
+	ps = appendRange(ps, FieldEstimatedLossRate, f.EstimatedLossRate)
+	ps = appendSet(ps, FieldFicoScore, f.FicoScore)
+	ps = appendRange(ps, FieldProsperScore, f.ProsperScore)
+	ps = appendSet(ps, FieldBorrowerState, f.BorrowerState)
+	ps = appendSet(ps, FieldEmploymentStatusDescription, f.EmploymentStatusDescription)
+	ps = appendRange(ps, FieldMonthsEmployed, f.MonthsEmployed)
+	ps = appendRange(ps, FieldStatedMonthlyIncome, f.StatedMonthlyIncome)
+	if f.IsHomeowner != nil {
+		ps = append(ps, Is(FieldIsHomeowner, *f.IsHomeowner))
+	}
+	if f.IncomeVerifiable != nil {
+		ps = append(ps, Is(FieldIncomeVerifiable, *f.IncomeVerifiable))
+	}
+	ps = appendSet(ps, FieldListingCategoryID, f.ListingCategoryID)
+	ps = appendRange(ps, FieldPriorProsperLoans, f.PriorProsperLoans)
+	ps = appendRange(ps, FieldPriorProsperLoansActive, f.PriorProsperLoansActive)
+	return And(ps...)
+}
+
+func appendRange[T float64 | int32](ps []Predicate, field NumericField, r interval.Range[T]) []Predicate {
+	if r.Min != nil {
+		op := AtLeast
+		if r.MinExclusive {
+			op = GreaterThan
+		}
+		ps = append(ps, Compare(field, op, float64(*r.Min)))
+	}
+	if r.Max != nil {
+		op := AtMost
+		if r.MaxExclusive {
+			op = LessThan
+		}
+		ps = append(ps, Compare(field, op, float64(*r.Max)))
+	}
+	return ps
+}
+
+func appendSet[T comparable](ps []Predicate, field SetField[T], values []T) []Predicate {
+	if len(values) == 0 {
+		return ps
+	}
+	return append(ps, In(field, values...))
+}
+
+// FilterListings returns the listings that satisfy p.
+func FilterListings(p Predicate, listings []Listing) []Listing {
+	var accepted []Listing
+	for _, l := range listings {
+		if p.Evaluate(l).Accepted {
+			accepted = append(accepted, l)
+		}
+	}
+	return accepted
+}
+
+type andPredicate []Predicate
+
+// And returns a Predicate that accepts listings that satisfy all of ps. It
+// accepts every listing if ps is empty.
+func And(ps ...Predicate) Predicate {
+	return andPredicate(ps)
+}
+
+func (a andPredicate) Evaluate(l Listing) Verdict {
+	v := Verdict{Accepted: true}
+	var rejected []string
+	for _, p := range a {
+		child := p.Evaluate(l)
+		if child.Accepted {
+			v.Reasons = append(v.Reasons, child.Reasons...)
+		} else {
+			v.Accepted = false
+			rejected = append(rejected, child.Reasons...)
+		}
+	}
+	if !v.Accepted {
+		v.Reasons = rejected
+	}
+	return v
+}
+
+func (a andPredicate) String() string {
+	return joinPredicates(a, " AND ", "TRUE")
+}
+
+// RestrictSearchFilter applies the server-side restrictions of each
+// predicate that supports them.
+func (a andPredicate) RestrictSearchFilter(f *SearchFilter) error {
+	for _, p := range a {
+		if sf, ok := p.(SearchFilterer); ok {
+			if err := sf.RestrictSearchFilter(f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type orPredicate []Predicate
+
+// Or returns a Predicate that accepts listings that satisfy any of ps. It
+// rejects every listing if ps is empty.
+func Or(ps ...Predicate) Predicate {
+	return orPredicate(ps)
+}
+
+func (o orPredicate) Evaluate(l Listing) Verdict {
+	var v Verdict
+	var rejected []string
+	for _, p := range o {
+		child := p.Evaluate(l)
+		if child.Accepted {
+			v.Accepted = true
+			v.Reasons = append(v.Reasons, child.Reasons...)
+		} else {
+			rejected = append(rejected, child.Reasons...)
+		}
+	}
+	if !v.Accepted {
+		v.Reasons = rejected
+	}
+	return v
+}
+
+func (o orPredicate) String() string {
+	return joinPredicates(o, " OR ", "FALSE")
+}
+
+func joinPredicates(ps []Predicate, sep, empty string) string {
+	if len(ps) == 0 {
+		return empty
+	}
+	var parts []string
+	for _, p := range ps {
+		parts = append(parts, p.String())
+	}
+	return "(" + strings.Join(parts, sep) + ")"
+}
+
+type notPredicate struct {
+	p Predicate
+}
+
+// Not returns a Predicate that accepts listings that do not satisfy p.
+func Not(p Predicate) Predicate {
+	return notPredicate{p}
+}
+
+func (n notPredicate) Evaluate(l Listing) Verdict {
+	child := n.p.Evaluate(l)
+	return Verdict{Accepted: !child.Accepted, Reasons: child.Reasons}
+}
+
+func (n notPredicate) String() string {
+	return "NOT " + n.p.String()
+}
+
+type funcPredicate struct {
+	name string
+	fn   func(Listing) bool
+}
+
+// Func returns a Predicate that accepts listings for which fn returns true,
+// for criteria the other predicates cannot express. name describes the
+// criterion in String and in Verdict reasons.
+func Func(name string, fn func(Listing) bool) Predicate {
+	return funcPredicate{name: name, fn: fn}
+}
+
+func (p funcPredicate) Evaluate(l Listing) Verdict {
+	if p.fn(l) {
+		return Verdict{Accepted: true, Reasons: []string{p.name + " holds"}}
+	}
+	return Verdict{Reasons: []string{p.name + " does not hold"}}
+}
+
+func (p funcPredicate) String() string {
+	return p.name
+}
+
+// Operator is a numeric comparison operator.
+type Operator int8
+
+// Set of possible Operator values.
+const (
+	LessThan Operator = iota
+	AtMost
+	GreaterThan
+	AtLeast
+	EqualTo
+	NotEqualTo
+)
+
+func (op Operator) String() string {
+	switch op {
+	case LessThan:
+		return "<"
+	case AtMost:
+		return "<="
+	case GreaterThan:
+		return ">"
+	case AtLeast:
+		return ">="
+	case EqualTo:
+		return "=="
+	case NotEqualTo:
+		return "!="
+	}
+	return "Invalid"
+}
+
+func (op Operator) holds(a, b float64) bool {
+	switch op {
+	case LessThan:
+		return a < b
+	case AtMost:
+		return a <= b
+	case GreaterThan:
+		return a > b
+	case AtLeast:
+		return a >= b
+	case EqualTo:
+		return a == b
+	case NotEqualTo:
+		return a != b
+	}
+	return false
+}
+
+// bounds returns the range of values x for which "x op v" holds, if it is a
+// single range.
+func (op Operator) bounds(v float64) (interval.Float64Range, bool) {
+	switch op {
+	case LessThan:
+		return interval.Float64Range{Max: &v, MaxExclusive: true}, true
+	case AtMost:
+		return interval.Float64Range{Max: &v}, true
+	case GreaterThan:
+		return interval.Float64Range{Min: &v, MinExclusive: true}, true
+	case AtLeast:
+		return interval.Float64Range{Min: &v}, true
+	case EqualTo:
+		return interval.NewFloat64Range(v, v), true
+	}
+	return interval.Float64Range{}, false
+}
+
+// NumericField is a numeric attribute of a Listing. Fields with a
+// server-side filter are defined by this package. Callers may define their
+// own fields, which are evaluated on the client only.
+type NumericField struct {
+	// Name identifies the field in explanations, for example
+	// "bankcard_utilization".
+	Name string
+	// Value extracts the field from a listing. It returns NaN if the field is
+	// undefined for the listing.
+	Value func(Listing) float64
+
+	float64Filter func(*SearchFilter) *interval.Float64Range
+	int32Filter   func(*SearchFilter) *interval.Int32Range
+}
+
+// Ratio returns a field whose value is num divided by den, such as
+// RevolvingBalance / StatedMonthlyIncome. The ratio is NaN, which satisfies no
+// comparison other than NotEqualTo, when den is zero.
+func Ratio(num, den NumericField) NumericField {
+	return NumericField{
+		Name: num.Name + "/" + den.Name,
+		Value: func(l Listing) float64 {
+			d := den.Value(l)
+			if d == 0 {
+				return math.NaN()
+			}
+			return num.Value(l) / d
+		},
+	}
+}
+
+type comparison struct {
+	field NumericField
+	op    Operator
+	value float64
+}
+
+// Compare returns a Predicate that accepts listings for which the field's
+// value compares to value according to op, for example
+// Compare(FieldMonthsEmployed, GreaterThan, 24).
+func Compare(field NumericField, op Operator, value float64) Predicate {
+	return comparison{field: field, op: op, value: value}
+}
+
+// Between returns a Predicate that accepts listings for which the field's
+// value is at least min and at most max.
+func Between(field NumericField, min, max float64) Predicate {
+	return And(Compare(field, AtLeast, min), Compare(field, AtMost, max))
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func (c comparison) Evaluate(l Listing) Verdict {
+	v := c.field.Value(l)
+	if math.IsNaN(v) {
+		return Verdict{
+			Accepted: c.op == NotEqualTo,
+			Reasons:  []string{fmt.Sprintf("%s is undefined", c.field.Name)},
+		}
+	}
+	if c.op.holds(v, c.value) {
+		return Verdict{Accepted: true, Reasons: []string{
+			fmt.Sprintf("%s is %s, which is %s %s", c.field.Name, formatNumber(v), c.op, formatNumber(c.value)),
+		}}
+	}
+	return Verdict{Reasons: []string{
+		fmt.Sprintf("%s is %s, which is not %s %s", c.field.Name, formatNumber(v), c.op, formatNumber(c.value)),
+	}}
+}
+
+func (c comparison) String() string {
+	return fmt.Sprintf("%s %s %s", c.field.Name, c.op, formatNumber(c.value))
+}
+
+// RestrictSearchFilter narrows the field's range filter, if it has one.
+// Prosper only supports inclusive bounds, so exclusive bounds on float fields
+// are relaxed to inclusive ones and checked on the client.
+func (c comparison) RestrictSearchFilter(f *SearchFilter) error {
+	r, ok := c.op.bounds(c.value)
+	if !ok {
+		return nil
+	}
+	switch {
+	case c.field.float64Filter != nil:
+		filter := c.field.float64Filter(f)
+		restricted := filter.Intersect(r)
+		restricted.MinExclusive, restricted.MaxExclusive = false, false
+		*filter = restricted
+		if filter.IsEmpty() {
+			return fmt.Errorf("no listing can satisfy %s with filter %s", c, *filter)
+		}
+	case c.field.int32Filter != nil:
+		filter := c.field.int32Filter(f)
+		*filter = filter.Intersect(toInt32Range(r))
+		if filter.IsEmpty() {
+			return fmt.Errorf("no listing can satisfy %s with filter %s", c, *filter)
+		}
+	}
+	return nil
+}
+
+// toInt32Range returns the inclusive Int32Range containing the same integers
+// as r.
+func toInt32Range(r interval.Float64Range) interval.Int32Range {
+	var result interval.Int32Range
+	if r.Min != nil {
+		min := math.Ceil(*r.Min)
+		if r.MinExclusive && min == *r.Min {
+			min++
+		}
+		result.Min = interval.CreateInt32(int32(math.Max(math.Min(min, math.MaxInt32), math.MinInt32)))
+	}
+	if r.Max != nil {
+		max := math.Floor(*r.Max)
+		if r.MaxExclusive && max == *r.Max {
+			max--
+		}
+		result.Max = interval.CreateInt32(int32(math.Max(math.Min(max, math.MaxInt32), math.MinInt32)))
+	}
+	return result
+}
+
+// SetField is an attribute of a Listing with a discrete set of values.
+type SetField[T comparable] struct {
+	// Name identifies the field in explanations, for example "prosper_rating".
+	Name string
+	// Value extracts the field from a listing.
+	Value func(Listing) T
+
+	filter func(*SearchFilter) *[]T
+}
+
+type membership[T comparable] struct {
+	field  SetField[T]
+	values []T
+}
+
+// In returns a Predicate that accepts listings for which the field's value is
+// one of values.
+func In[T comparable](field SetField[T], values ...T) Predicate {
+	return membership[T]{field: field, values: values}
+}
+
+func (m membership[T]) contains(v T) bool {
+	for _, want := range m.values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+func (m membership[T]) valuesString() string {
+	var parts []string
+	for _, v := range m.values {
+		parts = append(parts, fmt.Sprintf("%v", v))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+func (m membership[T]) Evaluate(l Listing) Verdict {
+	v := m.field.Value(l)
+	if m.contains(v) {
+		return Verdict{Accepted: true, Reasons: []string{
+			fmt.Sprintf("%s is %v, which is in %s", m.field.Name, v, m.valuesString()),
+		}}
+	}
+	return Verdict{Reasons: []string{
+		fmt.Sprintf("%s is %v, which is not in %s", m.field.Name, v, m.valuesString()),
+	}}
+}
+
+func (m membership[T]) String() string {
+	return fmt.Sprintf("%s IN %s", m.field.Name, m.valuesString())
+}
+
+// RestrictSearchFilter narrows the field's set filter, if it has one, to the
+// values allowed by both the filter and the predicate.
+func (m membership[T]) RestrictSearchFilter(f *SearchFilter) error {
+	if m.field.filter == nil {
+		return nil
+	}
+	filter := m.field.filter(f)
+	var restricted []T
+	for _, v := range m.values {
+		if *filter == nil || (membership[T]{values: *filter}).contains(v) {
+			restricted = append(restricted, v)
+		}
+	}
+	if len(restricted) == 0 {
+		return fmt.Errorf("no listing can satisfy %s with filter %v", m, *filter)
+	}
+	*filter = restricted
+	return nil
+}
+
+// BoolField is a boolean attribute of a Listing.
+type BoolField struct {
+	// Name identifies the field in explanations, for example "is_homeowner".
+	Name string
+	// Value extracts the field from a listing.
+	Value func(Listing) bool
+
+	filter func(*SearchFilter) **bool
+}
+
+type boolPredicate struct {
+	field BoolField
+	want  bool
+}
+
+// Is returns a Predicate that accepts listings for which the field is want.
+func Is(field BoolField, want bool) Predicate {
+	return boolPredicate{field: field, want: want}
+}
+
+func (b boolPredicate) Evaluate(l Listing) Verdict {
+	v := b.field.Value(l)
+	return Verdict{
+		Accepted: v == b.want,
+		Reasons:  []string{fmt.Sprintf("%s is %t", b.field.Name, v)},
+	}
+}
+
+func (b boolPredicate) String() string {
+	return fmt.Sprintf("%s IS %t", b.field.Name, b.want)
+}
+
+// RestrictSearchFilter sets the field's filter, if it has one.
+func (b boolPredicate) RestrictSearchFilter(f *SearchFilter) error {
+	if b.field.filter == nil {
+		return nil
+	}
+	filter := b.field.filter(f)
+	if *filter != nil && **filter != b.want {
+		return fmt.Errorf("no listing can satisfy %s with filter %s IS %t", b, b.field.Name, **filter)
+	}
+	want := b.want
+	*filter = &want
+	return nil
+}
+
+// StringField is a text attribute of a Listing.
+type StringField struct {
+	// Name identifies the field in explanations, for example "listing_title".
+	Name string
+	// Value extracts the field from a listing.
+	Value func(Listing) string
+}
+
+type keywordPredicate struct {
+	field    StringField
+	keywords []string
+}
+
+// ContainsAny returns a Predicate that accepts listings for which the field
+// contains any of keywords, ignoring case.
+func ContainsAny(field StringField, keywords ...string) Predicate {
+	return keywordPredicate{field: field, keywords: keywords}
+}
+
+func (k keywordPredicate) Evaluate(l Listing) Verdict {
+	v := k.field.Value(l)
+	lower := strings.ToLower(v)
+	for _, keyword := range k.keywords {
+		if strings.Contains(lower, strings.ToLower(keyword)) {
+			return Verdict{Accepted: true, Reasons: []string{
+				fmt.Sprintf("%s is %q, which contains %q", k.field.Name, v, keyword),
+			}}
+		}
+	}
+	return Verdict{Reasons: []string{
+		fmt.Sprintf("%s is %q, which contains none of %q", k.field.Name, v, k.keywords),
+	}}
+}
+
+func (k keywordPredicate) String() string {
+	return fmt.Sprintf("%s CONTAINS ANY %q", k.field.Name, k.keywords)
+}
+
+type regexpPredicate struct {
+	field StringField
+	re    *regexp.Regexp
+}
+
+// Matches returns a Predicate that accepts listings for which the field
+// matches re.
+func Matches(field StringField, re *regexp.Regexp) Predicate {
+	return regexpPredicate{field: field, re: re}
+}
+
+func (r regexpPredicate) Evaluate(l Listing) Verdict {
+	v := r.field.Value(l)
+	if r.re.MatchString(v) {
+		return Verdict{Accepted: true, Reasons: []string{
+			fmt.Sprintf("%s is %q, which matches /%s/", r.field.Name, v, r.re),
+		}}
+	}
+	return Verdict{Reasons: []string{
+		fmt.Sprintf("%s is %q, which does not match /%s/", r.field.Name, v, r.re),
+	}}
+}
+
+func (r regexpPredicate) String() string {
+	return fmt.Sprintf("%s MATCHES /%s/", r.field.Name, r.re)
+}
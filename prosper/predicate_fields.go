@@ -0,0 +1,521 @@
+package prosper
+
+import (
+	"math"
+
+	"github.com/mtlynch/gofn-prosper/interval"
+)
+
+// Numeric listing fields for use with Compare, Between, and Ratio. Fields that
+// Prosper's search API can filter on contribute to SearchFilterFor.
+var (
+	FieldAmountDelinquent = NumericField{
+		Name:  "amount_delinquent",
+		Value: func(l Listing) float64 { return l.AmountDelinquent },
+	}
+	FieldAmountFunded = NumericField{
+		Name:  "amount_funded",
+		Value: func(l Listing) float64 { return l.AmountFunded },
+	}
+	FieldAmountParticipation = NumericField{
+		Name:  "amount_participation",
+		Value: func(l Listing) float64 { return l.AmountParticipation },
+	}
+	FieldAmountRemaining = NumericField{
+		Name:          "amount_remaining",
+		Value:         func(l Listing) float64 { return l.AmountRemaining },
+		float64Filter: func(f *SearchFilter) *interval.Float64Range { return &f.AmountRemaining },
+	}
+	FieldBankcardUtilization = NumericField{
+		Name:  "bankcard_utilization",
+		Value: func(l Listing) float64 { return l.BankcardUtilization },
+	}
+	FieldBorrowerApr = NumericField{
+		Name:  "borrower_apr",
+		Value: func(l Listing) float64 { return l.BorrowerApr },
+	}
+	FieldBorrowerRate = NumericField{
+		Name:          "borrower_rate",
+		Value:         func(l Listing) float64 { return l.BorrowerRate },
+		float64Filter: func(f *SearchFilter) *interval.Float64Range { return &f.BorrowerRate },
+	}
+	FieldCombinedDtiWprosperLoan = NumericField{
+		Name:  "combined_dti_wprosper_loan",
+		Value: func(l Listing) float64 { return l.CombinedDtiWprosperLoan },
+	}
+	FieldCombinedStatedMonthlyIncome = NumericField{
+		Name:  "combined_stated_monthly_income",
+		Value: func(l Listing) float64 { return l.CombinedStatedMonthlyIncome },
+	}
+	FieldCreditLinesLast7Years = NumericField{
+		Name:  "credit_lines_last7_years",
+		Value: func(l Listing) float64 { return float64(l.CreditLinesLast7Years) },
+	}
+	FieldCurrentCreditLines = NumericField{
+		Name:  "current_credit_lines",
+		Value: func(l Listing) float64 { return float64(l.CurrentCreditLines) },
+	}
+	FieldCurrentDelinquencies = NumericField{
+		Name:  "current_delinquencies",
+		Value: func(l Listing) float64 { return float64(l.CurrentDelinquencies) },
+	}
+	FieldDelinquenciesLast7Years = NumericField{
+		Name:  "delinquencies_last7_years",
+		Value: func(l Listing) float64 { return float64(l.DelinquenciesLast7Years) },
+	}
+	FieldDelinquenciesOver30Days = NumericField{
+		Name:  "delinquencies_over30_days",
+		Value: func(l Listing) float64 { return float64(l.DelinquenciesOver30Days) },
+	}
+	FieldDelinquenciesOver60Days = NumericField{
+		Name:  "delinquencies_over60_days",
+		Value: func(l Listing) float64 { return float64(l.DelinquenciesOver60Days) },
+	}
+	FieldDelinquenciesOver90Days = NumericField{
+		Name:  "delinquencies_over90_days",
+		Value: func(l Listing) float64 { return float64(l.DelinquenciesOver90Days) },
+	}
+	FieldDtiWprosperLoan = NumericField{
+		Name:          "dti_wprosper_loan",
+		Value:         func(l Listing) float64 { return l.DtiWprosperLoan },
+		float64Filter: func(f *SearchFilter) *interval.Float64Range { return &f.DtiWprosperLoan },
+	}
+	FieldEffectiveYield = NumericField{
+		Name:          "effective_yield",
+		Value:         func(l Listing) float64 { return l.EffectiveYield },
+		float64Filter: func(f *SearchFilter) *interval.Float64Range { return &f.EffectiveYield },
+	}
+	FieldEstimatedLossRate = NumericField{
+		Name:          "estimated_loss_rate",
+		Value:         func(l Listing) float64 { return l.EstimatedLossRate },
+		float64Filter: func(f *SearchFilter) *interval.Float64Range { return &f.EstimatedLossRate },
+	}
+	FieldEstimatedReturn = NumericField{
+		Name:          "estimated_return",
+		Value:         func(l Listing) float64 { return l.EstimatedReturn },
+		float64Filter: func(f *SearchFilter) *interval.Float64Range { return &f.EstimatedReturn },
+	}
+	FieldFundingThreshold = NumericField{
+		Name:  "funding_threshold",
+		Value: func(l Listing) float64 { return l.FundingThreshold },
+	}
+	FieldInquiriesLast6Months = NumericField{
+		Name:        "inquiries_last6_months",
+		Value:       func(l Listing) float64 { return float64(l.InquiriesLast6Months) },
+		int32Filter: func(f *SearchFilter) *interval.Int32Range { return &f.InquiriesLast6Months },
+	}
+	FieldInstallmentBalance = NumericField{
+		Name:  "installment_balance",
+		Value: func(l Listing) float64 { return l.InstallmentBalance },
+	}
+	FieldLenderYield = NumericField{
+		Name:          "lender_yield",
+		Value:         func(l Listing) float64 { return l.LenderYield },
+		float64Filter: func(f *SearchFilter) *interval.Float64Range { return &f.LenderYield },
+	}
+	FieldListingAmount = NumericField{
+		Name:          "listing_amount",
+		Value:         func(l Listing) float64 { return l.ListingAmount },
+		float64Filter: func(f *SearchFilter) *interval.Float64Range { return &f.ListingAmount },
+	}
+	FieldListingMonthlyPayment = NumericField{
+		Name:  "listing_monthly_payment",
+		Value: func(l Listing) float64 { return l.ListingMonthlyPayment },
+	}
+	FieldMaxPriorProsperLoan = NumericField{
+		Name:  "max_prior_prosper_loan",
+		Value: func(l Listing) float64 { return l.MaxPriorProsperLoan },
+	}
+	FieldMinPriorProsperLoan = NumericField{
+		Name:  "min_prior_prosper_loan",
+		Value: func(l Listing) float64 { return l.MinPriorProsperLoan },
+	}
+	FieldMonthlyDebt = NumericField{
+		Name:  "monthly_debt",
+		Value: func(l Listing) float64 { return l.MonthlyDebt },
+	}
+	FieldMonthsEmployed = NumericField{
+		Name:        "months_employed",
+		Value:       func(l Listing) float64 { return float64(l.MonthsEmployed) },
+		int32Filter: func(f *SearchFilter) *interval.Int32Range { return &f.MonthsEmployed },
+	}
+	FieldNowDelinquentDerog = NumericField{
+		Name:  "now_delinquent_derog",
+		Value: func(l Listing) float64 { return float64(l.NowDelinquentDerog) },
+	}
+	FieldOpenCreditLines = NumericField{
+		Name:  "open_credit_lines",
+		Value: func(l Listing) float64 { return float64(l.OpenCreditLines) },
+	}
+	FieldPercentFunded = NumericField{
+		Name:          "percent_funded",
+		Value:         func(l Listing) float64 { return l.PercentFunded },
+		float64Filter: func(f *SearchFilter) *interval.Float64Range { return &f.PercentFunded },
+	}
+	FieldPriorProsperLoanEarliestPayOff = NumericField{
+		Name:  "prior_prosper_loan_earliest_pay_off",
+		Value: func(l Listing) float64 { return float64(l.PriorProsperLoanEarliestPayOff) },
+	}
+	FieldPriorProsperLoans = NumericField{
+		Name:        "prior_prosper_loans",
+		Value:       func(l Listing) float64 { return float64(l.PriorProsperLoans) },
+		int32Filter: func(f *SearchFilter) *interval.Int32Range { return &f.PriorProsperLoans },
+	}
+	FieldPriorProsperLoans31dpd = NumericField{
+		Name:  "prior_prosper_loans31dpd",
+		Value: func(l Listing) float64 { return float64(l.PriorProsperLoans31dpd) },
+	}
+	FieldPriorProsperLoans61dpd = NumericField{
+		Name:  "prior_prosper_loans61dpd",
+		Value: func(l Listing) float64 { return float64(l.PriorProsperLoans61dpd) },
+	}
+	FieldPriorProsperLoansActive = NumericField{
+		Name:        "prior_prosper_loans_active",
+		Value:       func(l Listing) float64 { return float64(l.PriorProsperLoansActive) },
+		int32Filter: func(f *SearchFilter) *interval.Int32Range { return &f.PriorProsperLoansActive },
+	}
+	FieldPriorProsperLoansBalanceOutstanding = NumericField{
+		Name:          "prior_prosper_loans_balance_outstanding",
+		Value:         func(l Listing) float64 { return l.PriorProsperLoansBalanceOutstanding },
+		float64Filter: func(f *SearchFilter) *interval.Float64Range { return &f.PriorProsperLoansBalanceOutstanding },
+	}
+	FieldPriorProsperLoansCyclesBilled = NumericField{
+		Name:  "prior_prosper_loans_cycles_billed",
+		Value: func(l Listing) float64 { return float64(l.PriorProsperLoansCyclesBilled) },
+	}
+	FieldPriorProsperLoansLateCycles = NumericField{
+		Name:  "prior_prosper_loans_late_cycles",
+		Value: func(l Listing) float64 { return float64(l.PriorProsperLoansLateCycles) },
+	}
+	FieldPriorProsperLoansLatePaymentsOneMonthPlus = NumericField{
+		Name:        "prior_prosper_loans_late_payments_one_month_plus",
+		Value:       func(l Listing) float64 { return float64(l.PriorProsperLoansLatePaymentsOneMonthPlus) },
+		int32Filter: func(f *SearchFilter) *interval.Int32Range { return &f.PriorProsperLoansLatePaymentsOneMonthPlus },
+	}
+	FieldPriorProsperLoansOntimePayments = NumericField{
+		Name:  "prior_prosper_loans_ontime_payments",
+		Value: func(l Listing) float64 { return float64(l.PriorProsperLoansOntimePayments) },
+	}
+	FieldPriorProsperLoansPrincipalBorrowed = NumericField{
+		Name:  "prior_prosper_loans_principal_borrowed",
+		Value: func(l Listing) float64 { return l.PriorProsperLoansPrincipalBorrowed },
+	}
+	FieldPriorProsperLoansPrincipalOutstanding = NumericField{
+		Name:  "prior_prosper_loans_principal_outstanding",
+		Value: func(l Listing) float64 { return l.PriorProsperLoansPrincipalOutstanding },
+	}
+	FieldProsperScore = NumericField{
+		Name: "prosper_score",
+		Value: func(l Listing) float64 {
+			if l.ProsperScore < ProsperScoreMin || l.ProsperScore > ProsperScoreMax {
+				return math.NaN()
+			}
+			return float64(l.ProsperScore)
+		},
+		int32Filter: func(f *SearchFilter) *interval.Int32Range { return &f.ProsperScore },
+	}
+	FieldPublicRecordsLast10Years = NumericField{
+		Name:  "public_records_last10_years",
+		Value: func(l Listing) float64 { return float64(l.PublicRecordsLast10Years) },
+	}
+	FieldPublicRecordsLast12Months = NumericField{
+		Name:  "public_records_last12_months",
+		Value: func(l Listing) float64 { return float64(l.PublicRecordsLast12Months) },
+	}
+	FieldRealEstateBalance = NumericField{
+		Name:  "real_estate_balance",
+		Value: func(l Listing) float64 { return l.RealEstateBalance },
+	}
+	FieldRealEstatePayment = NumericField{
+		Name:  "real_estate_payment",
+		Value: func(l Listing) float64 { return l.RealEstatePayment },
+	}
+	FieldRevolvingAvailablePercent = NumericField{
+		Name:  "revolving_available_percent",
+		Value: func(l Listing) float64 { return l.RevolvingAvailablePercent },
+	}
+	FieldRevolvingBalance = NumericField{
+		Name:  "revolving_balance",
+		Value: func(l Listing) float64 { return l.RevolvingBalance },
+	}
+	FieldSatisfactoryAccounts = NumericField{
+		Name:  "satisfactory_accounts",
+		Value: func(l Listing) float64 { return float64(l.SatisfactoryAccounts) },
+	}
+	FieldStatedMonthlyIncome = NumericField{
+		Name:          "stated_monthly_income",
+		Value:         func(l Listing) float64 { return l.StatedMonthlyIncome },
+		float64Filter: func(f *SearchFilter) *interval.Float64Range { return &f.StatedMonthlyIncome },
+	}
+	FieldTotalInquiries = NumericField{
+		Name:  "total_inquiries",
+		Value: func(l Listing) float64 { return float64(l.TotalInquiries) },
+	}
+	FieldTotalOpenRevolvingAccounts = NumericField{
+		Name:  "total_open_revolving_accounts",
+		Value: func(l Listing) float64 { return float64(l.TotalOpenRevolvingAccounts) },
+	}
+	FieldTotalTradeItems = NumericField{
+		Name:  "total_trade_items",
+		Value: func(l Listing) float64 { return float64(l.TotalTradeItems) },
+	}
+	FieldWasDelinquentDerog = NumericField{
+		Name:  "was_delinquent_derog",
+		Value: func(l Listing) float64 { return float64(l.WasDelinquentDerog) },
+	}
+)
+
+// Listing fields with discrete values for use with In.
+var (
+	FieldBorrowerState = SetField[string]{
+		Name:   "borrower_state",
+		Value:  func(l Listing) string { return l.BorrowerState },
+		filter: func(f *SearchFilter) *[]string { return &f.BorrowerState },
+	}
+	FieldEmploymentStatusDescription = SetField[string]{
+		Name:   "employment_status_description",
+		Value:  func(l Listing) string { return l.EmploymentStatusDescription },
+		filter: func(f *SearchFilter) *[]string { return &f.EmploymentStatusDescription },
+	}
+	FieldFicoScore = SetField[FicoScore]{
+		Name:   "fico_score",
+		Value:  func(l Listing) FicoScore { return l.FicoScore },
+		filter: func(f *SearchFilter) *[]FicoScore { return &f.FicoScore },
+	}
+	FieldIncomeRange = SetField[IncomeRange]{
+		Name:   "income_range",
+		Value:  func(l Listing) IncomeRange { return l.IncomeRange },
+		filter: func(f *SearchFilter) *[]IncomeRange { return &f.IncomeRange },
+	}
+	FieldInvestmentTypeID = SetField[InvestmentType]{
+		Name:  "investment_type_id",
+		Value: func(l Listing) InvestmentType { return l.InvestmentTypeID },
+	}
+	FieldLenderIndicator = SetField[LenderIndicator]{
+		Name:  "lender_indicator",
+		Value: func(l Listing) LenderIndicator { return l.LenderIndicator },
+	}
+	FieldListingCategoryID = SetField[ListingCategory]{
+		Name:   "listing_category_id",
+		Value:  func(l Listing) ListingCategory { return l.ListingCategoryID },
+		filter: func(f *SearchFilter) *[]ListingCategory { return &f.ListingCategoryID },
+	}
+	FieldListingNumber = SetField[ListingNumber]{
+		Name:   "listing_number",
+		Value:  func(l Listing) ListingNumber { return l.ListingNumber },
+		filter: func(f *SearchFilter) *[]ListingNumber { return &f.ListingNumber },
+	}
+	FieldListingStatus = SetField[ListingStatus]{
+		Name:   "listing_status",
+		Value:  func(l Listing) ListingStatus { return l.ListingStatus },
+		filter: func(f *SearchFilter) *[]ListingStatus { return &f.ListingStatus },
+	}
+	FieldListingTerm = SetField[int64]{
+		Name:   "listing_term",
+		Value:  func(l Listing) int64 { return l.ListingTerm },
+		filter: func(f *SearchFilter) *[]int64 { return &f.ListingTerm },
+	}
+	FieldRating = SetField[Rating]{
+		Name:   "prosper_rating",
+		Value:  func(l Listing) Rating { return l.Rating },
+		filter: func(f *SearchFilter) *[]Rating { return &f.Rating },
+	}
+	FieldVerificationStage = SetField[VerificationStage]{
+		Name:  "verification_stage",
+		Value: func(l Listing) VerificationStage { return l.VerificationStage },
+	}
+)
+
+// Boolean listing fields for use with Is.
+var (
+	FieldCoBorrowerApplication = BoolField{
+		Name:  "co_borrower_application",
+		Value: func(l Listing) bool { return l.CoBorrowerApplication },
+	}
+	FieldGroupIndicator = BoolField{
+		Name:  "group_indicator",
+		Value: func(l Listing) bool { return l.GroupIndicator },
+	}
+	FieldIncomeVerifiable = BoolField{
+		Name:   "income_verifiable",
+		Value:  func(l Listing) bool { return l.IncomeVerifiable },
+		filter: func(f *SearchFilter) **bool { return &f.IncomeVerifiable },
+	}
+	FieldInvested = BoolField{
+		Name:  "invested",
+		Value: func(l Listing) bool { return l.Invested },
+	}
+	FieldIsHomeowner = BoolField{
+		Name:   "is_homeowner",
+		Value:  func(l Listing) bool { return l.IsHomeowner },
+		filter: func(f *SearchFilter) **bool { return &f.IsHomeowner },
+	}
+	FieldPartialFundingIndicator = BoolField{
+		Name:  "partial_funding_indicator",
+		Value: func(l Listing) bool { return l.PartialFundingIndicator },
+	}
+)
+
+// Text listing fields for use with ContainsAny and Matches.
+var (
+	FieldBorrowerCity = StringField{
+		Name:  "borrower_city",
+		Value: func(l Listing) string { return l.BorrowerCity },
+	}
+	FieldBorrowerListingDescription = StringField{
+		Name:  "borrower_listing_description",
+		Value: func(l Listing) string { return l.BorrowerListingDescription },
+	}
+	FieldBorrowerMetropolitanArea = StringField{
+		Name:  "borrower_metropolitan_area",
+		Value: func(l Listing) string { return l.BorrowerMetropolitanArea },
+	}
+	FieldBorrowerStateText = StringField{
+		Name:  "borrower_state",
+		Value: func(l Listing) string { return l.BorrowerState },
+	}
+	FieldEmploymentStatusDescriptionText = StringField{
+		Name:  "employment_status_description",
+		Value: func(l Listing) string { return l.EmploymentStatusDescription },
+	}
+	FieldGroupName = StringField{
+		Name:  "group_name",
+		Value: func(l Listing) string { return l.GroupName },
+	}
+	FieldIncomeRangeDescription = StringField{
+		Name:  "income_range_description",
+		Value: func(l Listing) string { return l.IncomeRangeDescription },
+	}
+	FieldInvestmentTypeDescription = StringField{
+		Name:  "investment_type_description",
+		Value: func(l Listing) string { return l.InvestmentTypeDescription },
+	}
+	FieldListingPurpose = StringField{
+		Name:  "listing_purpose",
+		Value: func(l Listing) string { return l.ListingPurpose },
+	}
+	FieldListingStatusReason = StringField{
+		Name:  "listing_status_reason",
+		Value: func(l Listing) string { return l.ListingStatusReason },
+	}
+	FieldListingTitle = StringField{
+		Name:  "listing_title",
+		Value: func(l Listing) string { return l.ListingTitle },
+	}
+	FieldOccupation = StringField{
+		Name:  "occupation",
+		Value: func(l Listing) string { return l.Occupation },
+	}
+)
+
+// LookupNumericField returns the numeric listing field with the given name, such
+// as "bankcard_utilization".
+func LookupNumericField(name string) (NumericField, bool) {
+	for _, f := range []NumericField{
+		FieldAmountDelinquent,
+		FieldAmountFunded,
+		FieldAmountParticipation,
+		FieldAmountRemaining,
+		FieldBankcardUtilization,
+		FieldBorrowerApr,
+		FieldBorrowerRate,
+		FieldCombinedDtiWprosperLoan,
+		FieldCombinedStatedMonthlyIncome,
+		FieldCreditLinesLast7Years,
+		FieldCurrentCreditLines,
+		FieldCurrentDelinquencies,
+		FieldDelinquenciesLast7Years,
+		FieldDelinquenciesOver30Days,
+		FieldDelinquenciesOver60Days,
+		FieldDelinquenciesOver90Days,
+		FieldDtiWprosperLoan,
+		FieldEffectiveYield,
+		FieldEstimatedLossRate,
+		FieldEstimatedReturn,
+		FieldFundingThreshold,
+		FieldInquiriesLast6Months,
+		FieldInstallmentBalance,
+		FieldLenderYield,
+		FieldListingAmount,
+		FieldListingMonthlyPayment,
+		FieldMaxPriorProsperLoan,
+		FieldMinPriorProsperLoan,
+		FieldMonthlyDebt,
+		FieldMonthsEmployed,
+		FieldNowDelinquentDerog,
+		FieldOpenCreditLines,
+		FieldPercentFunded,
+		FieldPriorProsperLoanEarliestPayOff,
+		FieldPriorProsperLoans,
+		FieldPriorProsperLoans31dpd,
+		FieldPriorProsperLoans61dpd,
+		FieldPriorProsperLoansActive,
+		FieldPriorProsperLoansBalanceOutstanding,
+		FieldPriorProsperLoansCyclesBilled,
+		FieldPriorProsperLoansLateCycles,
+		FieldPriorProsperLoansLatePaymentsOneMonthPlus,
+		FieldPriorProsperLoansOntimePayments,
+		FieldPriorProsperLoansPrincipalBorrowed,
+		FieldPriorProsperLoansPrincipalOutstanding,
+		FieldProsperScore,
+		FieldPublicRecordsLast10Years,
+		FieldPublicRecordsLast12Months,
+		FieldRealEstateBalance,
+		FieldRealEstatePayment,
+		FieldRevolvingAvailablePercent,
+		FieldRevolvingBalance,
+		FieldSatisfactoryAccounts,
+		FieldStatedMonthlyIncome,
+		FieldTotalInquiries,
+		FieldTotalOpenRevolvingAccounts,
+		FieldTotalTradeItems,
+		FieldWasDelinquentDerog,
+	} {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return NumericField{}, false
+}
+
+// LookupBoolField returns the boolean listing field with the given name, such
+// as "is_homeowner".
+func LookupBoolField(name string) (BoolField, bool) {
+	for _, f := range []BoolField{
+		FieldCoBorrowerApplication,
+		FieldGroupIndicator,
+		FieldIncomeVerifiable,
+		FieldInvested,
+		FieldIsHomeowner,
+		FieldPartialFundingIndicator,
+	} {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return BoolField{}, false
+}
+
+// LookupStringField returns the text listing field with the given name, such
+// as "listing_title".
+func LookupStringField(name string) (StringField, bool) {
+	for _, f := range []StringField{
+		FieldBorrowerCity,
+		FieldBorrowerListingDescription,
+		FieldBorrowerMetropolitanArea,
+		FieldBorrowerStateText,
+		FieldEmploymentStatusDescriptionText,
+		FieldGroupName,
+		FieldIncomeRangeDescription,
+		FieldInvestmentTypeDescription,
+		FieldListingPurpose,
+		FieldListingStatusReason,
+		FieldListingTitle,
+		FieldOccupation,
+	} {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return StringField{}, false
+}
@@ -0,0 +1,336 @@
+package prosper
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/mtlynch/gofn-prosper/interval"
+)
+
+func TestPredicateEvaluate(t *testing.T) {
+	listing := Listing{
+		BankcardUtilization: 0.72,
+		MonthsEmployed:      36,
+		RevolvingBalance:    9000.0,
+		StatedMonthlyIncome: 3000.0,
+		Rating:              RatingB,
+		IsHomeowner:         true,
+		ListingTitle:        "Consolidate my credit cards",
+		Occupation:          "Nurse (RN)",
+		ProsperScore:        ProsperScoreUnknown,
+	}
+	var tests = []struct {
+		p            Predicate
+		wantAccepted bool
+		wantReasons  []string
+		wantString   string
+		msg          string
+	}{
+		{
+			p:            Compare(FieldBankcardUtilization, LessThan, 0.6),
+			wantAccepted: false,
+			wantReasons:  []string{"bankcard_utilization is 0.72, which is not < 0.6"},
+			wantString:   "bankcard_utilization < 0.6",
+			msg:          "failed comparison should explain the rejection",
+		},
+		{
+			p:            Compare(FieldMonthsEmployed, GreaterThan, 24),
+			wantAccepted: true,
+			wantReasons:  []string{"months_employed is 36, which is > 24"},
+			wantString:   "months_employed > 24",
+			msg:          "satisfied comparison should explain the acceptance",
+		},
+		{
+			p:            Compare(FieldProsperScore, AtLeast, 5),
+			wantAccepted: false,
+			wantReasons:  []string{"prosper_score is undefined"},
+			wantString:   "prosper_score >= 5",
+			msg:          "comparison on an unknown value should be rejected",
+		},
+		{
+			p:            Compare(Ratio(FieldRevolvingBalance, FieldStatedMonthlyIncome), AtMost, 2),
+			wantAccepted: false,
+			wantReasons:  []string{"revolving_balance/stated_monthly_income is 3, which is not <= 2"},
+			wantString:   "revolving_balance/stated_monthly_income <= 2",
+			msg:          "ratio should divide its fields",
+		},
+		{
+			p:            Compare(Ratio(FieldRevolvingBalance, FieldAmountDelinquent), LessThan, 2),
+			wantAccepted: false,
+			wantReasons:  []string{"revolving_balance/amount_delinquent is undefined"},
+			wantString:   "revolving_balance/amount_delinquent < 2",
+			msg:          "ratio with a zero denominator should be undefined",
+		},
+		{
+			p:            In(FieldRating, RatingA, RatingB),
+			wantAccepted: true,
+			wantReasons:  []string{"prosper_rating is B, which is in {A, B}"},
+			wantString:   "prosper_rating IN {A, B}",
+			msg:          "set membership should accept listed values",
+		},
+		{
+			p:            In(FieldRating, RatingAA),
+			wantAccepted: false,
+			wantReasons:  []string{"prosper_rating is B, which is not in {AA}"},
+			wantString:   "prosper_rating IN {AA}",
+			msg:          "set membership should reject unlisted values",
+		},
+		{
+			p:            Is(FieldIsHomeowner, false),
+			wantAccepted: false,
+			wantReasons:  []string{"is_homeowner is true"},
+			wantString:   "is_homeowner IS false",
+			msg:          "boolean predicate should compare the field",
+		},
+		{
+			p:            ContainsAny(FieldOccupation, "teacher", "NURSE"),
+			wantAccepted: true,
+			wantReasons:  []string{`occupation is "Nurse (RN)", which contains "NURSE"`},
+			wantString:   `occupation CONTAINS ANY ["teacher" "NURSE"]`,
+			msg:          "keyword match should ignore case",
+		},
+		{
+			p:            ContainsAny(FieldListingTitle, "vacation"),
+			wantAccepted: false,
+			wantReasons:  []string{`listing_title is "Consolidate my credit cards", which contains none of ["vacation"]`},
+			wantString:   `listing_title CONTAINS ANY ["vacation"]`,
+			msg:          "keyword mismatch should explain the rejection",
+		},
+		{
+			p:            Matches(FieldListingTitle, regexp.MustCompile(`(?i)^consolidate`)),
+			wantAccepted: true,
+			wantReasons:  []string{`listing_title is "Consolidate my credit cards", which matches /(?i)^consolidate/`},
+			wantString:   `listing_title MATCHES /(?i)^consolidate/`,
+			msg:          "regexp should match the field",
+		},
+		{
+			p: And(
+				Compare(FieldMonthsEmployed, GreaterThan, 24),
+				Compare(FieldBankcardUtilization, LessThan, 0.6),
+				In(FieldRating, RatingAA)),
+			wantAccepted: false,
+			wantReasons: []string{
+				"bankcard_utilization is 0.72, which is not < 0.6",
+				"prosper_rating is B, which is not in {AA}",
+			},
+			wantString: "(months_employed > 24 AND bankcard_utilization < 0.6 AND prosper_rating IN {AA})",
+			msg:        "rejected And should explain every failed clause",
+		},
+		{
+			p: And(
+				Compare(FieldMonthsEmployed, GreaterThan, 24),
+				Is(FieldIsHomeowner, true)),
+			wantAccepted: true,
+			wantReasons: []string{
+				"months_employed is 36, which is > 24",
+				"is_homeowner is true",
+			},
+			wantString: "(months_employed > 24 AND is_homeowner IS true)",
+			msg:        "accepted And should explain every clause",
+		},
+		{
+			p: Or(
+				Compare(FieldBankcardUtilization, LessThan, 0.6),
+				In(FieldRating, RatingB)),
+			wantAccepted: true,
+			wantReasons:  []string{"prosper_rating is B, which is in {B}"},
+			wantString:   "(bankcard_utilization < 0.6 OR prosper_rating IN {B})",
+			msg:          "accepted Or should explain the satisfied clauses",
+		},
+		{
+			p:            Not(ContainsAny(FieldListingTitle, "credit")),
+			wantAccepted: false,
+			wantReasons:  []string{`listing_title is "Consolidate my credit cards", which contains "credit"`},
+			wantString:   `NOT listing_title CONTAINS ANY ["credit"]`,
+			msg:          "Not should invert its predicate",
+		},
+		{
+			p:            And(),
+			wantAccepted: true,
+			wantString:   "TRUE",
+			msg:          "empty And should accept",
+		},
+		{
+			p:            Or(),
+			wantAccepted: false,
+			wantString:   "FALSE",
+			msg:          "empty Or should reject",
+		},
+		{
+			p:            Func("has title", func(l Listing) bool { return l.ListingTitle != "" }),
+			wantAccepted: true,
+			wantReasons:  []string{"has title holds"},
+			wantString:   "has title",
+			msg:          "Func should call its function",
+		},
+	}
+	for _, tt := range tests {
+		got := tt.p.Evaluate(listing)
+		if got.Accepted != tt.wantAccepted {
+			t.Errorf("%s: unexpected Accepted. got: %v, want: %v", tt.msg, got.Accepted, tt.wantAccepted)
+		}
+		if !reflect.DeepEqual(got.Reasons, tt.wantReasons) {
+			t.Errorf("%s: unexpected Reasons. got: %#v, want: %#v", tt.msg, got.Reasons, tt.wantReasons)
+		}
+		if s := tt.p.String(); s != tt.wantString {
+			t.Errorf("%s: unexpected String. got: %s, want: %s", tt.msg, s, tt.wantString)
+		}
+	}
+}
+
+func TestSearchFilterFor(t *testing.T) {
+	homeowner := true
+	var tests = []struct {
+		p             Predicate
+		want          SearchFilter
+		expectSuccess bool
+		msg           string
+	}{
+		{
+			p:             Compare(FieldBankcardUtilization, LessThan, 0.6),
+			want:          SearchFilter{},
+			expectSuccess: true,
+			msg:           "client-only field should not restrict the filter",
+		},
+		{
+			p: And(
+				Compare(FieldMonthsEmployed, GreaterThan, 24),
+				Compare(FieldMonthsEmployed, LessThan, 120.5),
+				Compare(FieldEstimatedReturn, GreaterThan, 0.05),
+				Compare(FieldEstimatedReturn, AtMost, 0.12),
+				Compare(FieldBankcardUtilization, LessThan, 0.6),
+				In(FieldRating, RatingA, RatingB, RatingC),
+				In(FieldRating, RatingB, RatingC, RatingD),
+				Is(FieldIsHomeowner, true)),
+			want: SearchFilter{
+				MonthsEmployed:  interval.NewInt32Range(25, 120),
+				EstimatedReturn: interval.NewFloat64Range(0.05, 0.12),
+				Rating:          []Rating{RatingB, RatingC},
+				IsHomeowner:     &homeowner,
+			},
+			expectSuccess: true,
+			msg:           "And should combine server-side clauses as inclusive bounds",
+		},
+		{
+			p:             Between(FieldProsperScore, 4, 8),
+			want:          SearchFilter{ProsperScore: interval.NewInt32Range(4, 8)},
+			expectSuccess: true,
+			msg:           "Between should restrict both bounds",
+		},
+		{
+			p: And(
+				Compare(FieldListingAmount, EqualTo, 5000),
+				Compare(FieldPriorProsperLoans, NotEqualTo, 0)),
+			want:          SearchFilter{ListingAmount: interval.NewFloat64Range(5000, 5000)},
+			expectSuccess: true,
+			msg:           "equality should restrict to a single value and inequality should not restrict",
+		},
+		{
+			p: Or(
+				In(FieldRating, RatingAA),
+				Compare(FieldMonthsEmployed, GreaterThan, 24)),
+			want:          SearchFilter{},
+			expectSuccess: true,
+			msg:           "Or should not restrict the filter",
+		},
+		{
+			p:             Not(In(FieldRating, RatingAA)),
+			want:          SearchFilter{},
+			expectSuccess: true,
+			msg:           "Not should not restrict the filter",
+		},
+		{
+			p: And(
+				Compare(FieldMonthsEmployed, GreaterThan, 24),
+				Compare(FieldMonthsEmployed, LessThan, 25)),
+			expectSuccess: false,
+			msg:           "contradictory integer bounds should fail",
+		},
+		{
+			p: And(
+				In(FieldRating, RatingA),
+				In(FieldRating, RatingB)),
+			expectSuccess: false,
+			msg:           "disjoint sets should fail",
+		},
+		{
+			p: And(
+				Is(FieldIncomeVerifiable, true),
+				Is(FieldIncomeVerifiable, false)),
+			expectSuccess: false,
+			msg:           "contradictory booleans should fail",
+		},
+	}
+	for _, tt := range tests {
+		got, err := SearchFilterFor(tt.p)
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s: expected success, got error: %v", tt.msg, err)
+			continue
+		} else if !tt.expectSuccess {
+			if err == nil {
+				t.Errorf("%s: expected failure, got success: %+v", tt.msg, got)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: unexpected filter. got: %+v, want: %+v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestFilterListings(t *testing.T) {
+	listings := []Listing{
+		{ListingNumber: 1, RevolvingBalance: 1000.0, StatedMonthlyIncome: 2000.0},
+		{ListingNumber: 2, RevolvingBalance: 9000.0, StatedMonthlyIncome: 2000.0},
+		{ListingNumber: 3, RevolvingBalance: 1000.0, StatedMonthlyIncome: 0.0},
+	}
+	got := FilterListings(Compare(Ratio(FieldRevolvingBalance, FieldStatedMonthlyIncome), LessThan, 1), listings)
+	want := []Listing{listings[0]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected listings. got: %+v, want: %+v", got, want)
+	}
+}
+
+func TestSearchFilterPredicate(t *testing.T) {
+	homeowner := true
+	p := SearchFilterPredicate(SearchFilter{
+		Rating:          []Rating{RatingA, RatingB},
+		EstimatedReturn: interval.Float64Range{Min: interval.CreateFloat64(0.05), MinExclusive: true},
+		MonthsEmployed:  interval.NewInt32Range(12, 120),
+		IsHomeowner:     &homeowner,
+	})
+	wantString := "(estimated_return > 0.05 AND prosper_rating IN {A, B} AND months_employed >= 12 AND months_employed <= 120 AND is_homeowner IS true)"
+	if got := p.String(); got != wantString {
+		t.Errorf("unexpected predicate. got: %s, want: %s", got, wantString)
+	}
+	var tests = []struct {
+		listing Listing
+		want    bool
+		msg     string
+	}{
+		{
+			listing: Listing{Rating: RatingA, EstimatedReturn: 0.06, MonthsEmployed: 12, IsHomeowner: true},
+			want:    true,
+			msg:     "listing matching every criterion should be accepted",
+		},
+		{
+			listing: Listing{Rating: RatingA, EstimatedReturn: 0.05, MonthsEmployed: 12, IsHomeowner: true},
+			want:    false,
+			msg:     "exclusive bound should be respected",
+		},
+		{
+			listing: Listing{Rating: RatingC, EstimatedReturn: 0.06, MonthsEmployed: 12, IsHomeowner: true},
+			want:    false,
+			msg:     "listing outside the set should be rejected",
+		},
+	}
+	for _, tt := range tests {
+		if got := p.Evaluate(tt.listing).Accepted; got != tt.want {
+			t.Errorf("%s: got: %v, want: %v", tt.msg, got, tt.want)
+		}
+	}
+	if !SearchFilterPredicate(SearchFilter{}).Evaluate(Listing{}).Accepted {
+		t.Errorf("empty filter should accept every listing")
+	}
+}
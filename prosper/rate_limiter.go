@@ -0,0 +1,58 @@
+package prosper
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultRequestsPerSecond is the default maximum rate at which the client
+// issues requests to the Prosper API.
+const defaultRequestsPerSecond = 10
+
+// RateLimiter limits the rate of requests to the Prosper API.
+type RateLimiter interface {
+	// Wait blocks until the caller is permitted to make another request.
+	Wait()
+}
+
+type intervalRateLimiter struct {
+	interval time.Duration
+	next     time.Time
+	now      func() time.Time
+	sleep    func(time.Duration)
+	lock     sync.Mutex
+}
+
+// NewRateLimiter creates a RateLimiter that permits at most requestsPerSecond
+// requests per second, spaced evenly apart. It fails unless requestsPerSecond
+// is positive.
+func NewRateLimiter(requestsPerSecond int) (RateLimiter, error) {
+	if requestsPerSecond <= 0 {
+		return nil, fmt.Errorf("requests per second must be positive: %d", requestsPerSecond)
+	}
+	return newIntervalRateLimiter(time.Second / time.Duration(requestsPerSecond)), nil
+}
+
+func newIntervalRateLimiter(interval time.Duration) *intervalRateLimiter {
+	return &intervalRateLimiter{
+		interval: interval,
+		now:      time.Now,
+		sleep:    time.Sleep,
+	}
+}
+
+// Wait blocks until the next request slot is available.
+func (r *intervalRateLimiter) Wait() {
+	r.lock.Lock()
+	now := r.now()
+	slot := r.next
+	if slot.Before(now) {
+		slot = now
+	}
+	r.next = slot.Add(r.interval)
+	r.lock.Unlock()
+	if delay := slot.Sub(now); delay > 0 {
+		r.sleep(delay)
+	}
+}
@@ -0,0 +1,101 @@
+package prosper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mtlynch/gofn-prosper/prosper/auth"
+)
+
+func TestRateLimiterSpacesRequests(t *testing.T) {
+	now := time.Date(2016, 2, 28, 11, 46, 5, 0, time.UTC)
+	var sleeps []time.Duration
+	r := &intervalRateLimiter{
+		interval: 100 * time.Millisecond,
+		now:      func() time.Time { return now },
+		sleep:    func(d time.Duration) { sleeps = append(sleeps, d) },
+	}
+	r.Wait()
+	r.Wait()
+	r.Wait()
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}
+	if !reflect.DeepEqual(sleeps, want) {
+		t.Errorf("unexpected sleeps for back-to-back requests. got %v, want %v", sleeps, want)
+	}
+
+	sleeps = nil
+	now = now.Add(time.Second)
+	r.Wait()
+	if len(sleeps) != 0 {
+		t.Errorf("request after an idle period should not wait, got %v", sleeps)
+	}
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	var tests = []struct {
+		requestsPerSecond int
+		want              time.Duration
+		expectSuccess     bool
+		msg               string
+	}{
+		{
+			requestsPerSecond: 4,
+			want:              250 * time.Millisecond,
+			expectSuccess:     true,
+			msg:               "positive rate should space requests evenly",
+		},
+		{
+			requestsPerSecond: 0,
+			expectSuccess:     false,
+			msg:               "zero rate should fail",
+		},
+		{
+			requestsPerSecond: -1,
+			expectSuccess:     false,
+			msg:               "negative rate should fail",
+		},
+	}
+	for _, tt := range tests {
+		r, err := NewRateLimiter(tt.requestsPerSecond)
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s: expected success, got error: %v", tt.msg, err)
+		} else if !tt.expectSuccess && err == nil {
+			t.Errorf("%s: expected failure, got success", tt.msg)
+		} else if err == nil && r.(*intervalRateLimiter).interval != tt.want {
+			t.Errorf("%s: unexpected interval. got %v, want %v", tt.msg, r.(*intervalRateLimiter).interval, tt.want)
+		}
+	}
+}
+
+func TestClientWaitsOnRateLimiter(t *testing.T) {
+	rateLimiter := &countingRateLimiter{}
+	c := defaultClient{
+		rawClient:           &mockRawClient{},
+		accountParser:       defaultAccountParser{},
+		notesResponseParser: newNotesResponseParser(defaultNoteParser{}),
+		listingParser:       defaultListingParser{location: time.UTC},
+		orderParser:         defaultOrderParser{location: time.UTC},
+		rateLimiter:         rateLimiter,
+	}
+	c.Account(AccountParams{})
+	c.Notes(NotesParams{})
+	c.Search(SearchParams{})
+	c.PlaceBid(NewBidRequest(1, 25*Dollar))
+	c.OrderStatus("order-1")
+	if rateLimiter.waits != 5 {
+		t.Errorf("client should wait on the rate limiter before each request, waited %d times for 5 requests", rateLimiter.waits)
+	}
+}
+
+func TestNewClientWithOptionsRateLimiter(t *testing.T) {
+	rateLimiter := &countingRateLimiter{}
+	c := NewClientWithOptions(auth.ClientCredentials{}, ClientOptions{RateLimiter: rateLimiter}).(*defaultClient)
+	if c.rateLimiter != rateLimiter {
+		t.Errorf("client should use the rate limiter from its options")
+	}
+	c = NewClient(auth.ClientCredentials{}).(*defaultClient)
+	if c.rateLimiter == nil {
+		t.Errorf("client should default to a rate limiter")
+	}
+}
@@ -1,7 +1,8 @@
 package prosper
 
 import (
-	"log"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/mtlynch/gofn-prosper/interval"
@@ -27,6 +28,10 @@ const (
 	IncomeRangeMin     IncomeRange = NotDisplayed
 	IncomeRangeMax     IncomeRange = NotEmployed
 	IncomeRangeInvalid IncomeRange = -1
+	// IncomeRangeUnknown stands for a income_range value this package does not
+	// recognize. It encodes as "Unknown", so the raw value Prosper sent is kept
+	// only in the UnknownEnumValues field of the parsed Listing.
+	IncomeRangeUnknown IncomeRange = -2
 )
 
 // FicoScore represents the FICO credit score of the borrower associated with a
@@ -51,6 +56,10 @@ const (
 	Between800And819
 	Between820And850
 	FicoScoreInvalid
+	// FicoScoreUnknown stands for a fico_score value this package does not
+	// recognize. It encodes as "Unknown", so the raw value Prosper sent is kept
+	// only in the UnknownEnumValues field of the parsed Listing.
+	FicoScoreUnknown FicoScore = -1
 )
 
 // ListingStatus represents the status of a loan listing. Possible values
@@ -69,9 +78,192 @@ const (
 	ListingPendingReviewOrAcceptance ListingStatus = 8
 	ListingStatusMin                 ListingStatus = ListingActive
 	ListingStatusMax                 ListingStatus = ListingPendingReviewOrAcceptance
-	ListingStatusUnknown             ListingStatus = -1
+	// ListingStatusUnknown stands for a listing_status value this package does not
+	// recognize. It encodes as "Unknown", so the raw value Prosper sent is kept
+	// only in the UnknownEnumValues field of the parsed Listing.
+	ListingStatusUnknown ListingStatus = -1
 )
 
+// ProsperScore represents the Prosper-assigned risk score of a listing, from 1
+// (highest risk) to 11 (lowest risk). Possible values correspond to the values
+// defined under the prosper_score attribute documented at:
+// https://developers.prosper.com/docs/investor/searchlistings-api/
+type ProsperScore int8
+
+// Set of possible ProsperScore values.
+const (
+	ProsperScoreNotAvailable ProsperScore = 0
+	ProsperScoreMin          ProsperScore = 1
+	ProsperScoreMax          ProsperScore = 11
+	ProsperScoreInvalid      ProsperScore = -1
+	// ProsperScoreUnknown stands for a prosper_score value this package does not
+	// recognize. It encodes as "Unknown", so the raw value Prosper sent is kept
+	// only in the UnknownEnumValues field of the parsed Listing.
+	ProsperScoreUnknown ProsperScore = -2
+)
+
+// VerificationStage represents how far Prosper has progressed in verifying the
+// borrower's information. Possible values correspond to the values defined
+// under the verification_stage attribute documented at:
+// https://developers.prosper.com/docs/investor/searchlistings-api/
+type VerificationStage int8
+
+// Set of possible VerificationStage values.
+const (
+	VerificationStageOne     VerificationStage = 1
+	VerificationStageTwo     VerificationStage = 2
+	VerificationStageThree   VerificationStage = 3
+	VerificationStageMin     VerificationStage = VerificationStageOne
+	VerificationStageMax     VerificationStage = VerificationStageThree
+	VerificationStageInvalid VerificationStage = -1
+	// VerificationStageUnknown stands for a verification_stage value this package
+	// does not recognize. It encodes as "Unknown", so the raw value Prosper sent
+	// is kept only in the UnknownEnumValues field of the parsed Listing.
+	VerificationStageUnknown VerificationStage = -2
+)
+
+// String returns a string representation of a VerificationStage.
+func (v VerificationStage) String() string {
+	if v == VerificationStageUnknown {
+		return unknownEnumText
+	}
+	if v < VerificationStageMin || v > VerificationStageMax {
+		return "Invalid"
+	}
+	return fmt.Sprintf("Stage %d", v)
+}
+
+// InvestmentType represents whether a listing is offered to investors as
+// fractional notes or as a whole loan. Possible values correspond to the values
+// defined under the investment_typeid attribute documented at:
+// https://developers.prosper.com/docs/investor/searchlistings-api/
+type InvestmentType int8
+
+// Set of possible InvestmentType values.
+const (
+	InvestmentFractional  InvestmentType = 1
+	InvestmentWhole       InvestmentType = 2
+	InvestmentTypeInvalid InvestmentType = -1
+	// InvestmentTypeUnknown stands for a investment_typeid value this package does
+	// not recognize. It encodes as "Unknown", so the raw value Prosper sent is
+	// kept only in the UnknownEnumValues field of the parsed Listing.
+	InvestmentTypeUnknown InvestmentType = -2
+)
+
+// String returns a string representation of an InvestmentType.
+func (t InvestmentType) String() string {
+	switch t {
+	case InvestmentFractional:
+		return "Fractional"
+	case InvestmentWhole:
+		return "Whole"
+	case InvestmentTypeUnknown:
+		return unknownEnumText
+	}
+	return "Invalid"
+}
+
+// LenderIndicator represents whether the borrower associated with a listing is
+// also a Prosper lender. Possible values correspond to the values defined
+// under the lender_indicator attribute documented at:
+// https://developers.prosper.com/docs/investor/searchlistings-api/
+type LenderIndicator int8
+
+// Set of possible LenderIndicator values.
+const (
+	BorrowerIsNotLender    LenderIndicator = 0
+	BorrowerIsLender       LenderIndicator = 1
+	LenderIndicatorInvalid LenderIndicator = -1
+	// LenderIndicatorUnknown stands for a lender_indicator value this package does
+	// not recognize. It encodes as "Unknown", so the raw value Prosper sent is
+	// kept only in the UnknownEnumValues field of the parsed Listing.
+	LenderIndicatorUnknown LenderIndicator = -2
+)
+
+// String returns a string representation of a LenderIndicator.
+func (l LenderIndicator) String() string {
+	switch l {
+	case BorrowerIsNotLender:
+		return "Not a lender"
+	case BorrowerIsLender:
+		return "Lender"
+	case LenderIndicatorUnknown:
+		return unknownEnumText
+	}
+	return "Invalid"
+}
+
+// ListingCategory represents the purpose for which the borrower is requesting
+// a loan. Possible values correspond to the values defined under the
+// listing_category_id attribute documented at:
+// https://developers.prosper.com/docs/investor/searchlistings-api/
+type ListingCategory int8
+
+// Set of possible ListingCategory values.
+const (
+	CategoryNotAvailable      ListingCategory = 0
+	CategoryDebtConsolidation ListingCategory = 1
+	CategoryHomeImprovement   ListingCategory = 2
+	CategoryBusiness          ListingCategory = 3
+	CategoryPersonalLoan      ListingCategory = 4
+	CategoryStudentUse        ListingCategory = 5
+	CategoryAuto              ListingCategory = 6
+	CategoryOther             ListingCategory = 7
+	CategoryBabyAndAdoption   ListingCategory = 8
+	CategoryBoat              ListingCategory = 9
+	CategoryCosmeticProcedure ListingCategory = 10
+	CategoryEngagementRing    ListingCategory = 11
+	CategoryGreenLoans        ListingCategory = 12
+	CategoryHouseholdExpenses ListingCategory = 13
+	CategoryLargePurchases    ListingCategory = 14
+	CategoryMedicalDental     ListingCategory = 15
+	CategoryMotorcycle        ListingCategory = 16
+	CategoryRV                ListingCategory = 17
+	CategoryTaxes             ListingCategory = 18
+	CategoryVacation          ListingCategory = 19
+	CategoryWeddingLoans      ListingCategory = 20
+	ListingCategoryMin        ListingCategory = CategoryNotAvailable
+	ListingCategoryMax        ListingCategory = CategoryWeddingLoans
+	ListingCategoryInvalid    ListingCategory = -1
+	// ListingCategoryUnknown stands for a listing_category_id value this package
+	// does not recognize. It encodes as "Unknown", so the raw value Prosper sent
+	// is kept only in the UnknownEnumValues field of the parsed Listing.
+	ListingCategoryUnknown ListingCategory = -2
+)
+
+// String returns a string representation of a ListingCategory.
+func (c ListingCategory) String() string {
+	categoryToString := map[ListingCategory]string{
+		CategoryNotAvailable:      "Not Available",
+		CategoryDebtConsolidation: "Debt Consolidation",
+		CategoryHomeImprovement:   "Home Improvement",
+		CategoryBusiness:          "Business",
+		CategoryPersonalLoan:      "Personal Loan",
+		CategoryStudentUse:        "Student Use",
+		CategoryAuto:              "Auto",
+		CategoryOther:             "Other",
+		CategoryBabyAndAdoption:   "Baby & Adoption",
+		CategoryBoat:              "Boat",
+		CategoryCosmeticProcedure: "Cosmetic Procedure",
+		CategoryEngagementRing:    "Engagement Ring",
+		CategoryGreenLoans:        "Green Loans",
+		CategoryHouseholdExpenses: "Household Expenses",
+		CategoryLargePurchases:    "Large Purchases",
+		CategoryMedicalDental:     "Medical/Dental",
+		CategoryMotorcycle:        "Motorcycle",
+		CategoryRV:                "RV",
+		CategoryTaxes:             "Taxes",
+		CategoryVacation:          "Vacation",
+		CategoryWeddingLoans:      "Wedding Loans",
+		ListingCategoryUnknown:    unknownEnumText,
+	}
+	s, ok := categoryToString[c]
+	if !ok {
+		return "Invalid"
+	}
+	return s
+}
+
 // ListingNumber represents the unique identifier associated with a listing.
 type ListingNumber int64
 
@@ -86,8 +278,14 @@ type Listing struct {
 	BankcardUtilization                       float64
 	BorrowerApr                               float64
 	BorrowerCity                              string
+	BorrowerListingDescription                string
+	BorrowerMetropolitanArea                  string
 	BorrowerRate                              float64
 	BorrowerState                             string
+	ChannelCode                               string
+	CoBorrowerApplication                     bool
+	CombinedDtiWprosperLoan                   float64
+	CombinedStatedMonthlyIncome               float64
 	CreditLinesLast7Years                     int64
 	CreditPullDate                            time.Time
 	CurrentCreditLines                        int64
@@ -104,23 +302,27 @@ type Listing struct {
 	FicoScore                                 FicoScore
 	FirstRecordedCreditLine                   time.Time
 	FundingThreshold                          float64
+	GroupIndicator                            bool
+	GroupName                                 string
 	IncomeRange                               IncomeRange
 	IncomeRangeDescription                    string
 	IncomeVerifiable                          bool
 	InquiriesLast6Months                      int64
 	InstallmentBalance                        float64
 	InvestmentTypeDescription                 string
-	InvestmentTypeID                          int64 //TODO: Parse this
+	InvestmentTypeID                          InvestmentType
+	Invested                                  bool
 	IsHomeowner                               bool
 	LastUpdatedDate                           time.Time
-	LenderIndicator                           int64 //TODO: Parse this
+	LenderIndicator                           LenderIndicator
 	LenderYield                               float64
 	ListingAmount                             float64
-	ListingCategoryID                         int64
+	ListingCategoryID                         ListingCategory
 	ListingCreationDate                       time.Time
 	ListingEndDate                            time.Time
 	ListingMonthlyPayment                     float64
 	ListingNumber                             ListingNumber
+	ListingPurpose                            string
 	ListingStartDate                          time.Time
 	ListingStatus                             ListingStatus
 	ListingStatusReason                       string
@@ -133,7 +335,7 @@ type Listing struct {
 	MonthsEmployed                            int64
 	NowDelinquentDerog                        int64
 	Occupation                                string
-	OldestTradeOpenDate                       time.Time
+	OldestTradeOpenDate                       CreditAge
 	OpenCreditLines                           int64
 	PartialFundingIndicator                   bool
 	PercentFunded                             float64
@@ -150,7 +352,7 @@ type Listing struct {
 	PriorProsperLoansPrincipalBorrowed        float64
 	PriorProsperLoansPrincipalOutstanding     float64
 	Rating                                    Rating
-	ProsperScore                              int64 //TODO: Parse this
+	ProsperScore                              ProsperScore
 	PublicRecordsLast10Years                  int64
 	PublicRecordsLast12Months                 int64
 	RealEstateBalance                         float64
@@ -158,31 +360,105 @@ type Listing struct {
 	RevolvingAvailablePercent                 float64
 	RevolvingBalance                          float64
 	SatisfactoryAccounts                      int64
-	ScoreX                                    string //TODO: Parse this
-	ScoreXChange                              string //TODO: Parse this
+	ScoreX                                    interval.Int32Range
+	ScoreXChange                              *int64
 	StatedMonthlyIncome                       float64
 	TotalInquiries                            int64
 	TotalOpenRevolvingAccounts                int64
 	TotalTradeItems                           int64
-	VerificationStage                         int64 //TODO: Parse this
+	VerificationStage                         VerificationStage
 	WasDelinquentDerog                        int64
 	WholeLoanEndDate                          time.Time
 	WholeLoanStartDate                        time.Time
+
+	// UnknownEnumValues holds the raw values of enum attributes that parsed to
+	// an Unknown variant, keyed by Prosper attribute name. It is only populated
+	// when the client accepts unknown enum values.
+	UnknownEnumValues map[string]string
+	// Raw holds the JSON Prosper sent for this listing. It is only populated when
+	// the client keeps raw JSON.
+	Raw json.RawMessage `json:",omitempty"`
+
+	// amountText holds the decimal text Prosper sent for the currency fields.
+	amountText thin.SearchResultAmounts
 }
 
+// SortField represents a listing attribute by which Prosper can sort Search
+// results. Possible values correspond to the values accepted by the sort_by
+// parameter documented at:
+// https://developers.prosper.com/docs/investor/searchlistings-api/
+type SortField string
+
+// Set of possible SortField values.
+const (
+	SortByAmountRemaining   SortField = "amount_remaining"
+	SortByBorrowerRate      SortField = "borrower_rate"
+	SortByEffectiveYield    SortField = "effective_yield"
+	SortByEstimatedLossRate SortField = "estimated_loss_rate"
+	SortByEstimatedReturn   SortField = "estimated_return"
+	SortByLenderYield       SortField = "lender_yield"
+	SortByListingAmount     SortField = "listing_amount"
+	SortByListingEndDate    SortField = "listing_end_date"
+	SortByListingNumber     SortField = "listing_number"
+	SortByListingStartDate  SortField = "listing_start_date"
+	SortByPercentFunded     SortField = "percent_funded"
+	SortByProsperRating     SortField = "prosper_rating"
+	SortByProsperScore      SortField = "prosper_score"
+)
+
+// SortDirection represents the order in which to sort Search results.
+type SortDirection int8
+
+// Set of possible SortDirection values.
+const (
+	SortAscending SortDirection = iota
+	SortDescending
+)
+
 type (
+	// SearchSort specifies a single sort key for Search results. When multiple
+	// SearchSort values are given, Prosper sorts by each key in order.
+	SearchSort struct {
+		Field     SortField
+		Direction SortDirection
+	}
+
 	// SearchFilter specifies a filter for the types of listings to retrieve in
-	// the Search function.
+	// the Search function. A SearchFilter encodes to JSON using Prosper's
+	// filter names, with ranges in interval notation such as "[0.05,0.12)" and
+	// enums as their text values, so it can be kept in JSON config files, or
+	// YAML files through a YAML library that honors JSON tags. Unset filters
+	// are omitted.
 	SearchFilter struct {
-		EstimatedReturn                           interval.Float64Range
-		IncomeRange                               []IncomeRange
-		InquiriesLast6Months                      interval.Int32Range
-		PriorProsperLoansLatePaymentsOneMonthPlus interval.Int32Range
-		PriorProsperLoansBalanceOutstanding       interval.Float64Range
-		DtiWprosperLoan                           interval.Float64Range
-		Rating                                    []Rating
-		ListingStartDate                          interval.TimeRange
-		ListingStatus                             []ListingStatus
+		EstimatedReturn                           interval.Float64Range `json:"estimated_return,omitzero"`
+		IncomeRange                               []IncomeRange         `json:"income_range,omitempty"`
+		InquiriesLast6Months                      interval.Int32Range   `json:"inquiries_last6_months,omitzero"`
+		PriorProsperLoansLatePaymentsOneMonthPlus interval.Int32Range   `json:"prior_prosper_loans_late_payments_one_month_plus,omitzero"`
+		PriorProsperLoansBalanceOutstanding       interval.Float64Range `json:"prior_prosper_loans_balance_outstanding,omitzero"`
+		DtiWprosperLoan                           interval.Float64Range `json:"dti_wprosper_loan,omitzero"`
+		Rating                                    []Rating              `json:"prosper_rating,omitempty"`
+		ListingStartDate                          interval.TimeRange    `json:"listing_start_date,omitzero"`
+		ListingStatus                             []ListingStatus       `json:"listing_status,omitempty"`
+		ListingNumber                             []ListingNumber       `json:"listing_number,omitempty"`
+		ListingTerm                               []int64               `json:"listing_term,omitempty"`
+		ListingAmount                             interval.Float64Range `json:"listing_amount,omitzero"`
+		AmountRemaining                           interval.Float64Range `json:"amount_remaining,omitzero"`
+		PercentFunded                             interval.Float64Range `json:"percent_funded,omitzero"`
+		LenderYield                               interval.Float64Range `json:"lender_yield,omitzero"`
+		BorrowerRate                              interval.Float64Range `json:"borrower_rate,omitzero"`
+		EffectiveYield                            interval.Float64Range `json:"effective_yield,omitzero"`
+		EstimatedLossRate                         interval.Float64Range `json:"estimated_loss_rate,omitzero"`
+		FicoScore                                 []FicoScore           `json:"fico_score,omitempty"`
+		ProsperScore                              interval.Int32Range   `json:"prosper_score,omitzero"`
+		BorrowerState                             []string              `json:"borrower_state,omitempty"`
+		EmploymentStatusDescription               []string              `json:"employment_status_description,omitempty"`
+		MonthsEmployed                            interval.Int32Range   `json:"months_employed,omitzero"`
+		StatedMonthlyIncome                       interval.Float64Range `json:"stated_monthly_income,omitzero"`
+		IsHomeowner                               *bool                 `json:"is_homeowner,omitempty"`
+		IncomeVerifiable                          *bool                 `json:"income_verifiable,omitempty"`
+		ListingCategoryID                         []ListingCategory     `json:"listing_category_id,omitempty"`
+		PriorProsperLoans                         interval.Int32Range   `json:"prior_prosper_loans,omitzero"`
+		PriorProsperLoansActive                   interval.Int32Range   `json:"prior_prosper_loans_active,omitzero"`
 	}
 
 	// SearchParams specifies parameters to the Search.
@@ -190,15 +466,21 @@ type (
 		Offset                  int
 		Limit                   int
 		ExcludeListingsInvested bool
+		SortBy                  []SearchSort
 		Filter                  SearchFilter
+		ParseMode               ParseMode
 	}
 
 	// SearchResponse represents the full response from the Search API, documented
 	// at: https://developers.prosper.com/docs/investor/searchlistings-api/
+	// ResultCount is the number of listings Prosper returned, including any that
+	// failed to parse. In ParseLenient mode, ParseErrors describes each listing
+	// that was omitted from Results because it failed to parse.
 	SearchResponse struct {
 		Results     []Listing
 		ResultCount int
 		TotalCount  int
+		ParseErrors []*ListingParseError
 	}
 
 	// ListingSearcher is an interface that supports the Search API for active
@@ -209,18 +491,33 @@ type (
 )
 
 // Search queries Prosper for current listings that match specified search
-// parameters. Search implements the REST API described at:
+// parameters. Search first checks p with Validate and, if p is invalid, fails
+// with a *SearchValidationError without contacting Prosper. Searches that
+// earlier versions sent to Prosper, such as one with a Limit above
+// MaxSearchLimit or a prosper_score bound outside ProsperScoreMin to
+// ProsperScoreMax, now fail this way.
+// Search implements the REST API described at:
 // https://developers.prosper.com/docs/investor/searchlistings-api/
 func (c defaultClient) Search(p SearchParams) (response SearchResponse, err error) {
+	if err := p.Validate(); err != nil {
+		return SearchResponse{}, err
+	}
+	c.wait()
 	rawResponse, err := c.rawClient.Search(searchParamsToThinType(p))
 	if err != nil {
 		return SearchResponse{}, err
 	}
 	var results []Listing
+	var parseErrors []*ListingParseError
 	for _, lRaw := range rawResponse.Results {
 		l, err := c.listingParser.Parse(lRaw)
 		if err != nil {
-			log.Printf("failed to parse listing. err: %v, listing: %+v", err, lRaw)
+			parseErr := toListingParseError(lRaw, err)
+			logListingParseError(c.log(), parseErr)
+			if p.ParseMode == ParseLenient {
+				parseErrors = append(parseErrors, parseErr)
+				continue
+			}
 			return SearchResponse{}, err
 		}
 		results = append(results, l)
@@ -229,18 +526,41 @@ func (c defaultClient) Search(p SearchParams) (response SearchResponse, err erro
 		Results:     results,
 		ResultCount: rawResponse.ResultCount,
 		TotalCount:  rawResponse.TotalCount,
+		ParseErrors: parseErrors,
 	}, nil
 }
 
+func toListingParseError(r thin.SearchResult, err error) *ListingParseError {
+	if parseErr, ok := err.(*ListingParseError); ok {
+		return parseErr
+	}
+	return &ListingParseError{
+		ListingNumber: ListingNumber(r.ListingNumber),
+		Err:           err,
+	}
+}
+
 func searchParamsToThinType(p SearchParams) thin.SearchParams {
 	return thin.SearchParams{
-		Offset: p.Offset,
-		Limit:  p.Limit,
+		Offset:                  p.Offset,
+		Limit:                   p.Limit,
 		ExcludeListingsInvested: p.ExcludeListingsInvested,
+		SortBy:                  searchSortsToThinType(p.SortBy),
 		Filter:                  searchFilterToThinType(p.Filter),
 	}
 }
 
+func searchSortsToThinType(sorts []SearchSort) []thin.SortKey {
+	keys := []thin.SortKey{}
+	for _, s := range sorts {
+		keys = append(keys, thin.SortKey{
+			Field:      string(s.Field),
+			Descending: s.Direction == SortDescending,
+		})
+	}
+	return keys
+}
+
 func searchFilterToThinType(f SearchFilter) thin.SearchFilter {
 	incomeRanges := []int8{}
 	for _, incomeRange := range f.IncomeRange {
@@ -248,39 +568,57 @@ func searchFilterToThinType(f SearchFilter) thin.SearchFilter {
 	}
 	ratings := []string{}
 	for _, rating := range f.Rating {
-		ratings = append(ratings, ratingToString(rating))
+		ratings = append(ratings, rating.String())
 	}
 	listingStatus := []int{}
 	for _, status := range f.ListingStatus {
 		listingStatus = append(listingStatus, int(status))
 	}
+	listingNumbers := []int64{}
+	for _, listingNumber := range f.ListingNumber {
+		listingNumbers = append(listingNumbers, int64(listingNumber))
+	}
+	listingTerms := []int{}
+	for _, term := range f.ListingTerm {
+		listingTerms = append(listingTerms, int(term))
+	}
+	ficoScores := []string{}
+	for _, ficoScore := range f.FicoScore {
+		ficoScores = append(ficoScores, ficoScore.String())
+	}
+	listingCategoryIDs := []int{}
+	for _, categoryID := range f.ListingCategoryID {
+		listingCategoryIDs = append(listingCategoryIDs, int(categoryID))
+	}
 	return thin.SearchFilter{
-		EstimatedReturn:                           f.EstimatedReturn,
-		IncomeRange:                               incomeRanges,
-		InquiriesLast6Months:                      f.InquiriesLast6Months,
+		EstimatedReturn:      f.EstimatedReturn,
+		IncomeRange:          incomeRanges,
+		InquiriesLast6Months: f.InquiriesLast6Months,
 		PriorProsperLoansLatePaymentsOneMonthPlus: f.PriorProsperLoansLatePaymentsOneMonthPlus,
 		PriorProsperLoansBalanceOutstanding:       f.PriorProsperLoansBalanceOutstanding,
 		DtiWprosperLoan:                           f.DtiWprosperLoan,
 		Rating:                                    ratings,
 		ListingStartDate:                          f.ListingStartDate,
 		ListingStatus:                             listingStatus,
+		ListingNumber:                             listingNumbers,
+		ListingTerm:                               listingTerms,
+		ListingAmount:                             f.ListingAmount,
+		AmountRemaining:                           f.AmountRemaining,
+		PercentFunded:                             f.PercentFunded,
+		LenderYield:                               f.LenderYield,
+		BorrowerRate:                              f.BorrowerRate,
+		EffectiveYield:                            f.EffectiveYield,
+		EstimatedLossRate:                         f.EstimatedLossRate,
+		FicoScore:                                 ficoScores,
+		ProsperScore:                              f.ProsperScore,
+		BorrowerState:                             f.BorrowerState,
+		EmploymentStatusDescription:               f.EmploymentStatusDescription,
+		MonthsEmployed:                            f.MonthsEmployed,
+		StatedMonthlyIncome:                       f.StatedMonthlyIncome,
+		IsHomeowner:                               f.IsHomeowner,
+		IncomeVerifiable:                          f.IncomeVerifiable,
+		ListingCategoryID:                         listingCategoryIDs,
+		PriorProsperLoans:                         f.PriorProsperLoans,
+		PriorProsperLoansActive:                   f.PriorProsperLoansActive,
 	}
 }
-
-func ratingToString(r Rating) string {
-	ratingToString := map[Rating]string{
-		RatingAA: "AA",
-		RatingA:  "A",
-		RatingB:  "B",
-		RatingC:  "C",
-		RatingD:  "D",
-		RatingE:  "E",
-		RatingHR: "HR",
-		RatingNA: "N/A",
-	}
-	s, ok := ratingToString[r]
-	if !ok {
-		panic("failed to convert prosper rating")
-	}
-	return s
-}
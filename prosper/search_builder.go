@@ -0,0 +1,285 @@
+package prosper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mtlynch/gofn-prosper/interval"
+)
+
+// SearchBuilder builds and validates SearchParams through chained method calls,
+// for example:
+//
+//	params, err := prosper.NewSearch().
+//		Rating(prosper.RatingA, prosper.RatingB).
+//		Where(prosper.FilterEstimatedReturn.AtLeast(0.06)).
+//		Term(36).
+//		ExcludeInvested().
+//		Build()
+//
+// Methods that restrict a field to a set of values replace any values set
+// earlier. Range filters are set through Where, with clauses such as
+// FilterProsperScore.AtLeast(6).
+type SearchBuilder struct {
+	params SearchParams
+	errs   []error
+}
+
+// NewSearch creates a SearchBuilder with no filters.
+func NewSearch() *SearchBuilder {
+	return &SearchBuilder{}
+}
+
+// Build returns the SearchParams, or a *SearchValidationError listing every
+// problem with them, such as empty sets of values, invalid or Unknown enum
+// values, inverted ranges, and a limit above MaxSearchLimit.
+func (b *SearchBuilder) Build() (SearchParams, error) {
+	errs := append(append([]error{}, b.errs...), b.params.validationErrors()...)
+	if len(errs) > 0 {
+		return SearchParams{}, &SearchValidationError{Errors: errs}
+	}
+	return b.params, nil
+}
+
+// Offset sets the number of matching listings to skip.
+func (b *SearchBuilder) Offset(offset int) *SearchBuilder {
+	b.params.Offset = offset
+	return b
+}
+
+// Limit sets the maximum number of listings to return, which may not exceed
+// MaxSearchLimit.
+func (b *SearchBuilder) Limit(limit int) *SearchBuilder {
+	b.params.Limit = limit
+	return b
+}
+
+// ExcludeInvested excludes listings in which the user has already invested.
+func (b *SearchBuilder) ExcludeInvested() *SearchBuilder {
+	b.params.ExcludeListingsInvested = true
+	return b
+}
+
+// SortBy adds a sort key. Prosper sorts by each key in the order added.
+func (b *SearchBuilder) SortBy(field SortField, direction SortDirection) *SearchBuilder {
+	b.params.SortBy = append(b.params.SortBy, SearchSort{Field: field, Direction: direction})
+	return b
+}
+
+// ParseMode sets how Search handles listings that fail to parse.
+func (b *SearchBuilder) ParseMode(mode ParseMode) *SearchBuilder {
+	b.params.ParseMode = mode
+	return b
+}
+
+// Filter replaces the entire filter.
+func (b *SearchBuilder) Filter(f SearchFilter) *SearchBuilder {
+	b.params.Filter = f
+	return b
+}
+
+// IsHomeowner restricts results to borrowers who are, or are not, homeowners.
+func (b *SearchBuilder) IsHomeowner(isHomeowner bool) *SearchBuilder {
+	b.params.Filter.IsHomeowner = &isHomeowner
+	return b
+}
+
+// IncomeVerifiable restricts results to borrowers whose income is, or is not,
+// verifiable.
+func (b *SearchBuilder) IncomeVerifiable(verifiable bool) *SearchBuilder {
+	b.params.Filter.IncomeVerifiable = &verifiable
+	return b
+}
+
+// emptySet records an error for a set filter that was given no values.
+func (b *SearchBuilder) emptySet(field string) *SearchBuilder {
+	b.errs = append(b.errs, fmt.Errorf("%s filter requires at least one value", field))
+	return b
+}
+
+func atLeast[T interval.Value](r *interval.Range[T], min T) {
+	r.Min, r.MinExclusive = &min, false
+}
+
+func atMost[T interval.Value](r *interval.Range[T], max T) {
+	r.Max, r.MaxExclusive = &max, false
+}
+
+// Rating restricts results to listings with one of the given Prosper ratings.
+func (b *SearchBuilder) Rating(values ...Rating) *SearchBuilder {
+	if len(values) == 0 {
+		return b.emptySet("prosper_rating")
+	}
+	b.params.Filter.Rating = values
+	return b
+}
+
+// FicoScore restricts results to listings with one of the given FICO score ranges.
+func (b *SearchBuilder) FicoScore(values ...FicoScore) *SearchBuilder {
+	if len(values) == 0 {
+		return b.emptySet("fico_score")
+	}
+	b.params.Filter.FicoScore = values
+	return b
+}
+
+// IncomeRange restricts results to listings with one of the given borrower income ranges.
+func (b *SearchBuilder) IncomeRange(values ...IncomeRange) *SearchBuilder {
+	if len(values) == 0 {
+		return b.emptySet("income_range")
+	}
+	b.params.Filter.IncomeRange = values
+	return b
+}
+
+// ListingStatus restricts results to listings with one of the given listing statuses.
+func (b *SearchBuilder) ListingStatus(values ...ListingStatus) *SearchBuilder {
+	if len(values) == 0 {
+		return b.emptySet("listing_status")
+	}
+	b.params.Filter.ListingStatus = values
+	return b
+}
+
+// ListingCategory restricts results to listings with one of the given listing categories.
+func (b *SearchBuilder) ListingCategory(values ...ListingCategory) *SearchBuilder {
+	if len(values) == 0 {
+		return b.emptySet("listing_category_id")
+	}
+	b.params.Filter.ListingCategoryID = values
+	return b
+}
+
+// ListingNumber restricts results to listings with one of the given listing numbers.
+func (b *SearchBuilder) ListingNumber(values ...ListingNumber) *SearchBuilder {
+	if len(values) == 0 {
+		return b.emptySet("listing_number")
+	}
+	b.params.Filter.ListingNumber = values
+	return b
+}
+
+// Term restricts results to listings with one of the given loan terms, in months.
+func (b *SearchBuilder) Term(values ...int64) *SearchBuilder {
+	if len(values) == 0 {
+		return b.emptySet("listing_term")
+	}
+	b.params.Filter.ListingTerm = values
+	return b
+}
+
+// BorrowerState restricts results to listings with one of the given borrower states.
+func (b *SearchBuilder) BorrowerState(values ...string) *SearchBuilder {
+	if len(values) == 0 {
+		return b.emptySet("borrower_state")
+	}
+	b.params.Filter.BorrowerState = values
+	return b
+}
+
+// EmploymentStatus restricts results to listings with one of the given borrower employment statuses.
+func (b *SearchBuilder) EmploymentStatus(values ...string) *SearchBuilder {
+	if len(values) == 0 {
+		return b.emptySet("employment_status_description")
+	}
+	b.params.Filter.EmploymentStatusDescription = values
+	return b
+}
+
+// SearchClause sets part of a SearchFilter. The methods of SearchRange create
+// clauses for SearchBuilder.Where.
+type SearchClause func(*SearchFilter)
+
+// Where applies the given clauses to the filter, in order.
+func (b *SearchBuilder) Where(clauses ...SearchClause) *SearchBuilder {
+	for _, c := range clauses {
+		c(&b.params.Filter)
+	}
+	return b
+}
+
+// SearchRange identifies one of the range filters of a SearchFilter, such as
+// FilterEstimatedReturn.
+type SearchRange[T interval.Value] struct {
+	field func(*SearchFilter) *interval.Range[T]
+}
+
+// Within restricts results to listings whose value falls within r, replacing
+// both bounds.
+func (s SearchRange[T]) Within(r interval.Range[T]) SearchClause {
+	return func(f *SearchFilter) {
+		*s.field(f) = r
+	}
+}
+
+// AtLeast sets the minimum value and leaves the maximum unchanged.
+func (s SearchRange[T]) AtLeast(min T) SearchClause {
+	return func(f *SearchFilter) {
+		atLeast(s.field(f), min)
+	}
+}
+
+// AtMost sets the maximum value and leaves the minimum unchanged.
+func (s SearchRange[T]) AtMost(max T) SearchClause {
+	return func(f *SearchFilter) {
+		atMost(s.field(f), max)
+	}
+}
+
+// Set of range filters that SearchBuilder.Where accepts.
+var (
+	FilterEstimatedReturn = SearchRange[float64]{func(f *SearchFilter) *interval.Float64Range {
+		return &f.EstimatedReturn
+	}}
+	FilterInquiriesLast6Months = SearchRange[int32]{func(f *SearchFilter) *interval.Int32Range {
+		return &f.InquiriesLast6Months
+	}}
+	FilterPriorProsperLoansLatePaymentsOneMonthPlus = SearchRange[int32]{func(f *SearchFilter) *interval.Int32Range {
+		return &f.PriorProsperLoansLatePaymentsOneMonthPlus
+	}}
+	FilterPriorProsperLoansBalanceOutstanding = SearchRange[float64]{func(f *SearchFilter) *interval.Float64Range {
+		return &f.PriorProsperLoansBalanceOutstanding
+	}}
+	FilterDtiWprosperLoan = SearchRange[float64]{func(f *SearchFilter) *interval.Float64Range {
+		return &f.DtiWprosperLoan
+	}}
+	FilterListingStartDate = SearchRange[time.Time]{func(f *SearchFilter) *interval.TimeRange {
+		return &f.ListingStartDate
+	}}
+	FilterListingAmount = SearchRange[float64]{func(f *SearchFilter) *interval.Float64Range {
+		return &f.ListingAmount
+	}}
+	FilterAmountRemaining = SearchRange[float64]{func(f *SearchFilter) *interval.Float64Range {
+		return &f.AmountRemaining
+	}}
+	FilterPercentFunded = SearchRange[float64]{func(f *SearchFilter) *interval.Float64Range {
+		return &f.PercentFunded
+	}}
+	FilterLenderYield = SearchRange[float64]{func(f *SearchFilter) *interval.Float64Range {
+		return &f.LenderYield
+	}}
+	FilterBorrowerRate = SearchRange[float64]{func(f *SearchFilter) *interval.Float64Range {
+		return &f.BorrowerRate
+	}}
+	FilterEffectiveYield = SearchRange[float64]{func(f *SearchFilter) *interval.Float64Range {
+		return &f.EffectiveYield
+	}}
+	FilterEstimatedLossRate = SearchRange[float64]{func(f *SearchFilter) *interval.Float64Range {
+		return &f.EstimatedLossRate
+	}}
+	FilterProsperScore = SearchRange[int32]{func(f *SearchFilter) *interval.Int32Range {
+		return &f.ProsperScore
+	}}
+	FilterMonthsEmployed = SearchRange[int32]{func(f *SearchFilter) *interval.Int32Range {
+		return &f.MonthsEmployed
+	}}
+	FilterStatedMonthlyIncome = SearchRange[float64]{func(f *SearchFilter) *interval.Float64Range {
+		return &f.StatedMonthlyIncome
+	}}
+	FilterPriorProsperLoans = SearchRange[int32]{func(f *SearchFilter) *interval.Int32Range {
+		return &f.PriorProsperLoans
+	}}
+	FilterPriorProsperLoansActive = SearchRange[int32]{func(f *SearchFilter) *interval.Int32Range {
+		return &f.PriorProsperLoansActive
+	}}
+)
@@ -0,0 +1,111 @@
+package prosper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mtlynch/gofn-prosper/interval"
+)
+
+func TestSearchBuilderBuild(t *testing.T) {
+	listedAfter := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	got, err := NewSearch().
+		Rating(RatingA, RatingB).
+		Where(
+			FilterEstimatedReturn.AtLeast(0.06),
+			FilterEstimatedReturn.AtMost(0.1),
+			FilterProsperScore.AtLeast(6),
+			FilterListingStartDate.AtLeast(listedAfter)).
+		Term(36).
+		IsHomeowner(true).
+		ExcludeInvested().
+		SortBy(SortByEstimatedReturn, SortDescending).
+		Limit(MaxSearchLimit).
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	isHomeowner := true
+	want := SearchParams{
+		Limit:                   MaxSearchLimit,
+		ExcludeListingsInvested: true,
+		SortBy:                  []SearchSort{{Field: SortByEstimatedReturn, Direction: SortDescending}},
+		Filter: SearchFilter{
+			Rating:           []Rating{RatingA, RatingB},
+			EstimatedReturn:  interval.NewFloat64Range(0.06, 0.1),
+			ProsperScore:     interval.Int32Range{Min: interval.CreateInt32(6)},
+			ListingStartDate: interval.TimeRange{Min: &listedAfter},
+			ListingTerm:      []int64{36},
+			IsHomeowner:      &isHomeowner,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Build returned %+v, want %+v", got, want)
+	}
+}
+
+func TestSearchBuilderValidation(t *testing.T) {
+	var tests = []struct {
+		b          *SearchBuilder
+		wantErrors int
+		msg        string
+	}{
+		{
+			b:          NewSearch().Rating(),
+			wantErrors: 1,
+			msg:        "empty set of ratings should fail",
+		},
+		{
+			b:          NewSearch().Rating(RatingAA, Rating(42)),
+			wantErrors: 1,
+			msg:        "invalid rating should fail",
+		},
+		{
+			b:          NewSearch().FicoScore(FicoScoreUnknown),
+			wantErrors: 1,
+			msg:        "Unknown enum variant should fail",
+		},
+		{
+			b:          NewSearch().Where(FilterLenderYield.AtLeast(0.2), FilterLenderYield.AtMost(0.1)),
+			wantErrors: 1,
+			msg:        "inverted range should fail",
+		},
+		{
+			b:          NewSearch().Where(FilterProsperScore.Within(interval.NewInt32Range(0, 12))),
+			wantErrors: 2,
+			msg:        "prosper score bounds outside 1 to 11 should fail",
+		},
+		{
+			b:          NewSearch().Limit(MaxSearchLimit + 1),
+			wantErrors: 1,
+			msg:        "limit above the API maximum should fail",
+		},
+		{
+			b:          NewSearch().SortBy(SortField("borrower_city"), SortAscending),
+			wantErrors: 1,
+			msg:        "unrecognized sort field should fail",
+		},
+		{
+			b: NewSearch().
+				Term().
+				ListingCategory(ListingCategoryInvalid).
+				Where(FilterProsperScore.AtLeast(9), FilterProsperScore.AtMost(2)).
+				Offset(-1),
+			wantErrors: 4,
+			msg:        "every problem should be reported",
+		},
+	}
+	for _, tt := range tests {
+		_, err := tt.b.Build()
+		var validationErr *SearchValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("%s: Build returned %v, want a *SearchValidationError", tt.msg, err)
+			continue
+		}
+		if len(validationErr.Errors) != tt.wantErrors {
+			t.Errorf("%s: Build returned %d errors, want %d: %v", tt.msg, len(validationErr.Errors), tt.wantErrors, err)
+		}
+	}
+}
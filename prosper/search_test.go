@@ -1,9 +1,11 @@
 package prosper
 
 import (
+	"encoding/json"
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/mtlynch/gofn-prosper/interval"
 	"github.com/mtlynch/gofn-prosper/prosper/thin"
@@ -32,9 +34,16 @@ func (p *mockListingParser) Parse(r thin.SearchResult) (Listing, error) {
 var (
 	rawListingA             = thin.SearchResult{ListingNumber: 1234}
 	rawListingB             = thin.SearchResult{ListingNumber: 4567}
+	rawListingC             = thin.SearchResult{ListingNumber: 8910}
 	listingA                = Listing{ListingNumber: 1234}
 	listingB                = Listing{ListingNumber: 4567}
 	errMockListingParseFail = errors.New("mock listing parser error")
+	mockFieldParseError     = &ListingParseError{
+		ListingNumber: 8910,
+		Field:         "fico_score",
+		Value:         "900-999",
+		Err:           errMockListingParseFail,
+	}
 )
 
 func rawSearchFilterEqual(a, b thin.SearchFilter) bool {
@@ -71,9 +80,90 @@ func rawSearchFilterEqual(a, b thin.SearchFilter) bool {
 			return false
 		}
 	}
+	if len(a.ListingNumber) != len(b.ListingNumber) {
+		return false
+	}
+	for i := range a.ListingNumber {
+		if a.ListingNumber[i] != b.ListingNumber[i] {
+			return false
+		}
+	}
+	if !intSlicesEqual(a.ListingTerm, b.ListingTerm) {
+		return false
+	}
+	if !intSlicesEqual(a.ListingCategoryID, b.ListingCategoryID) {
+		return false
+	}
+	if !stringSlicesEqual(a.FicoScore, b.FicoScore) {
+		return false
+	}
+	if !stringSlicesEqual(a.BorrowerState, b.BorrowerState) {
+		return false
+	}
+	if !stringSlicesEqual(a.EmploymentStatusDescription, b.EmploymentStatusDescription) {
+		return false
+	}
+	for _, r := range [][2]interval.Float64Range{
+		{a.ListingAmount, b.ListingAmount},
+		{a.AmountRemaining, b.AmountRemaining},
+		{a.PercentFunded, b.PercentFunded},
+		{a.LenderYield, b.LenderYield},
+		{a.BorrowerRate, b.BorrowerRate},
+		{a.EffectiveYield, b.EffectiveYield},
+		{a.EstimatedLossRate, b.EstimatedLossRate},
+		{a.StatedMonthlyIncome, b.StatedMonthlyIncome},
+	} {
+		if !interval.Float64RangeEqual(r[0], r[1]) {
+			return false
+		}
+	}
+	for _, r := range [][2]interval.Int32Range{
+		{a.ProsperScore, b.ProsperScore},
+		{a.MonthsEmployed, b.MonthsEmployed},
+		{a.PriorProsperLoans, b.PriorProsperLoans},
+		{a.PriorProsperLoansActive, b.PriorProsperLoansActive},
+	} {
+		if !interval.Int32RangeEqual(r[0], r[1]) {
+			return false
+		}
+	}
+	if !boolPointersEqual(a.IsHomeowner, b.IsHomeowner) {
+		return false
+	}
+	return boolPointersEqual(a.IncomeVerifiable, b.IncomeVerifiable)
+}
+
+func intSlicesEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
 	return true
 }
 
+func boolPointersEqual(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func rawSearchParamsEqual(a, b thin.SearchParams) bool {
 	if a.Offset != b.Offset {
 		return false
@@ -84,10 +174,19 @@ func rawSearchParamsEqual(a, b thin.SearchParams) bool {
 	if a.ExcludeListingsInvested != b.ExcludeListingsInvested {
 		return false
 	}
+	if len(a.SortBy) != len(b.SortBy) {
+		return false
+	}
+	for i := range a.SortBy {
+		if a.SortBy[i] != b.SortBy[i] {
+			return false
+		}
+	}
 	return rawSearchFilterEqual(a.Filter, b.Filter)
 }
 
 func TestSearch(t *testing.T) {
+	isHomeowner := true
 	var tests = []struct {
 		searchParams        SearchParams
 		wantRawSearchParams thin.SearchParams
@@ -121,8 +220,8 @@ func TestSearch(t *testing.T) {
 		},
 		{
 			searchParams: SearchParams{
-				Offset: 25,
-				Limit:  50,
+				Offset:                  25,
+				Limit:                   50,
 				ExcludeListingsInvested: true,
 				Filter: SearchFilter{
 					EstimatedReturn:      interval.NewFloat64Range(0.0, 0.2),
@@ -134,8 +233,8 @@ func TestSearch(t *testing.T) {
 				},
 			},
 			wantRawSearchParams: thin.SearchParams{
-				Offset: 25,
-				Limit:  50,
+				Offset:                  25,
+				Limit:                   50,
 				ExcludeListingsInvested: true,
 				Filter: thin.SearchFilter{
 					EstimatedReturn:      interval.NewFloat64Range(0.0, 0.2),
@@ -160,6 +259,84 @@ func TestSearch(t *testing.T) {
 			},
 			msg: "parsing a single result from search parameters should succeed",
 		},
+		{
+			searchParams: SearchParams{
+				Limit: 25,
+				Filter: SearchFilter{
+					ListingNumber:               []ListingNumber{4247229, 4245951},
+					ListingTerm:                 []int64{36, 60},
+					ListingAmount:               interval.NewFloat64Range(2000.0, 15000.0),
+					AmountRemaining:             interval.Float64Range{Min: interval.CreateFloat64(25.0)},
+					PercentFunded:               interval.Float64Range{Max: interval.CreateFloat64(0.75)},
+					FicoScore:                   []FicoScore{Between720And739, Below600},
+					ProsperScore:                interval.NewInt32Range(6, 11),
+					BorrowerState:               []string{"CA", "NY"},
+					EmploymentStatusDescription: []string{"Employed"},
+					MonthsEmployed:              interval.Int32Range{Min: interval.CreateInt32(24)},
+					IsHomeowner:                 &isHomeowner,
+					ListingCategoryID:           []ListingCategory{CategoryDebtConsolidation, CategoryOther},
+				},
+			},
+			wantRawSearchParams: thin.SearchParams{
+				Limit: 25,
+				Filter: thin.SearchFilter{
+					ListingNumber:               []int64{4247229, 4245951},
+					ListingTerm:                 []int{36, 60},
+					ListingAmount:               interval.NewFloat64Range(2000.0, 15000.0),
+					AmountRemaining:             interval.Float64Range{Min: interval.CreateFloat64(25.0)},
+					PercentFunded:               interval.Float64Range{Max: interval.CreateFloat64(0.75)},
+					FicoScore:                   []string{"720-739", "<600"},
+					ProsperScore:                interval.NewInt32Range(6, 11),
+					BorrowerState:               []string{"CA", "NY"},
+					EmploymentStatusDescription: []string{"Employed"},
+					MonthsEmployed:              interval.Int32Range{Min: interval.CreateInt32(24)},
+					IsHomeowner:                 &isHomeowner,
+					ListingCategoryID:           []int{1, 7},
+				},
+			},
+			rawSearchResponse: thin.SearchResponse{
+				Results:     []thin.SearchResult{rawListingA},
+				ResultCount: 1,
+				TotalCount:  1,
+			},
+			parsedListings: []Listing{listingA},
+			parseErrors:    []error{nil},
+			want: SearchResponse{
+				Results:     []Listing{listingA},
+				ResultCount: 1,
+				TotalCount:  1,
+			},
+			msg: "extended search filters should convert to raw search parameters",
+		},
+		{
+			searchParams: SearchParams{
+				Limit: 25,
+				SortBy: []SearchSort{
+					{Field: SortByLenderYield, Direction: SortDescending},
+					{Field: SortByListingStartDate, Direction: SortAscending},
+				},
+			},
+			wantRawSearchParams: thin.SearchParams{
+				Limit: 25,
+				SortBy: []thin.SortKey{
+					{Field: "lender_yield", Descending: true},
+					{Field: "listing_start_date", Descending: false},
+				},
+			},
+			rawSearchResponse: thin.SearchResponse{
+				Results:     []thin.SearchResult{rawListingA},
+				ResultCount: 1,
+				TotalCount:  1,
+			},
+			parsedListings: []Listing{listingA},
+			parseErrors:    []error{nil},
+			want: SearchResponse{
+				Results:     []Listing{listingA},
+				ResultCount: 1,
+				TotalCount:  1,
+			},
+			msg: "multi-key sort order should convert to raw search parameters",
+		},
 		{
 			rawSearchResponse: thin.SearchResponse{
 				Results:     []thin.SearchResult{rawListingA, rawListingB},
@@ -197,6 +374,45 @@ func TestSearch(t *testing.T) {
 			wantErr:        errMockListingParseFail,
 			msg:            "a single parser error among successful parses should fail",
 		},
+		{
+			searchParams: SearchParams{
+				ParseMode: ParseLenient,
+			},
+			rawSearchResponse: thin.SearchResponse{
+				Results:     []thin.SearchResult{rawListingA, rawListingC, rawListingB},
+				ResultCount: 3,
+				TotalCount:  3,
+			},
+			parsedListings: []Listing{listingA, {}, listingB},
+			parseErrors:    []error{nil, errMockListingParseFail, nil},
+			want: SearchResponse{
+				Results:     []Listing{listingA, listingB},
+				ResultCount: 3,
+				TotalCount:  3,
+				ParseErrors: []*ListingParseError{
+					{ListingNumber: 8910, Err: errMockListingParseFail},
+				},
+			},
+			msg: "lenient parsing should return successful parses and report failures",
+		},
+		{
+			searchParams: SearchParams{
+				ParseMode: ParseLenient,
+			},
+			rawSearchResponse: thin.SearchResponse{
+				Results:     []thin.SearchResult{rawListingC},
+				ResultCount: 1,
+				TotalCount:  1,
+			},
+			parsedListings: []Listing{{}},
+			parseErrors:    []error{mockFieldParseError},
+			want: SearchResponse{
+				ResultCount: 1,
+				TotalCount:  1,
+				ParseErrors: []*ListingParseError{mockFieldParseError},
+			},
+			msg: "lenient parsing should preserve field-level parse errors",
+		},
 	}
 	for _, tt := range tests {
 		rawClient := mockRawClient{
@@ -226,3 +442,77 @@ func TestSearch(t *testing.T) {
 		}
 	}
 }
+
+func TestSearchFilterJSONRoundTrip(t *testing.T) {
+	isHomeowner := true
+	f := SearchFilter{
+		EstimatedReturn: interval.Float64Range{
+			Min:          interval.CreateFloat64(0.05),
+			Max:          interval.CreateFloat64(0.12),
+			MaxExclusive: true,
+		},
+		IncomeRange:       []IncomeRange{Between50kAnd75k, Over100k},
+		Rating:            []Rating{RatingAA, RatingA},
+		ProsperScore:      interval.Int32Range{Min: interval.CreateInt32(7)},
+		ListingStartDate:  interval.TimeRange{Max: interval.CreateTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))},
+		FicoScore:         []FicoScore{Between740And759},
+		IsHomeowner:       &isHomeowner,
+		ListingCategoryID: []ListingCategory{CategoryDebtConsolidation},
+	}
+	encoded, err := json.Marshal(f)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	want := `{"estimated_return":"[0.05,0.12)","income_range":["$50,000-74,999","$100,000+"],` +
+		`"prosper_rating":["AA","A"],"listing_start_date":"(,2020-01-01T00:00:00Z]","fico_score":["740-759"],` +
+		`"prosper_score":"[7,)","is_homeowner":true,"listing_category_id":["Debt Consolidation"]}`
+	if string(encoded) != want {
+		t.Errorf("json.Marshal = %s, want %s", encoded, want)
+	}
+	var decoded SearchFilter
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	reencoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	if string(reencoded) != string(encoded) {
+		t.Errorf("SearchFilter did not round-trip: got %s, want %s", reencoded, encoded)
+	}
+}
+
+func TestSearchRejectsInvalidParams(t *testing.T) {
+	var tests = []struct {
+		params SearchParams
+		msg    string
+	}{
+		{
+			params: SearchParams{
+				Filter: SearchFilter{Rating: []Rating{Rating(42)}},
+			},
+			msg: "search with an invalid filter value should fail",
+		},
+		{
+			params: SearchParams{Limit: MaxSearchLimit + 1},
+			msg:    "search with a limit above MaxSearchLimit should fail",
+		},
+		{
+			params: SearchParams{
+				Filter: SearchFilter{ProsperScore: interval.NewInt32Range(0, 11)},
+			},
+			msg: "search with a prosper_score bound below ProsperScoreMin should fail",
+		},
+	}
+	for _, tt := range tests {
+		rawClient := &mockRawClient{}
+		client := defaultClient{rawClient: rawClient}
+		_, err := client.Search(tt.params)
+		if _, ok := err.(*SearchValidationError); !ok {
+			t.Errorf("%s: expected *SearchValidationError, got %T: %v", tt.msg, err, err)
+		}
+		if !reflect.DeepEqual(rawClient.searchParams, thin.SearchParams{}) {
+			t.Errorf("%s: client.Search should not call the raw client with invalid params", tt.msg)
+		}
+	}
+}
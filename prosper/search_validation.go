@@ -0,0 +1,139 @@
+package prosper
+
+import (
+	"encoding"
+	"fmt"
+	"strings"
+)
+
+// MaxSearchLimit is the largest number of listings Prosper returns for a single
+// Search request.
+const MaxSearchLimit = 500
+
+var sortFields = map[SortField]bool{
+	SortByAmountRemaining:   true,
+	SortByBorrowerRate:      true,
+	SortByEffectiveYield:    true,
+	SortByEstimatedLossRate: true,
+	SortByEstimatedReturn:   true,
+	SortByLenderYield:       true,
+	SortByListingAmount:     true,
+	SortByListingEndDate:    true,
+	SortByListingNumber:     true,
+	SortByListingStartDate:  true,
+	SortByPercentFunded:     true,
+	SortByProsperRating:     true,
+	SortByProsperScore:      true,
+}
+
+// SearchValidationError lists the problems that make search parameters
+// invalid.
+type SearchValidationError struct {
+	Errors []error
+}
+
+func (e *SearchValidationError) Error() string {
+	var msgs []string
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return "invalid search parameters: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the individual validation errors.
+func (e *SearchValidationError) Unwrap() []error {
+	return e.Errors
+}
+
+func newSearchValidationError(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &SearchValidationError{Errors: errs}
+}
+
+// Validate returns a *SearchValidationError if p has a negative offset, a
+// limit above MaxSearchLimit, an unrecognized sort, or an invalid filter.
+func (p SearchParams) Validate() error {
+	return newSearchValidationError(p.validationErrors())
+}
+
+func (p SearchParams) validationErrors() []error {
+	var errs []error
+	if p.Offset < 0 {
+		errs = append(errs, fmt.Errorf("offset must not be negative: %d", p.Offset))
+	}
+	if p.Limit < 0 || p.Limit > MaxSearchLimit {
+		errs = append(errs, fmt.Errorf("limit must be between 0 and %d: %d", MaxSearchLimit, p.Limit))
+	}
+	for _, s := range p.SortBy {
+		if !sortFields[s.Field] {
+			errs = append(errs, fmt.Errorf("unrecognized sort field: %q", s.Field))
+		}
+		if s.Direction != SortAscending && s.Direction != SortDescending {
+			errs = append(errs, fmt.Errorf("unrecognized sort direction: %d", s.Direction))
+		}
+	}
+	return append(errs, p.Filter.validationErrors()...)
+}
+
+// Validate returns a *SearchValidationError if the filter contains invalid or
+// Unknown enum values, a prosper_score bound outside ProsperScoreMin to
+// ProsperScoreMax, or a range that is empty, such as one whose minimum is
+// greater than its maximum, or that has an exclusive bound, which Prosper does
+// not support.
+func (f SearchFilter) Validate() error {
+	return newSearchValidationError(f.validationErrors())
+}
+
+func (f SearchFilter) validationErrors() []error {
+	var errs []error
+	errs = append(errs, enumErrors("income_range", f.IncomeRange)...)
+	errs = append(errs, enumErrors("prosper_rating", f.Rating)...)
+	errs = append(errs, enumErrors("listing_status", f.ListingStatus)...)
+	errs = append(errs, enumErrors("fico_score", f.FicoScore)...)
+	errs = append(errs, enumErrors("listing_category_id", f.ListingCategoryID)...)
+	for _, n := range f.ListingNumber {
+		if n <= 0 {
+			errs = append(errs, fmt.Errorf("invalid listing_number value: %d", n))
+		}
+	}
+	for _, term := range f.ListingTerm {
+		if term <= 0 {
+			errs = append(errs, fmt.Errorf("invalid listing_term value: %d", term))
+		}
+	}
+	for _, bound := range []*int32{f.ProsperScore.Min, f.ProsperScore.Max} {
+		if bound != nil && (*bound < int32(ProsperScoreMin) || *bound > int32(ProsperScoreMax)) {
+			errs = append(errs, fmt.Errorf("prosper_score bound must be between %d and %d: %d", ProsperScoreMin, ProsperScoreMax, *bound))
+		}
+	}
+	if err := searchFilterToThinType(f).Validate(); err != nil {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			errs = append(errs, joined.Unwrap()...)
+		} else {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// filterEnum is the set of enum types that can appear in a SearchFilter.
+type filterEnum interface {
+	~int8
+	encoding.TextMarshaler
+}
+
+// enumErrors returns an error for each value that is not a valid value of its
+// enum type, is the unset zero value of a type that has no zero variant, or is
+// the type's Unknown variant, none of which Prosper accepts in a filter.
+func enumErrors[E filterEnum](field string, values []E) []error {
+	var errs []error
+	for _, v := range values {
+		text, err := v.MarshalText()
+		if err != nil || len(text) == 0 || string(text) == unknownEnumText {
+			errs = append(errs, fmt.Errorf("invalid %s value: %d", field, int8(v)))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,492 @@
+package strategy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/mtlynch/gofn-prosper/prosper"
+)
+
+type (
+	// Error is a problem at a position in a strategy file.
+	Error struct {
+		// File is the name of the file, if the strategies were loaded from one.
+		File   string
+		Line   int
+		Column int
+		Err    error
+	}
+
+	// ErrorList is the list of problems found while loading a strategy file.
+	ErrorList []*Error
+)
+
+func (e *Error) Error() string {
+	if e.File != "" {
+		return fmt.Sprintf("%s:%d:%d: %v", e.File, e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (l ErrorList) Error() string {
+	var msgs []string
+	for _, e := range l {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the individual errors.
+func (l ErrorList) Unwrap() []error {
+	var errs []error
+	for _, e := range l {
+		errs = append(errs, e)
+	}
+	return errs
+}
+
+// LoadFile reads and parses the strategy file at path.
+func LoadFile(path string) ([]*Strategy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	strategies, err := Parse(data)
+	var errs ErrorList
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			e.File = path
+		}
+	}
+	return strategies, err
+}
+
+// Load reads and parses a strategy file from r.
+func Load(r io.Reader) ([]*Strategy, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses and validates a strategy file. If the file is invalid, it
+// returns an ErrorList with the position of each problem.
+//
+// A strategy file is a JSON object whose "strategies" attribute lists one or
+// more strategies:
+//
+//	{
+//	  "strategies": [
+//	    {
+//	      "name": "steady-earners",
+//	      "search": {
+//	        "prosper_rating": ["A", "B"],
+//	        "estimated_return": "[0.05,)"
+//	      },
+//	      "exclude_invested": true,
+//	      "require": [
+//	        {"field": "bankcard_utilization", "<": 0.6},
+//	        {"ratio": ["revolving_balance", "stated_monthly_income"], "<=": 2},
+//	        {"not": {"field": "listing_title", "contains_any": ["vacation"]}}
+//	      ],
+//	      "bid": {"amount": 25, "by_rating": {"A": 50}, "max": 100},
+//	      "budget": {"total": 500, "max_bids": 10}
+//	    }
+//	  ]
+//	}
+//
+// The "search" object uses the attribute names and value formats of
+// prosper.SearchFilter's JSON encoding. Each "require" entry is a predicate
+// that listings must satisfy, in one of these forms:
+//
+//	{"all": [predicates]}               all of the predicates hold
+//	{"any": [predicates]}               any of the predicates holds
+//	{"not": predicate}                  the predicate does not hold
+//	{"field": name, op: number, ...}    compares a numeric field, where op is
+//	                                    one of <, <=, >, >=, ==, !=
+//	{"ratio": [name, name], op: number} compares the ratio of two numeric fields
+//	{"field": name, "in": [values]}     the field is one of the values
+//	{"field": name, "is": bool}         compares a boolean field
+//	{"field": name, "contains_any": [strings]}
+//	                                    a text field contains any of the
+//	                                    strings, ignoring case
+//	{"field": name, "matches": regexp}  a text field matches the regexp
+//
+// Field names are the Name values of the prosper package's Field variables.
+// The "bid" object sets the attributes of BidRule and the "budget" object sets
+// the attributes of Budget, using the JSON names shown above along with
+// "percent_of_listing", "min", and "max". Money amounts are in dollars.
+func Parse(data []byte) ([]*Strategy, error) {
+	d := &decoder{data: data}
+	root, err := parseNode(data)
+	if err != nil {
+		var syntaxErr *json.SyntaxError
+		var offsetErr *offsetError
+		offset := len(data)
+		switch {
+		case errors.As(err, &offsetErr):
+			offset, err = offsetErr.offset, offsetErr.err
+		case errors.As(err, &syntaxErr):
+			// Offset counts the invalid byte itself.
+			offset = max(int(syntaxErr.Offset)-1, 0)
+		case errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF):
+			err = errors.New("unexpected end of file")
+		}
+		d.errorAt(offset, err)
+		return nil, d.errs
+	}
+	strategies := d.file(root)
+	if len(d.errs) > 0 {
+		return nil, d.errs
+	}
+	return strategies, nil
+}
+
+type decoder struct {
+	data []byte
+	errs ErrorList
+}
+
+func (d *decoder) errorAt(offset int, err error) {
+	line, column := 1, 1
+	for _, c := range d.data[:min(offset, len(d.data))] {
+		if c == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	d.errs = append(d.errs, &Error{Line: line, Column: column, Err: err})
+}
+
+func (d *decoder) errorf(n *node, format string, args ...any) {
+	d.errorAt(n.start, fmt.Errorf(format, args...))
+}
+
+// decode unmarshals the JSON value of n into v.
+func (d *decoder) decode(n *node, v any) bool {
+	if err := json.Unmarshal(d.data[n.start:n.end], v); err != nil {
+		d.errorAt(n.start, err)
+		return false
+	}
+	return true
+}
+
+// fields checks that n is an object whose attributes are all in allowed and
+// returns the attributes by name.
+func (d *decoder) fields(n *node, what string, allowed ...string) (map[string]*node, bool) {
+	if n.kind != objectNode {
+		d.errorf(n, "%s must be an object", what)
+		return nil, false
+	}
+	fields := map[string]*node{}
+	for _, m := range n.members {
+		if !contains(allowed, m.key) {
+			d.errorAt(m.keyStart, fmt.Errorf("unknown %s attribute %q", what, m.key))
+			continue
+		}
+		fields[m.key] = m.value
+	}
+	return fields, true
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *decoder) file(n *node) []*Strategy {
+	fields, ok := d.fields(n, "strategy file", "strategies")
+	if !ok {
+		return nil
+	}
+	list, ok := fields["strategies"]
+	if !ok {
+		d.errorf(n, "strategy file must have a strategies attribute")
+		return nil
+	}
+	if list.kind != arrayNode || len(list.elems) == 0 {
+		d.errorf(list, "strategies must be a non-empty array")
+		return nil
+	}
+	var strategies []*Strategy
+	names := map[string]bool{}
+	for _, elem := range list.elems {
+		s := d.strategy(elem)
+		if s == nil {
+			continue
+		}
+		if names[s.Name] {
+			d.errorf(elem, "duplicate strategy name %q", s.Name)
+		}
+		names[s.Name] = true
+		strategies = append(strategies, s)
+	}
+	return strategies
+}
+
+func (d *decoder) strategy(n *node) *Strategy {
+	fields, ok := d.fields(n, "strategy", "name", "search", "exclude_invested", "require", "bid", "budget")
+	if !ok {
+		return nil
+	}
+	s := &Strategy{}
+	errCount := len(d.errs)
+	if name, ok := fields["name"]; ok {
+		if d.decode(name, &s.Name) && s.Name == "" {
+			d.errorf(name, "strategy name must not be empty")
+		}
+	} else {
+		d.errorf(n, "strategy must have a name")
+	}
+	if exclude, ok := fields["exclude_invested"]; ok {
+		d.decode(exclude, &s.ExcludeInvested)
+	}
+	search, hasSearch := fields["search"]
+	if hasSearch {
+		s.Search = d.searchFilter(search)
+	}
+	require, hasRequire := fields["require"]
+	if hasRequire {
+		s.Require = d.require(require)
+	} else {
+		s.Require = prosper.And()
+	}
+	if bid, ok := fields["bid"]; ok {
+		s.Bid = d.bidRule(bid)
+	} else {
+		d.errorf(n, "strategy must have a bid")
+	}
+	if budget, ok := fields["budget"]; ok {
+		s.Budget = d.budget(budget)
+	} else {
+		d.errorf(n, "strategy must have a budget")
+	}
+	if len(d.errs) == errCount {
+		if _, err := s.SearchFilter(); err != nil {
+			switch {
+			case hasRequire:
+				d.errorAt(require.start, err)
+			case hasSearch:
+				d.errorAt(search.start, err)
+			default:
+				d.errorAt(n.start, err)
+			}
+		}
+	}
+	return s
+}
+
+// searchFilterFields maps the JSON attribute names of prosper.SearchFilter to
+// field indexes.
+var searchFilterFields = func() map[string]int {
+	fields := map[string]int{}
+	t := reflect.TypeOf(prosper.SearchFilter{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		fields[name] = i
+	}
+	return fields
+}()
+
+// searchFilter decodes each attribute of n separately so that errors point at
+// the attribute that caused them.
+func (d *decoder) searchFilter(n *node) prosper.SearchFilter {
+	var f prosper.SearchFilter
+	if n.kind != objectNode {
+		d.errorf(n, "search must be an object")
+		return f
+	}
+	v := reflect.ValueOf(&f).Elem()
+	for _, m := range n.members {
+		i, ok := searchFilterFields[m.key]
+		if !ok {
+			d.errorAt(m.keyStart, fmt.Errorf("unknown search attribute %q", m.key))
+			continue
+		}
+		d.decode(m.value, v.Field(i).Addr().Interface())
+	}
+	return f
+}
+
+func (d *decoder) bidRule(n *node) BidRule {
+	var r BidRule
+	fields, ok := d.fields(n, "bid", "amount", "percent_of_listing", "by_rating", "min", "max")
+	if !ok {
+		return r
+	}
+	errCount := len(d.errs)
+	if v, ok := fields["amount"]; ok {
+		d.decode(v, &r.Amount)
+	}
+	if v, ok := fields["percent_of_listing"]; ok {
+		d.decode(v, &r.PercentOfListing)
+	}
+	if v, ok := fields["by_rating"]; ok {
+		d.decode(v, &r.ByRating)
+	}
+	if v, ok := fields["min"]; ok {
+		d.decode(v, &r.Min)
+	}
+	if v, ok := fields["max"]; ok {
+		d.decode(v, &r.Max)
+	}
+	if len(d.errs) == errCount {
+		if err := r.Validate(); err != nil {
+			d.errorAt(n.start, err)
+		}
+	}
+	return r
+}
+
+func (d *decoder) budget(n *node) Budget {
+	var b Budget
+	fields, ok := d.fields(n, "budget", "total", "max_bids")
+	if !ok {
+		return b
+	}
+	errCount := len(d.errs)
+	if v, ok := fields["total"]; ok {
+		d.decode(v, &b.Total)
+	}
+	if v, ok := fields["max_bids"]; ok {
+		d.decode(v, &b.MaxBids)
+	}
+	if len(d.errs) == errCount {
+		if err := b.Validate(); err != nil {
+			d.errorAt(n.start, err)
+		}
+	}
+	return b
+}
+
+type nodeKind int8
+
+const (
+	objectNode nodeKind = iota
+	arrayNode
+	scalarNode
+)
+
+type (
+	// node is a JSON value along with its position in the input, which the
+	// decoder uses to report errors by line.
+	node struct {
+		kind       nodeKind
+		start, end int
+		members    []member
+		elems      []*node
+	}
+
+	member struct {
+		key      string
+		keyStart int
+		value    *node
+	}
+)
+
+// parseNode parses data, which must hold a single JSON value, into a tree of
+// nodes.
+func parseNode(data []byte) (*node, error) {
+	p := &nodeParser{data: data, dec: json.NewDecoder(bytes.NewReader(data))}
+	p.dec.UseNumber()
+	n, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	end := p.next()
+	if _, err := p.dec.Token(); err != io.EOF {
+		return nil, &offsetError{offset: end, err: errors.New("unexpected data after strategy file object")}
+	}
+	return n, nil
+}
+
+// offsetError is a parse error at a byte offset in the input.
+type offsetError struct {
+	offset int
+	err    error
+}
+
+func (e *offsetError) Error() string {
+	return e.err.Error()
+}
+
+type nodeParser struct {
+	data []byte
+	dec  *json.Decoder
+}
+
+// next returns the offset of the next token, skipping the whitespace and
+// separators that json.Decoder consumes along with a token.
+func (p *nodeParser) next() int {
+	i := int(p.dec.InputOffset())
+	for i < len(p.data) && strings.IndexByte(" \t\r\n,:", p.data[i]) >= 0 {
+		i++
+	}
+	return i
+}
+
+func (p *nodeParser) value() (*node, error) {
+	n := &node{start: p.next()}
+	tok, err := p.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		n.kind = objectNode
+		seen := map[string]bool{}
+		for p.dec.More() {
+			keyStart := p.next()
+			key, err := p.dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			if seen[key.(string)] {
+				return nil, &offsetError{offset: keyStart, err: fmt.Errorf("duplicate attribute %q", key)}
+			}
+			seen[key.(string)] = true
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			n.members = append(n.members, member{key: key.(string), keyStart: keyStart, value: value})
+		}
+		if _, err := p.dec.Token(); err != nil {
+			return nil, err
+		}
+	case json.Delim('['):
+		n.kind = arrayNode
+		for p.dec.More() {
+			elem, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			n.elems = append(n.elems, elem)
+		}
+		if _, err := p.dec.Token(); err != nil {
+			return nil, err
+		}
+	default:
+		n.kind = scalarNode
+	}
+	n.end = int(p.dec.InputOffset())
+	return n, nil
+}
@@ -0,0 +1,266 @@
+package strategy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mtlynch/gofn-prosper/interval"
+	"github.com/mtlynch/gofn-prosper/prosper"
+)
+
+const validStrategyFile = `{
+  "strategies": [
+    {
+      "name": "steady-earners",
+      "search": {
+        "prosper_rating": ["A", "B"],
+        "estimated_return": "[0.05,)"
+      },
+      "exclude_invested": true,
+      "require": [
+        {"field": "bankcard_utilization", "<": 0.6},
+        {"field": "months_employed", ">": 24, "<=": 120},
+        {"ratio": ["revolving_balance", "stated_monthly_income"], "<=": 2},
+        {"field": "income_range", "in": ["$50,000-74,999", "$75,000-99,999"]},
+        {"field": "is_homeowner", "is": true},
+        {"any": [
+          {"field": "occupation", "contains_any": ["nurse", "teacher"]},
+          {"field": "listing_title", "matches": "(?i)^consolidat"}
+        ]},
+        {"not": {"field": "listing_title", "contains_any": ["vacation"]}}
+      ],
+      "bid": {"amount": 25, "by_rating": {"A": 50}, "max": 100},
+      "budget": {"total": "500.00", "max_bids": 10}
+    },
+    {
+      "name": "small-slices",
+      "bid": {"percent_of_listing": 0.5},
+      "budget": {"total": 100}
+    }
+  ]
+}`
+
+func TestParse(t *testing.T) {
+	strategies, err := Parse([]byte(validStrategyFile))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(strategies) != 2 {
+		t.Fatalf("unexpected strategy count. got: %d, want: 2", len(strategies))
+	}
+
+	s := strategies[0]
+	if s.Name != "steady-earners" {
+		t.Errorf("unexpected name. got: %s, want: steady-earners", s.Name)
+	}
+	if !s.ExcludeInvested {
+		t.Errorf("expected ExcludeInvested to be set")
+	}
+	wantBid := BidRule{
+		Amount:   25 * prosper.Dollar,
+		ByRating: map[prosper.Rating]prosper.Money{prosper.RatingA: 50 * prosper.Dollar},
+		Max:      100 * prosper.Dollar,
+	}
+	if !reflect.DeepEqual(s.Bid, wantBid) {
+		t.Errorf("unexpected bid rule. got: %+v, want: %+v", s.Bid, wantBid)
+	}
+	wantBudget := Budget{Total: 500 * prosper.Dollar, MaxBids: 10}
+	if s.Budget != wantBudget {
+		t.Errorf("unexpected budget. got: %+v, want: %+v", s.Budget, wantBudget)
+	}
+	wantRequire := `(bankcard_utilization < 0.6 AND (months_employed > 24 AND months_employed <= 120) AND ` +
+		`revolving_balance/stated_monthly_income <= 2 AND income_range IN {$50,000-74,999, $75,000-99,999} AND ` +
+		`is_homeowner IS true AND (occupation CONTAINS ANY ["nurse" "teacher"] OR listing_title MATCHES /(?i)^consolidat/) AND ` +
+		`NOT listing_title CONTAINS ANY ["vacation"])`
+	if got := s.Require.String(); got != wantRequire {
+		t.Errorf("unexpected require. got: %s, want: %s", got, wantRequire)
+	}
+
+	homeowner := true
+	wantFilter := prosper.SearchFilter{
+		Rating:          []prosper.Rating{prosper.RatingA, prosper.RatingB},
+		EstimatedReturn: interval.Float64Range{Min: interval.CreateFloat64(0.05)},
+		MonthsEmployed:  interval.NewInt32Range(25, 120),
+		IncomeRange:     []prosper.IncomeRange{prosper.Between50kAnd75k, prosper.Between75kAnd100k},
+		IsHomeowner:     &homeowner,
+	}
+	gotFilter, err := s.SearchFilter()
+	if err != nil {
+		t.Fatalf("unexpected error from SearchFilter: %v", err)
+	}
+	if !reflect.DeepEqual(gotFilter, wantFilter) {
+		t.Errorf("unexpected search filter. got: %+v, want: %+v", gotFilter, wantFilter)
+	}
+
+	if got := strategies[1].Require.String(); got != "TRUE" {
+		t.Errorf("strategy without require should accept all listings, got: %s", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	var tests = []struct {
+		file string
+		want []string
+		msg  string
+	}{
+		{
+			file: "{\n  \"strategies\": [\n    {\"name\": \"x\",}\n  ]\n}",
+			want: []string{"line 3, column 17: invalid character ',' looking for beginning of value"},
+			msg:  "syntax error should report its position",
+		},
+		{
+			file: "{\"strategies\": [",
+			want: []string{"line 1, column 16: unexpected end of JSON input"},
+			msg:  "truncated file should report the end of the file",
+		},
+		{
+			file: "{\"strategies\": []} {}",
+			want: []string{"line 1, column 20: unexpected data after strategy file object"},
+			msg:  "trailing data should fail",
+		},
+		{
+			file: "{\"strategies\": [\n  {\"name\": \"x\", \"name\": \"y\"}\n]}",
+			want: []string{`line 2, column 17: duplicate attribute "name"`},
+			msg:  "duplicate attributes should fail",
+		},
+		{
+			file: `{"strategies": []}`,
+			want: []string{"line 1, column 16: strategies must be a non-empty array"},
+			msg:  "empty strategy list should fail",
+		},
+		{
+			file: `{
+  "strategies": [
+    {
+      "name": "x",
+      "search": {
+        "prosper_rating": ["A", "Z"],
+        "color": "blue"
+      },
+      "bid": {"amount": 25},
+      "budget": {"total": 100}
+    }
+  ]
+}`,
+			want: []string{
+				"line 6, column 27: unrecognized Prosper rating value: Z",
+				`line 7, column 9: unknown search attribute "color"`,
+			},
+			msg: "search errors should point at the offending attribute",
+		},
+		{
+			file: `{
+  "strategies": [
+    {
+      "name": "x",
+      "require": [
+        {"field": "bankcard_utilization", "<": "low"},
+        {"field": "shoe_size", ">": 9},
+        {"field": "prosper_rating", "in": ["A"], "is": true},
+        {"field": "occupation", "matches": "("},
+        {"field": "is_homeowner", "between": [1, 2]},
+        {"ratio": ["revolving_balance"], "<": 1},
+        {"any": {}},
+        {"value": 1}
+      ],
+      "bid": {"amount": 25},
+      "budget": {"total": 100}
+    }
+  ]
+}`,
+			want: []string{
+				"line 6, column 48: json: cannot unmarshal string into Go value of type float64",
+				`line 7, column 19: unknown numeric field "shoe_size"`,
+				`line 8, column 50: predicate on "prosper_rating" must have a single in test`,
+				"line 9, column 44: error parsing regexp: missing closing ): `(`",
+				`line 10, column 35: unknown predicate test "between"`,
+				"line 11, column 19: ratio must list exactly two numeric fields",
+				"line 12, column 17: any must be an array of predicates",
+				`line 13, column 9: predicate must have a "field" or "ratio" attribute, or be "all", "any" or "not"`,
+			},
+			msg: "predicate errors should point at the offending value",
+		},
+		{
+			file: `{
+  "strategies": [
+    {
+      "name": "x",
+      "bid": {"amount": 25, "percent_of_listing": 1, "min": 10},
+      "budget": {"total": 0}
+    },
+    {
+      "name": "x",
+      "require": [
+        {"field": "prosper_rating", "in": ["A"]},
+        {"field": "prosper_rating", "in": ["B"]}
+      ],
+      "bid": {"amount": 25}
+    }
+  ]
+}`,
+			want: []string{
+				"line 5, column 14: bid must not set both amount and percent_of_listing\nbid min must be at least 25.00: 10.00",
+				"line 6, column 17: budget total must be positive: 0.00",
+				"line 8, column 5: strategy must have a budget",
+				`line 8, column 5: duplicate strategy name "x"`,
+			},
+			msg: "invalid rules should report the position of their object",
+		},
+		{
+			file: `{
+  "strategies": [
+    {
+      "name": "x",
+      "require": [
+        {"field": "prosper_rating", "in": ["A"]},
+        {"field": "prosper_rating", "in": ["B"]}
+      ],
+      "bid": {"amount": 25},
+      "budget": {"total": 100}
+    }
+  ]
+}`,
+			want: []string{"line 5, column 18: no listing can satisfy prosper_rating IN {B} with filter [A]"},
+			msg:  "contradictory criteria should fail",
+		},
+	}
+	for _, tt := range tests {
+		_, err := Parse([]byte(tt.file))
+		var errs ErrorList
+		if !errors.As(err, &errs) {
+			t.Errorf("%s: expected ErrorList, got: %v", tt.msg, err)
+			continue
+		}
+		var got []string
+		for _, e := range errs {
+			got = append(got, e.Error())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: unexpected errors.\ngot:  %q\nwant: %q", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "strategies.json")
+	if err := os.WriteFile(path, []byte("{\n  \"strategies\": 3\n}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := LoadFile(path)
+	want := path + ":2:17: strategies must be a non-empty array"
+	if err == nil || err.Error() != want {
+		t.Errorf("unexpected error. got: %v, want: %s", err, want)
+	}
+
+	strategies, err := Load(strings.NewReader(validStrategyFile))
+	if err != nil {
+		t.Fatalf("unexpected error from Load: %v", err)
+	}
+	if len(strategies) != 2 {
+		t.Errorf("unexpected strategy count. got: %d, want: 2", len(strategies))
+	}
+}
@@ -0,0 +1,236 @@
+package strategy
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/mtlynch/gofn-prosper/prosper"
+)
+
+var operators = map[string]prosper.Operator{
+	"<":  prosper.LessThan,
+	"<=": prosper.AtMost,
+	">":  prosper.GreaterThan,
+	">=": prosper.AtLeast,
+	"==": prosper.EqualTo,
+	"!=": prosper.NotEqualTo,
+}
+
+// setPredicates builds "in" predicates for each listing field with a discrete
+// set of values, keyed by field name.
+var setPredicates = map[string]func(*decoder, *node) prosper.Predicate{
+	prosper.FieldBorrowerState.Name:               setPredicate(prosper.FieldBorrowerState),
+	prosper.FieldEmploymentStatusDescription.Name: setPredicate(prosper.FieldEmploymentStatusDescription),
+	prosper.FieldFicoScore.Name:                   setPredicate(prosper.FieldFicoScore),
+	prosper.FieldIncomeRange.Name:                 setPredicate(prosper.FieldIncomeRange),
+	prosper.FieldInvestmentTypeID.Name:            setPredicate(prosper.FieldInvestmentTypeID),
+	prosper.FieldLenderIndicator.Name:             setPredicate(prosper.FieldLenderIndicator),
+	prosper.FieldListingCategoryID.Name:           setPredicate(prosper.FieldListingCategoryID),
+	prosper.FieldListingNumber.Name:               setPredicate(prosper.FieldListingNumber),
+	prosper.FieldListingStatus.Name:               setPredicate(prosper.FieldListingStatus),
+	prosper.FieldListingTerm.Name:                 setPredicate(prosper.FieldListingTerm),
+	prosper.FieldRating.Name:                      setPredicate(prosper.FieldRating),
+	prosper.FieldVerificationStage.Name:           setPredicate(prosper.FieldVerificationStage),
+}
+
+func setPredicate[T comparable](field prosper.SetField[T]) func(*decoder, *node) prosper.Predicate {
+	return func(d *decoder, n *node) prosper.Predicate {
+		var values []T
+		if !d.decode(n, &values) {
+			return nil
+		}
+		if len(values) == 0 {
+			d.errorf(n, "in must list at least one value")
+			return nil
+		}
+		return prosper.In(field, values...)
+	}
+}
+
+// require decodes the list of predicates that a strategy's listings must
+// satisfy.
+func (d *decoder) require(n *node) prosper.Predicate {
+	if n.kind != arrayNode {
+		d.errorf(n, "require must be an array of predicates")
+		return nil
+	}
+	return prosper.And(d.predicates(n.elems)...)
+}
+
+func (d *decoder) predicates(nodes []*node) []prosper.Predicate {
+	var ps []prosper.Predicate
+	for _, elem := range nodes {
+		if p := d.predicate(elem); p != nil {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+func (d *decoder) predicate(n *node) prosper.Predicate {
+	if n.kind != objectNode {
+		d.errorf(n, "predicate must be an object")
+		return nil
+	}
+	if len(n.members) == 1 {
+		m := n.members[0]
+		switch m.key {
+		case "all", "any":
+			if m.value.kind != arrayNode {
+				d.errorf(m.value, "%s must be an array of predicates", m.key)
+				return nil
+			}
+			if m.key == "any" {
+				return prosper.Or(d.predicates(m.value.elems)...)
+			}
+			return prosper.And(d.predicates(m.value.elems)...)
+		case "not":
+			if p := d.predicate(m.value); p != nil {
+				return prosper.Not(p)
+			}
+			return nil
+		}
+	}
+
+	var fieldNode, ratioNode *node
+	var tests []member
+	for _, m := range n.members {
+		switch m.key {
+		case "field":
+			fieldNode = m.value
+		case "ratio":
+			ratioNode = m.value
+		default:
+			tests = append(tests, m)
+		}
+	}
+	switch {
+	case fieldNode == nil && ratioNode == nil:
+		d.errorf(n, `predicate must have a "field" or "ratio" attribute, or be "all", "any" or "not"`)
+		return nil
+	case fieldNode != nil && ratioNode != nil:
+		d.errorf(n, `predicate must not have both "field" and "ratio" attributes`)
+		return nil
+	case len(tests) == 0:
+		d.errorf(n, "predicate must have a test such as <, in or contains_any")
+		return nil
+	}
+
+	if ratioNode != nil {
+		field, ok := d.ratio(ratioNode)
+		if !ok {
+			return nil
+		}
+		return d.comparisons(field, tests)
+	}
+	var name string
+	if !d.decode(fieldNode, &name) {
+		return nil
+	}
+	if _, ok := operators[tests[0].key]; ok {
+		field, ok := prosper.LookupNumericField(name)
+		if !ok {
+			d.errorf(fieldNode, "unknown numeric field %q", name)
+			return nil
+		}
+		return d.comparisons(field, tests)
+	}
+	if len(tests) > 1 {
+		d.errorAt(tests[1].keyStart, fmt.Errorf("predicate on %q must have a single %s test", name, tests[0].key))
+		return nil
+	}
+	test := tests[0]
+	switch test.key {
+	case "in":
+		build, ok := setPredicates[name]
+		if !ok {
+			d.errorf(fieldNode, "unknown set field %q", name)
+			return nil
+		}
+		return build(d, test.value)
+	case "is":
+		field, ok := prosper.LookupBoolField(name)
+		if !ok {
+			d.errorf(fieldNode, "unknown boolean field %q", name)
+			return nil
+		}
+		var want bool
+		if !d.decode(test.value, &want) {
+			return nil
+		}
+		return prosper.Is(field, want)
+	case "contains_any", "matches":
+		field, ok := prosper.LookupStringField(name)
+		if !ok {
+			d.errorf(fieldNode, "unknown text field %q", name)
+			return nil
+		}
+		if test.key == "matches" {
+			var pattern string
+			if !d.decode(test.value, &pattern) {
+				return nil
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				d.errorAt(test.value.start, err)
+				return nil
+			}
+			return prosper.Matches(field, re)
+		}
+		var keywords []string
+		if !d.decode(test.value, &keywords) {
+			return nil
+		}
+		if len(keywords) == 0 {
+			d.errorf(test.value, "contains_any must list at least one keyword")
+			return nil
+		}
+		return prosper.ContainsAny(field, keywords...)
+	}
+	d.errorAt(test.keyStart, fmt.Errorf("unknown predicate test %q", test.key))
+	return nil
+}
+
+// ratio decodes a pair of numeric field names into their ratio.
+func (d *decoder) ratio(n *node) (prosper.NumericField, bool) {
+	var names []string
+	if !d.decode(n, &names) {
+		return prosper.NumericField{}, false
+	}
+	if len(names) != 2 {
+		d.errorf(n, "ratio must list exactly two numeric fields")
+		return prosper.NumericField{}, false
+	}
+	var fields []prosper.NumericField
+	for _, name := range names {
+		field, ok := prosper.LookupNumericField(name)
+		if !ok {
+			d.errorf(n, "unknown numeric field %q", name)
+			return prosper.NumericField{}, false
+		}
+		fields = append(fields, field)
+	}
+	return prosper.Ratio(fields[0], fields[1]), true
+}
+
+// comparisons decodes numeric comparisons such as {">=": 0.05, "<": 0.1} on
+// field.
+func (d *decoder) comparisons(field prosper.NumericField, tests []member) prosper.Predicate {
+	var ps []prosper.Predicate
+	for _, test := range tests {
+		op, ok := operators[test.key]
+		if !ok {
+			d.errorAt(test.keyStart, fmt.Errorf("%q is not a numeric comparison on %q", test.key, field.Name))
+			return nil
+		}
+		var value float64
+		if !d.decode(test.value, &value) {
+			return nil
+		}
+		ps = append(ps, prosper.Compare(field, op, value))
+	}
+	if len(ps) == 1 {
+		return ps[0]
+	}
+	return prosper.And(ps...)
+}
@@ -0,0 +1,268 @@
+// Package strategy loads declarative investment strategies from JSON files and
+// evaluates them against Prosper listings to propose bids. A strategy combines
+// server-side search criteria, client-side listing predicates, bid sizing rules
+// and a budget, so that buying rules can be written without Go code. See Parse
+// for the file format.
+package strategy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mtlynch/gofn-prosper/prosper"
+)
+
+// MinimumBid is the smallest bid Prosper accepts on a listing.
+const MinimumBid = 25 * prosper.Dollar
+
+type (
+	// Strategy is a named set of buying rules.
+	Strategy struct {
+		Name string
+		// Search holds the criteria that Prosper evaluates on the server.
+		Search prosper.SearchFilter
+		// ExcludeInvested skips listings the account has already invested in.
+		ExcludeInvested bool
+		// Require holds the criteria evaluated on the client. Listings must
+		// satisfy both Search and Require.
+		Require prosper.Predicate
+		Bid     BidRule
+		Budget  Budget
+	}
+
+	// BidRule sizes the bid on an accepted listing. The bid is the amount in
+	// ByRating for the listing's rating if there is one, otherwise Amount if it
+	// is set, otherwise PercentOfListing percent of the listing amount. The
+	// bid is then capped at Max and at the listing's remaining amount, and
+	// rounded down to whole cents. Listings whose bid falls below Min are
+	// skipped.
+	BidRule struct {
+		Amount           prosper.Money
+		PercentOfListing float64
+		ByRating         map[prosper.Rating]prosper.Money
+		// Min defaults to MinimumBid.
+		Min prosper.Money
+		// Max of zero leaves bids uncapped.
+		Max prosper.Money
+	}
+
+	// Budget limits the bids a strategy proposes. Evaluate applies it to a
+	// single evaluation, and EvaluateRemaining to a series of evaluations.
+	Budget struct {
+		Total prosper.Money
+		// MaxBids of zero leaves the number of bids unlimited.
+		MaxBids int
+	}
+
+	// Rejection explains why a strategy proposed no bid on a listing.
+	Rejection struct {
+		ListingNumber prosper.ListingNumber
+		Reasons       []string
+	}
+
+	// Evaluation is the outcome of evaluating a strategy against a set of
+	// listings.
+	Evaluation struct {
+		Bids     []prosper.BidRequest
+		Rejected []Rejection
+		// Spent is the sum of the amounts of Bids.
+		Spent prosper.Money
+	}
+)
+
+// Validate returns an error if the strategy has no name, its bid rule or
+// budget is invalid, or its search criteria cannot match any listing.
+func (s *Strategy) Validate() error {
+	var errs []error
+	if s.Name == "" {
+		errs = append(errs, errors.New("strategy name must not be empty"))
+	}
+	if err := s.Bid.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := s.Budget.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := s.SearchFilter(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// SearchFilter returns the strategy's Search criteria, narrowed by the parts
+// of Require that Prosper can evaluate on the server.
+func (s *Strategy) SearchFilter() (prosper.SearchFilter, error) {
+	f := s.Search
+	if sf, ok := s.Require.(prosper.SearchFilterer); ok {
+		if err := sf.RestrictSearchFilter(&f); err != nil {
+			return prosper.SearchFilter{}, err
+		}
+	}
+	if err := f.Validate(); err != nil {
+		return prosper.SearchFilter{}, err
+	}
+	return f, nil
+}
+
+// SearchParams returns parameters that search for the listings the strategy
+// may bid on.
+func (s *Strategy) SearchParams() (prosper.SearchParams, error) {
+	f, err := s.SearchFilter()
+	if err != nil {
+		return prosper.SearchParams{}, err
+	}
+	return prosper.SearchParams{
+		Limit:                   prosper.MaxSearchLimit,
+		ExcludeListingsInvested: s.ExcludeInvested,
+		Filter:                  f,
+	}, nil
+}
+
+// Validate returns an error if the rule has no way to size a bid, or has
+// negative or inconsistent amounts.
+func (r BidRule) Validate() error {
+	var errs []error
+	if r.Amount == 0 && r.PercentOfListing == 0 && len(r.ByRating) == 0 {
+		errs = append(errs, errors.New("bid must set amount, percent_of_listing or by_rating"))
+	}
+	if r.Amount != 0 && r.PercentOfListing != 0 {
+		errs = append(errs, errors.New("bid must not set both amount and percent_of_listing"))
+	}
+	if r.Amount < 0 {
+		errs = append(errs, fmt.Errorf("bid amount must be positive: %s", r.Amount))
+	}
+	if r.PercentOfListing < 0 || r.PercentOfListing > 100 {
+		errs = append(errs, fmt.Errorf("bid percent_of_listing must be between 0 and 100: %v", r.PercentOfListing))
+	}
+	for rating, amount := range r.ByRating {
+		if amount <= 0 {
+			errs = append(errs, fmt.Errorf("bid amount for rating %s must be positive: %s", rating, amount))
+		}
+	}
+	if r.Min != 0 && r.Min < MinimumBid {
+		errs = append(errs, fmt.Errorf("bid min must be at least %s: %s", MinimumBid, r.Min))
+	}
+	if r.Max < 0 {
+		errs = append(errs, fmt.Errorf("bid max must be positive: %s", r.Max))
+	}
+	if r.Max != 0 && r.Max < r.MinBid() {
+		errs = append(errs, fmt.Errorf("bid max %s is below bid min %s", r.Max, r.MinBid()))
+	}
+	return errors.Join(errs...)
+}
+
+// MinBid returns the smallest bid the rule places, which is Min or, if Min is
+// unset, MinimumBid.
+func (r BidRule) MinBid() prosper.Money {
+	if r.Min == 0 {
+		return MinimumBid
+	}
+	return r.Min
+}
+
+// Size returns the bid the rule places on l, or an error explaining why it
+// places none.
+func (r BidRule) Size(l prosper.Listing) (prosper.Money, error) {
+	amount, ok := r.ByRating[l.Rating]
+	switch {
+	case ok:
+	case r.Amount != 0:
+		amount = r.Amount
+	case r.PercentOfListing != 0:
+		amount = prosper.MoneyFromFloat64(l.ListingAmount * r.PercentOfListing / 100)
+	default:
+		return 0, fmt.Errorf("no bid amount for rating %s", l.Rating)
+	}
+	if r.Max != 0 && amount > r.Max {
+		amount = r.Max
+	}
+	if remaining := prosper.MoneyFromFloat64(l.AmountRemaining); amount > remaining {
+		amount = remaining
+	}
+	amount = floorToCents(amount)
+	if amount < r.MinBid() {
+		return 0, fmt.Errorf("bid of $%s is below the minimum of $%s", amount, r.MinBid())
+	}
+	return amount, nil
+}
+
+func floorToCents(m prosper.Money) prosper.Money {
+	return m - m%prosper.Cent
+}
+
+// Validate returns an error if the budget total is not positive or the bid
+// limit is negative.
+func (b Budget) Validate() error {
+	var errs []error
+	if b.Total <= 0 {
+		errs = append(errs, fmt.Errorf("budget total must be positive: %s", b.Total))
+	}
+	if b.MaxBids < 0 {
+		errs = append(errs, fmt.Errorf("budget max_bids must not be negative: %d", b.MaxBids))
+	}
+	return errors.Join(errs...)
+}
+
+// Evaluate proposes bids on the listings that satisfy the strategy, in listing
+// order, until the budget runs out. Listings that do not satisfy Search are
+// rejected too, so listings from any source may be evaluated. When the
+// remaining budget is smaller than a bid, the bid shrinks to fit if it stays at
+// or above the bid minimum.
+func (s *Strategy) Evaluate(listings []prosper.Listing) Evaluation {
+	return s.EvaluateRemaining(listings, 0, 0)
+}
+
+// EvaluateRemaining is like Evaluate, but counts the amount spent and the
+// number of bids placed by earlier evaluations against the budget, so that the
+// budget holds across evaluations of successive batches of listings.
+func (s *Strategy) EvaluateRemaining(listings []prosper.Listing, spent prosper.Money, bids int) Evaluation {
+	var e Evaluation
+	search := prosper.SearchFilterPredicate(s.Search)
+	for _, l := range listings {
+		reasons := s.reject(l, search)
+		if reasons == nil {
+			amount, err := s.Bid.Size(l)
+			if err == nil {
+				amount, err = s.fitBudget(amount, spent+e.Spent, bids+len(e.Bids))
+			}
+			if err == nil {
+				e.Bids = append(e.Bids, prosper.NewBidRequest(l.ListingNumber, amount))
+				e.Spent += amount
+				continue
+			}
+			reasons = []string{err.Error()}
+		}
+		e.Rejected = append(e.Rejected, Rejection{ListingNumber: l.ListingNumber, Reasons: reasons})
+	}
+	return e
+}
+
+// reject returns the reasons l fails the strategy's criteria, or nil if it
+// satisfies them.
+func (s *Strategy) reject(l prosper.Listing, search prosper.Predicate) []string {
+	if s.ExcludeInvested && l.Invested {
+		return []string{"already invested in listing"}
+	}
+	if v := search.Evaluate(l); !v.Accepted {
+		return v.Reasons
+	}
+	if s.Require != nil {
+		if v := s.Require.Evaluate(l); !v.Accepted {
+			return v.Reasons
+		}
+	}
+	return nil
+}
+
+func (s *Strategy) fitBudget(amount, spent prosper.Money, bids int) (prosper.Money, error) {
+	if s.Budget.MaxBids != 0 && bids >= s.Budget.MaxBids {
+		return 0, fmt.Errorf("budget allows at most %d bids", s.Budget.MaxBids)
+	}
+	if remaining := s.Budget.Total - spent; amount > remaining {
+		amount = floorToCents(remaining)
+		if amount < s.Bid.MinBid() {
+			return 0, fmt.Errorf("remaining budget of $%s is below the bid minimum of $%s", remaining, s.Bid.MinBid())
+		}
+	}
+	return amount, nil
+}
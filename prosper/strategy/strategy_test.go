@@ -0,0 +1,275 @@
+package strategy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mtlynch/gofn-prosper/prosper"
+)
+
+func TestBidRuleSize(t *testing.T) {
+	listing := prosper.Listing{
+		Rating:          prosper.RatingB,
+		ListingAmount:   10000.0,
+		AmountRemaining: 4000.0,
+	}
+	var tests = []struct {
+		rule          BidRule
+		listing       prosper.Listing
+		want          prosper.Money
+		expectSuccess bool
+		msg           string
+	}{
+		{
+			rule:          BidRule{Amount: 30 * prosper.Dollar},
+			listing:       listing,
+			want:          30 * prosper.Dollar,
+			expectSuccess: true,
+			msg:           "fixed amount should be bid as is",
+		},
+		{
+			rule: BidRule{
+				Amount:   30 * prosper.Dollar,
+				ByRating: map[prosper.Rating]prosper.Money{prosper.RatingB: 60 * prosper.Dollar},
+			},
+			listing:       listing,
+			want:          60 * prosper.Dollar,
+			expectSuccess: true,
+			msg:           "rating amount should take precedence",
+		},
+		{
+			rule:          BidRule{PercentOfListing: 0.333},
+			listing:       listing,
+			want:          prosper.MoneyFromCents(3330),
+			expectSuccess: true,
+			msg:           "percent of listing should round down to cents",
+		},
+		{
+			rule:          BidRule{PercentOfListing: 5, Max: 100 * prosper.Dollar},
+			listing:       listing,
+			want:          100 * prosper.Dollar,
+			expectSuccess: true,
+			msg:           "bid should be capped at max",
+		},
+		{
+			rule:          BidRule{Amount: 100 * prosper.Dollar},
+			listing:       prosper.Listing{AmountRemaining: 40.5},
+			want:          prosper.MoneyFromCents(4050),
+			expectSuccess: true,
+			msg:           "bid should be capped at the remaining amount",
+		},
+		{
+			rule:          BidRule{Amount: 100 * prosper.Dollar},
+			listing:       prosper.Listing{AmountRemaining: 20.0},
+			expectSuccess: false,
+			msg:           "bid below the minimum should fail",
+		},
+		{
+			rule:          BidRule{ByRating: map[prosper.Rating]prosper.Money{prosper.RatingA: 60 * prosper.Dollar}},
+			listing:       listing,
+			expectSuccess: false,
+			msg:           "rating without an amount should fail",
+		},
+	}
+	for _, tt := range tests {
+		got, err := tt.rule.Size(tt.listing)
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s: expected success, got error: %v", tt.msg, err)
+		} else if !tt.expectSuccess && err == nil {
+			t.Errorf("%s: expected failure, got success: %s", tt.msg, got)
+		} else if got != tt.want {
+			t.Errorf("%s: unexpected bid. got: %s, want: %s", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestStrategyValidate(t *testing.T) {
+	valid := Strategy{
+		Name:   "valid",
+		Bid:    BidRule{Amount: 25 * prosper.Dollar},
+		Budget: Budget{Total: 100 * prosper.Dollar},
+	}
+	var tests = []struct {
+		modify        func(*Strategy)
+		expectSuccess bool
+		msg           string
+	}{
+		{
+			modify:        func(s *Strategy) {},
+			expectSuccess: true,
+			msg:           "valid strategy should pass",
+		},
+		{
+			modify:        func(s *Strategy) { s.Name = "" },
+			expectSuccess: false,
+			msg:           "empty name should fail",
+		},
+		{
+			modify:        func(s *Strategy) { s.Bid = BidRule{} },
+			expectSuccess: false,
+			msg:           "bid rule without an amount should fail",
+		},
+		{
+			modify:        func(s *Strategy) { s.Bid.Max = 20 * prosper.Dollar },
+			expectSuccess: false,
+			msg:           "max below min should fail",
+		},
+		{
+			modify:        func(s *Strategy) { s.Bid.PercentOfListing = 150 },
+			expectSuccess: false,
+			msg:           "percent above 100 should fail",
+		},
+		{
+			modify:        func(s *Strategy) { s.Budget.MaxBids = -1 },
+			expectSuccess: false,
+			msg:           "negative bid limit should fail",
+		},
+		{
+			modify: func(s *Strategy) {
+				s.Search.Rating = []prosper.Rating{prosper.RatingA}
+				s.Require = prosper.In(prosper.FieldRating, prosper.RatingB)
+			},
+			expectSuccess: false,
+			msg:           "contradictory criteria should fail",
+		},
+	}
+	for _, tt := range tests {
+		s := valid
+		tt.modify(&s)
+		err := s.Validate()
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s: expected success, got error: %v", tt.msg, err)
+		} else if !tt.expectSuccess && err == nil {
+			t.Errorf("%s: expected failure, got success", tt.msg)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	s := Strategy{
+		Name:            "test",
+		Search:          prosper.SearchFilter{Rating: []prosper.Rating{prosper.RatingA, prosper.RatingB}},
+		ExcludeInvested: true,
+		Require:         prosper.Compare(prosper.FieldBankcardUtilization, prosper.LessThan, 0.6),
+		Bid:             BidRule{Amount: 40 * prosper.Dollar},
+		Budget:          Budget{Total: 100 * prosper.Dollar, MaxBids: 3},
+	}
+	listing := func(n int64, rating prosper.Rating, utilization float64) prosper.Listing {
+		return prosper.Listing{
+			ListingNumber:       prosper.ListingNumber(n),
+			Rating:              rating,
+			BankcardUtilization: utilization,
+			AmountRemaining:     1000.0,
+		}
+	}
+	invested := listing(4, prosper.RatingA, 0.1)
+	invested.Invested = true
+	listings := []prosper.Listing{
+		listing(1, prosper.RatingA, 0.1),
+		listing(2, prosper.RatingC, 0.1),
+		listing(3, prosper.RatingB, 0.9),
+		invested,
+		listing(5, prosper.RatingB, 0.2),
+		listing(6, prosper.RatingA, 0.3),
+		listing(7, prosper.RatingA, 0.3),
+	}
+	got := s.Evaluate(listings)
+	want := Evaluation{
+		Bids: []prosper.BidRequest{
+			{ListingID: 1, BidAmount: 40.0},
+			{ListingID: 5, BidAmount: 40.0},
+		},
+		Rejected: []Rejection{
+			{ListingNumber: 2, Reasons: []string{"prosper_rating is C, which is not in {A, B}"}},
+			{ListingNumber: 3, Reasons: []string{"bankcard_utilization is 0.9, which is not < 0.6"}},
+			{ListingNumber: 4, Reasons: []string{"already invested in listing"}},
+			{ListingNumber: 6, Reasons: []string{"remaining budget of $20.00 is below the bid minimum of $25.00"}},
+			{ListingNumber: 7, Reasons: []string{"remaining budget of $20.00 is below the bid minimum of $25.00"}},
+		},
+		Spent: 80 * prosper.Dollar,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected evaluation.\ngot:  %+v\nwant: %+v", got, want)
+	}
+
+	s.Budget = Budget{Total: 90 * prosper.Dollar, MaxBids: 3}
+	got = s.Evaluate(listings)
+	wantBids := []prosper.BidRequest{
+		{ListingID: 1, BidAmount: 40.0},
+		{ListingID: 5, BidAmount: 40.0},
+	}
+	if !reflect.DeepEqual(got.Bids, wantBids) {
+		t.Errorf("unexpected bids with a small budget. got: %+v, want: %+v", got.Bids, wantBids)
+	}
+
+	s.Budget = Budget{Total: 1000 * prosper.Dollar, MaxBids: 2}
+	got = s.Evaluate(listings)
+	if len(got.Bids) != 2 || got.Rejected[3].Reasons[0] != "budget allows at most 2 bids" {
+		t.Errorf("unexpected evaluation with a bid limit: %+v", got)
+	}
+
+	s.Budget = Budget{Total: 105 * prosper.Dollar}
+	got = s.Evaluate(listings)
+	wantBids = []prosper.BidRequest{
+		{ListingID: 1, BidAmount: 40.0},
+		{ListingID: 5, BidAmount: 40.0},
+		{ListingID: 6, BidAmount: 25.0},
+	}
+	if !reflect.DeepEqual(got.Bids, wantBids) {
+		t.Errorf("last bid should shrink to fit the budget. got: %+v, want: %+v", got.Bids, wantBids)
+	}
+}
+
+func TestEvaluateRemaining(t *testing.T) {
+	s := Strategy{
+		Name:   "test",
+		Bid:    BidRule{Amount: 40 * prosper.Dollar},
+		Budget: Budget{Total: 100 * prosper.Dollar, MaxBids: 3},
+	}
+	listings := []prosper.Listing{
+		{ListingNumber: 1, AmountRemaining: 1000.0},
+		{ListingNumber: 2, AmountRemaining: 1000.0},
+	}
+	var tests = []struct {
+		spent    prosper.Money
+		bids     int
+		wantBids []prosper.BidRequest
+		msg      string
+	}{
+		{
+			wantBids: []prosper.BidRequest{
+				{ListingID: 1, BidAmount: 40.0},
+				{ListingID: 2, BidAmount: 40.0},
+			},
+			msg: "nothing spent yet should leave the whole budget",
+		},
+		{
+			spent: 50 * prosper.Dollar,
+			bids:  1,
+			wantBids: []prosper.BidRequest{
+				{ListingID: 1, BidAmount: 40.0},
+			},
+			msg: "earlier spending should count against the total",
+		},
+		{
+			spent: 40 * prosper.Dollar,
+			bids:  2,
+			wantBids: []prosper.BidRequest{
+				{ListingID: 1, BidAmount: 40.0},
+			},
+			msg: "earlier bids should count against the bid limit",
+		},
+		{
+			spent:    90 * prosper.Dollar,
+			bids:     2,
+			wantBids: nil,
+			msg:      "exhausted budget should propose no bids",
+		},
+	}
+	for _, tt := range tests {
+		got := s.EvaluateRemaining(listings, tt.spent, tt.bids)
+		if !reflect.DeepEqual(got.Bids, tt.wantBids) {
+			t.Errorf("%s: got bids %+v, want %+v", tt.msg, got.Bids, tt.wantBids)
+		}
+	}
+}
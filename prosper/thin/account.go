@@ -1,5 +1,7 @@
 package thin
 
+import "encoding/json"
+
 type (
 
 	// AccountParams specifies the optional parameters to the Prosper accounts
@@ -27,6 +29,10 @@ type (
 		LastWithdrawAmount                  float64 `json:"last_withdraw_amount"`
 		LastWithdrawDate                    string  `json:"last_withdraw_date"`
 		ExternalUserID                      string  `json:"external_user_id"`
+		// Amounts holds the exact decimal text of the currency fields.
+		Amounts AccountResponseAmounts `json:"-"`
+		// Raw holds the JSON response when the client keeps raw JSON.
+		Raw json.RawMessage `json:"-"`
 	}
 )
 
@@ -35,9 +41,12 @@ type (
 // implements the REST API described at:
 // https://developers.prosper.com/docs/investor/accounts-api/
 func (c defaultClient) Account(AccountParams) (response AccountResponse, err error) {
-	err = c.DoRequest("GET", c.baseURL+"/accounts/prosper/", nil, &response)
+	body, err := c.doRequest("GET", c.baseURL+"/accounts/prosper/", nil, &response)
 	if err != nil {
 		return AccountResponse{}, err
 	}
+	if c.keepRaw {
+		response.Raw = body
+	}
 	return response, nil
 }
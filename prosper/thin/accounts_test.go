@@ -55,6 +55,19 @@ func TestAccountsSuccessfulResponse(t *testing.T) {
 		LastDepositDate:                     "2015-10-23",
 		LastWithdrawAmount:                  5400,
 		LastWithdrawDate:                    "2015-10-02",
+		Amounts: AccountResponseAmounts{
+			AvailableCashBalance:                "22139.89",
+			PendingInvestmentsPrimaryMarket:     "5700",
+			PendingInvestmentsSecondaryMarket:   "0",
+			PendingQuickInvestOrders:            "0",
+			TotalPrincipalReceivedOnActiveNotes: "95460.28",
+			TotalAmountInvestedOnActiveNotes:    "394096.56",
+			OutstandingPrincipalOnActiveNotes:   "298636.28",
+			TotalAccountValue:                   "326476.16",
+			InflightGross:                       "12345.67",
+			LastDepositAmount:                   "20000",
+			LastWithdrawAmount:                  "5400",
+		},
 	}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("client.Accounts returned %#v, want %#v", got, want)
@@ -79,3 +92,30 @@ func TestAccountsErrorResponse(t *testing.T) {
 		t.Fatal("client.Accounts should fail when server returns error")
 	}
 }
+
+func TestAccountsKeepsRawJSON(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	body := `{"available_cash_balance": 22139.89, "new_field": "x"}`
+	mux.HandleFunc("/accounts/prosper/",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, body)
+		},
+	)
+	client := defaultClient{
+		baseURL:      server.URL,
+		tokenManager: mockTokenManager{},
+		keepRaw:      true,
+	}
+	got, err := client.Account(AccountParams{})
+	if err != nil {
+		t.Fatalf("client.Accounts failed: %v", err)
+	}
+	if got.AvailableCashBalance != 22139.89 {
+		t.Errorf("client.Accounts AvailableCashBalance = %v, want %v", got.AvailableCashBalance, 22139.89)
+	}
+	if string(got.Raw) != body {
+		t.Errorf("client.Accounts Raw = %s, want %s", got.Raw, body)
+	}
+}
@@ -0,0 +1,112 @@
+package thin
+
+import "encoding/json"
+
+// The Amounts types in this file hold the decimal text Prosper sent for the
+// currency fields of a response, decoded as json.Number so that no precision is
+// lost to float64. A field is empty if Prosper omitted it or sent null.
+
+type (
+	// AccountResponseAmounts holds the currency fields of an AccountResponse as
+	// Prosper sent them.
+	AccountResponseAmounts struct {
+		AvailableCashBalance                json.Number `json:"available_cash_balance"`
+		PendingInvestmentsPrimaryMarket     json.Number `json:"pending_investments_primary_market"`
+		PendingInvestmentsSecondaryMarket   json.Number `json:"pending_investments_secondary_market"`
+		PendingQuickInvestOrders            json.Number `json:"pending_quick_invest_orders"`
+		TotalPrincipalReceivedOnActiveNotes json.Number `json:"total_principal_received_on_active_notes"`
+		TotalAmountInvestedOnActiveNotes    json.Number `json:"total_amount_invested_on_active_notes"`
+		OutstandingPrincipalOnActiveNotes   json.Number `json:"outstanding_principal_on_active_notes"`
+		TotalAccountValue                   json.Number `json:"total_account_value"`
+		InflightGross                       json.Number `json:"inflight_gross"`
+		LastDepositAmount                   json.Number `json:"last_deposit_amount"`
+		LastWithdrawAmount                  json.Number `json:"last_withdraw_amount"`
+	}
+
+	// NoteResultAmounts holds the currency fields of a NoteResult as Prosper
+	// sent them.
+	NoteResultAmounts struct {
+		AmountBorrowed                       json.Number `json:"amount_borrowed"`
+		PrincipalBalanceProRataShare         json.Number `json:"principal_balance_pro_rata_share"`
+		ServiceFeesPaidProRataShare          json.Number `json:"service_fees_paid_pro_rata_share"`
+		PrincipalPaidProRataShare            json.Number `json:"principal_paid_pro_rata_share"`
+		InterestPaidProRataShare             json.Number `json:"interest_paid_pro_rata_share"`
+		ProsperFeesPaidProRataShare          json.Number `json:"prosper_fees_paid_pro_rata_share"`
+		LateFeesPaidProRataShare             json.Number `json:"late_fees_paid_pro_rata_share"`
+		DebtSaleProceedsReceivedProRataShare json.Number `json:"debt_sale_proceeds_received_pro_rata_share"`
+		NextPaymentDueAmountProRataShare     json.Number `json:"next_payment_due_amount_pro_rata_share"`
+		NoteOwnershipAmount                  json.Number `json:"note_ownership_amount"`
+		NoteSaleGrossAmountReceived          json.Number `json:"note_sale_gross_amount_received"`
+		NoteSaleFeesPaid                     json.Number `json:"note_sale_fees_paid"`
+	}
+
+	// SearchResultAmounts holds the currency fields of a SearchResult as
+	// Prosper sent them.
+	SearchResultAmounts struct {
+		AmountDelinquent                      json.Number `json:"amount_delinquent"`
+		AmountFunded                          json.Number `json:"amount_funded"`
+		AmountParticipation                   json.Number `json:"amount_participation"`
+		AmountRemaining                       json.Number `json:"amount_remaining"`
+		CombinedStatedMonthlyIncome           json.Number `json:"combined_stated_monthly_income"`
+		InstallmentBalance                    json.Number `json:"installment_balance"`
+		ListingAmount                         json.Number `json:"listing_amount"`
+		ListingMonthlyPayment                 json.Number `json:"listing_monthly_payment"`
+		MaxPriorProsperLoan                   json.Number `json:"max_prior_prosper_loan"`
+		MinPriorProsperLoan                   json.Number `json:"min_prior_prosper_loan"`
+		MonthlyDebt                           json.Number `json:"monthly_debt"`
+		PriorProsperLoansBalanceOutstanding   json.Number `json:"prior_prosper_loans_balance_outstanding"`
+		PriorProsperLoansPrincipalBorrowed    json.Number `json:"prior_prosper_loans_principal_borrowed"`
+		PriorProsperLoansPrincipalOutstanding json.Number `json:"prior_prosper_loans_principal_outstanding"`
+		RealEstateBalance                     json.Number `json:"real_estate_balance"`
+		RealEstatePayment                     json.Number `json:"real_estate_payment"`
+		RevolvingBalance                      json.Number `json:"revolving_balance"`
+		StatedMonthlyIncome                   json.Number `json:"stated_monthly_income"`
+	}
+
+	// BidStatusAmounts holds the currency fields of a BidStatus as Prosper
+	// sent them.
+	BidStatusAmounts struct {
+		BidAmount       json.Number `json:"bid_amount"`
+		BidAmountPlaced json.Number `json:"bid_amount_placed"`
+	}
+)
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the currency fields into
+// Amounts as well.
+func (r *AccountResponse) UnmarshalJSON(data []byte) error {
+	type plain AccountResponse
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &r.Amounts)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the currency fields into
+// Amounts as well.
+func (r *NoteResult) UnmarshalJSON(data []byte) error {
+	type plain NoteResult
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &r.Amounts)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the currency fields into
+// Amounts as well.
+func (r *SearchResult) UnmarshalJSON(data []byte) error {
+	type plain SearchResult
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &r.Amounts)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the currency fields into
+// Amounts as well.
+func (s *BidStatus) UnmarshalJSON(data []byte) error {
+	type plain BidStatus
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.Amounts)
+}
@@ -11,10 +11,13 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/mtlynch/gofn-prosper/prosper/auth"
+	"github.com/mtlynch/gofn-prosper/prosper/logging"
 )
 
 const baseProsperURL = "https://api.prosper.com/v1"
@@ -32,26 +35,73 @@ type Client interface {
 type defaultClient struct {
 	baseURL      string
 	tokenManager auth.TokenManager
+	logger       logging.Logger
+	location     *time.Location
+	strict       bool
+	onDrift      func(SchemaDrift)
+	keepRaw      bool
+}
+
+// ClientOptions specifies optional settings for a Client.
+type ClientOptions struct {
+	// Logger receives an event for each request to the Prosper API. If nil,
+	// events are discarded.
+	Logger logging.Logger
+	// Location is the time zone in which time filters are sent to Prosper. If
+	// nil, DefaultLocation() is used.
+	Location *time.Location
+	// StrictDecoding makes GET requests fail with a *SchemaDriftError when a
+	// response contains fields the thin types do not define or lacks fields
+	// they expect. The response is still fully decoded. Drift in the response
+	// to any other request, such as placing an order, is only logged and
+	// reported to OnSchemaDrift, since the request may already have taken
+	// effect.
+	StrictDecoding bool
+	// OnSchemaDrift, if non-nil, is called with the drift found in each
+	// response that differs from its thin type, whether or not StrictDecoding
+	// is set.
+	OnSchemaDrift func(SchemaDrift)
+	// KeepRawJSON makes the client keep the raw JSON Prosper sent for each
+	// account, note, listing and order in the Raw field of the response.
+	KeepRawJSON bool
 }
 
 // NewClient creates a new Client instance with the given token manager.
 func NewClient(t auth.TokenManager) Client {
+	return NewClientWithOptions(t, ClientOptions{})
+}
+
+// NewClientWithOptions creates a new Client instance with the given token
+// manager and options.
+func NewClientWithOptions(t auth.TokenManager, opts ClientOptions) Client {
 	return &defaultClient{
 		baseURL:      baseProsperURL,
 		tokenManager: t,
+		logger:       opts.Logger,
+		location:     opts.Location,
+		strict:       opts.StrictDecoding,
+		onDrift:      opts.OnSchemaDrift,
+		keepRaw:      opts.KeepRawJSON,
 	}
 }
 
 // DoRequest performs a single HTTP request against the Prosper server and
 // returns the result of the request.
 func (c defaultClient) DoRequest(method, urlStr string, body io.Reader, response interface{}) error {
+	_, err := c.doRequest(method, urlStr, body, response)
+	return err
+}
+
+// doRequest performs a request as in DoRequest. If the client keeps raw JSON
+// or checks for schema drift, it also returns the raw response body.
+func (c defaultClient) doRequest(method, urlStr string, body io.Reader, response interface{}) ([]byte, error) {
 	req, err := http.NewRequest(method, urlStr, body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	accessToken, err := c.token()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Authorization", "bearer "+accessToken)
 	req.Header.Set("Accept", "application/json")
@@ -59,28 +109,99 @@ func (c defaultClient) DoRequest(method, urlStr string, body io.Reader, response
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	logger := logging.OrDiscard(c.logger)
+	requestFields := []logging.Field{
+		logging.F("method", method),
+		logging.F("path", req.URL.Path),
+		logging.F("query", req.URL.RawQuery),
+	}
+	logger.Log(logging.Debug, "sending Prosper API request", requestFields...)
+
 	httpClient := &http.Client{
 		Timeout: 10 * time.Second,
 	}
+	start := time.Now()
 	resp, err := httpClient.Do(req)
 	if err != nil {
-		return err
+		logger.Log(logging.Error, "Prosper API request failed", append(requestFields, logging.F("error", err))...)
+		return nil, err
 	}
+	responseFields := append(requestFields,
+		logging.F("status", resp.StatusCode),
+		logging.F("duration", time.Since(start)))
 
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
+		logger.Log(logging.Warn, "Prosper API request returned error status", responseFields...)
 		if body, err := ioutil.ReadAll(resp.Body); err == nil {
 			msgCleaned := regexp.MustCompile(`\n\s*`).ReplaceAllString(string(body), " ")
-			return errors.New("request failed: " + resp.Status + " -" + msgCleaned)
+			return nil, errors.New("request failed: " + resp.Status + " -" + msgCleaned)
 		}
-		return errors.New("request failed: " + resp.Status)
+		return nil, errors.New("request failed: " + resp.Status)
 	}
+	logger.Log(logging.Debug, "received Prosper API response", responseFields...)
 
-	err = json.NewDecoder(resp.Body).Decode(response)
+	if !c.strict && c.onDrift == nil && !c.keepRaw {
+		err = json.NewDecoder(resp.Body).Decode(response)
+		if err != nil {
+			logger.Log(logging.Error, "failed to decode Prosper API response", append(responseFields, logging.F("error", err))...)
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	err = json.Unmarshal(responseBody, response)
+	if err != nil {
+		logger.Log(logging.Error, "failed to decode Prosper API response", append(responseFields, logging.F("error", err))...)
+		return nil, err
+	}
+	if !c.strict && c.onDrift == nil {
+		return responseBody, nil
+	}
+	drift, err := DetectDrift(c.endpoint(req.URL.Path), responseBody, response)
+	if err != nil {
+		return nil, err
+	}
+	if drift.IsEmpty() {
+		return responseBody, nil
+	}
+	logger.Log(logging.Warn, "Prosper API response does not match expected schema",
+		append(responseFields,
+			logging.F("unknown_fields", strings.Join(drift.UnknownFields, ",")),
+			logging.F("missing_fields", strings.Join(drift.MissingFields, ",")))...)
+	if c.onDrift != nil {
+		c.onDrift(drift)
+	}
+	// Only a GET is safe to fail on drift: a POST may already have taken
+	// effect, such as placing an order, so its response is always returned.
+	if c.strict && method == "GET" {
+		return nil, &SchemaDriftError{Drift: drift}
+	}
+	return responseBody, nil
+}
+
+// endpoint returns the name of the Prosper endpoint for the given request path,
+// relative to the client's base URL and with any order ID replaced by a
+// placeholder.
+func (c defaultClient) endpoint(path string) string {
+	if base, err := url.Parse(c.baseURL); err == nil {
+		path = strings.TrimPrefix(path, strings.TrimSuffix(base.Path, "/"))
+	}
+	if strings.HasPrefix(path, "/orders/") && path != "/orders/" {
+		return "/orders/{order_id}"
+	}
+	return path
+}
+
+func (c defaultClient) loc() *time.Location {
+	if c.location == nil {
+		return DefaultLocation()
+	}
+	return c.location
 }
 
 func (c defaultClient) token() (string, error) {
@@ -90,3 +211,13 @@ func (c defaultClient) token() (string, error) {
 	}
 	return token.AccessToken, nil
 }
+
+// rawResults returns the raw JSON of each element in the "result" array of a
+// paged Prosper response.
+func rawResults(body []byte) ([]json.RawMessage, error) {
+	var envelope struct {
+		Result []json.RawMessage `json:"result"`
+	}
+	err := json.Unmarshal(body, &envelope)
+	return envelope.Result, err
+}
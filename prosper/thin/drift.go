@@ -0,0 +1,264 @@
+package thin
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaDrift describes the differences between a JSON response from a Prosper
+// endpoint and the thin type it decodes into. Field paths use the JSON
+// attribute names, with "[]" marking array elements, for example
+// "result[].listing_title".
+type SchemaDrift struct {
+	// Endpoint identifies the Prosper API endpoint, such as "/search/listings/".
+	Endpoint string
+	// UnknownFields lists fields present in the response that the thin type
+	// does not define.
+	UnknownFields []string
+	// MissingFields lists fields the thin type defines that are absent from the
+	// response.
+	MissingFields []string
+}
+
+// IsEmpty returns true if the response matched the thin type exactly.
+func (d SchemaDrift) IsEmpty() bool {
+	return len(d.UnknownFields) == 0 && len(d.MissingFields) == 0
+}
+
+// String returns a human-readable summary of the drift.
+func (d SchemaDrift) String() string {
+	if d.IsEmpty() {
+		return fmt.Sprintf("%s: no schema drift", d.Endpoint)
+	}
+	var parts []string
+	if len(d.UnknownFields) > 0 {
+		parts = append(parts, "unknown fields: "+strings.Join(d.UnknownFields, ", "))
+	}
+	if len(d.MissingFields) > 0 {
+		parts = append(parts, "missing fields: "+strings.Join(d.MissingFields, ", "))
+	}
+	return fmt.Sprintf("%s: %s", d.Endpoint, strings.Join(parts, "; "))
+}
+
+// SchemaDriftError is returned by a client in strict decoding mode when a
+// response does not match the thin type it decodes into.
+type SchemaDriftError struct {
+	Drift SchemaDrift
+}
+
+func (e *SchemaDriftError) Error() string {
+	return "schema drift in Prosper API response: " + e.Drift.String()
+}
+
+// endpointTypes maps each Prosper endpoint to the thin type of its response.
+var endpointTypes = map[string]reflect.Type{
+	"/accounts/prosper/": reflect.TypeOf(AccountResponse{}),
+	"/notes/":            reflect.TypeOf(NotesResponse{}),
+	"/orders/":           reflect.TypeOf(OrderResponse{}),
+	"/orders/{order_id}": reflect.TypeOf(OrderResponse{}),
+	"/search/listings/":  reflect.TypeOf(SearchResponse{}),
+}
+
+// DetectEndpointDrift compares a recorded JSON response from the given Prosper
+// endpoint, such as "/search/listings/", against the thin type for that
+// endpoint.
+func DetectEndpointDrift(endpoint string, data []byte) (SchemaDrift, error) {
+	t, ok := endpointTypes[endpoint]
+	if !ok {
+		return SchemaDrift{}, fmt.Errorf("unrecognized Prosper endpoint: %s", endpoint)
+	}
+	return detectDrift(endpoint, data, t)
+}
+
+// DetectDrift compares the JSON document data against the type of v, which is
+// usually a pointer to a thin response type.
+func DetectDrift(endpoint string, data []byte, v interface{}) (SchemaDrift, error) {
+	return detectDrift(endpoint, data, reflect.TypeOf(v))
+}
+
+func detectDrift(endpoint string, data []byte, t reflect.Type) (SchemaDrift, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return SchemaDrift{}, err
+	}
+	unknown := map[string]bool{}
+	missing := map[string]bool{}
+	compareToType(doc, t, "", unknown, missing)
+	return SchemaDrift{
+		Endpoint:      endpoint,
+		UnknownFields: sortedKeys(unknown),
+		MissingFields: sortedKeys(missing),
+	}, nil
+}
+
+// compareToType walks the decoded JSON value alongside type t, recording the
+// paths of object keys that t does not define and of fields t defines that the
+// object lacks.
+func compareToType(value interface{}, t reflect.Type, path string, unknown, missing map[string]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if t.Kind() != reflect.Struct {
+			return
+		}
+		fields := jsonFields(t)
+		for key, child := range v {
+			fieldType, ok := fields[key]
+			if !ok {
+				unknown[joinPath(path, key)] = true
+				continue
+			}
+			compareToType(child, fieldType, joinPath(path, key), unknown, missing)
+		}
+		for name := range fields {
+			if _, ok := v[name]; !ok {
+				missing[joinPath(path, name)] = true
+			}
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return
+		}
+		for _, child := range v {
+			compareToType(child, t.Elem(), path+"[]", unknown, missing)
+		}
+	}
+}
+
+// jsonFields returns the JSON attribute names of struct type t, including
+// those promoted from embedded structs, mapped to their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for embeddedName, embeddedType := range jsonFields(f.Type) {
+				fields[embeddedName] = embeddedType
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys(m map[string]bool) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DriftReport aggregates schema drift across many responses, such as a
+// directory of recorded responses checked in CI.
+type DriftReport struct {
+	endpoints map[string]*driftCounts
+}
+
+type driftCounts struct {
+	responses int
+	unknown   map[string]int
+	missing   map[string]int
+}
+
+// NewDriftReport creates an empty DriftReport.
+func NewDriftReport() *DriftReport {
+	return &DriftReport{endpoints: map[string]*driftCounts{}}
+}
+
+// Add records the drift found in a single response.
+func (r *DriftReport) Add(d SchemaDrift) {
+	c, ok := r.endpoints[d.Endpoint]
+	if !ok {
+		c = &driftCounts{unknown: map[string]int{}, missing: map[string]int{}}
+		r.endpoints[d.Endpoint] = c
+	}
+	c.responses++
+	for _, f := range d.UnknownFields {
+		c.unknown[f]++
+	}
+	for _, f := range d.MissingFields {
+		c.missing[f]++
+	}
+}
+
+// HasDrift returns true if any recorded response differed from its thin type.
+func (r *DriftReport) HasDrift() bool {
+	for _, c := range r.endpoints {
+		if len(c.unknown) > 0 || len(c.missing) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Drifts returns the union of drift recorded for each endpoint, sorted by
+// endpoint.
+func (r *DriftReport) Drifts() []SchemaDrift {
+	var endpoints []string
+	for e := range r.endpoints {
+		endpoints = append(endpoints, e)
+	}
+	sort.Strings(endpoints)
+	var drifts []SchemaDrift
+	for _, e := range endpoints {
+		c := r.endpoints[e]
+		drifts = append(drifts, SchemaDrift{
+			Endpoint:      e,
+			UnknownFields: sortedCountKeys(c.unknown),
+			MissingFields: sortedCountKeys(c.missing),
+		})
+	}
+	return drifts
+}
+
+// String summarizes the report, one line per endpoint and field, with the
+// number of responses in which each difference appeared.
+func (r *DriftReport) String() string {
+	var lines []string
+	for _, d := range r.Drifts() {
+		c := r.endpoints[d.Endpoint]
+		lines = append(lines, fmt.Sprintf("%s (%d responses)", d.Endpoint, c.responses))
+		for _, f := range d.UnknownFields {
+			lines = append(lines, fmt.Sprintf("  unknown field %s (%d/%d)", f, c.unknown[f], c.responses))
+		}
+		for _, f := range d.MissingFields {
+			lines = append(lines, fmt.Sprintf("  missing field %s (%d/%d)", f, c.missing[f], c.responses))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func sortedCountKeys(m map[string]int) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
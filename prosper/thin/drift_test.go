@@ -0,0 +1,240 @@
+package thin
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const accountResponseJSON = `{
+	"available_cash_balance": 22139.89,
+	"pending_investments_primary_market": 5700,
+	"pending_investments_secondary_market": 0,
+	"pending_quick_invest_orders": 0,
+	"total_principal_received_on_active_notes": 95460.28,
+	"total_amount_invested_on_active_notes": 394096.56,
+	"outstanding_principal_on_active_notes": 298636.28,
+	"total_account_value": 326476.16,
+	"inflight_gross": 12345.67,
+	"last_deposit_amount": 20000,
+	"last_deposit_date": "2015-10-23",
+	"last_withdraw_amount": 5400,
+	"last_withdraw_date": "2015-10-02",
+	"external_user_id": "ABCDEFGH-1234-5678-IJKL-MNOPQRSTUVWX"%s
+}`
+
+func TestDetectDrift(t *testing.T) {
+	type item struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+	type embedded struct {
+		ID string `json:"id"`
+	}
+	type response struct {
+		embedded
+		Items []item `json:"items"`
+		Total int    `json:"total"`
+	}
+	var tests = []struct {
+		json        string
+		wantUnknown []string
+		wantMissing []string
+		msg         string
+	}{
+		{
+			json: `{"id": "a", "items": [{"name": "x", "count": 1}], "total": 1}`,
+			msg:  "matching response should have no drift",
+		},
+		{
+			json:        `{"id": "a", "items": [{"name": "x", "count": 1, "color": "red"}, {"name": "y"}], "total": 1, "page": 2}`,
+			wantUnknown: []string{"items[].color", "page"},
+			wantMissing: []string{"items[].count"},
+			msg:         "added and removed fields should be reported by path",
+		},
+		{
+			json:        `{"items": null, "total": 1}`,
+			wantMissing: []string{"id"},
+			msg:         "missing embedded field should be reported",
+		},
+	}
+	for _, tt := range tests {
+		got, err := DetectDrift("/test/", []byte(tt.json), &response{})
+		if err != nil {
+			t.Errorf("%s: DetectDrift failed: %v", tt.msg, err)
+			continue
+		}
+		want := SchemaDrift{
+			Endpoint:      "/test/",
+			UnknownFields: tt.wantUnknown,
+			MissingFields: tt.wantMissing,
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: DetectDrift got %+v, want %+v", tt.msg, got, want)
+		}
+	}
+}
+
+func TestDetectEndpointDrift(t *testing.T) {
+	got, err := DetectEndpointDrift("/accounts/prosper/", []byte(fmt.Sprintf(accountResponseJSON, `, "crypto_balance": 0`)))
+	if err != nil {
+		t.Fatalf("DetectEndpointDrift failed: %v", err)
+	}
+	want := SchemaDrift{
+		Endpoint:      "/accounts/prosper/",
+		UnknownFields: []string{"crypto_balance"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectEndpointDrift got %+v, want %+v", got, want)
+	}
+	if _, err := DetectEndpointDrift("/unknown/", []byte(`{}`)); err == nil {
+		t.Errorf("DetectEndpointDrift should fail for an unrecognized endpoint")
+	}
+}
+
+func TestStrictDecoding(t *testing.T) {
+	var tests = []struct {
+		strict        bool
+		extraField    string
+		expectSuccess bool
+		wantReported  int
+		msg           string
+	}{
+		{
+			strict:        true,
+			expectSuccess: true,
+			msg:           "matching response should succeed in strict mode",
+		},
+		{
+			strict:        true,
+			extraField:    `, "crypto_balance": 0`,
+			expectSuccess: false,
+			wantReported:  1,
+			msg:           "unknown field should fail in strict mode",
+		},
+		{
+			strict:        false,
+			extraField:    `, "crypto_balance": 0`,
+			expectSuccess: true,
+			wantReported:  1,
+			msg:           "unknown field should only be reported outside strict mode",
+		},
+	}
+	for _, tt := range tests {
+		setUp()
+		mux.HandleFunc("/accounts/prosper/",
+			func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, accountResponseJSON, tt.extraField)
+			},
+		)
+		var reported []SchemaDrift
+		client := defaultClient{
+			baseURL:      server.URL,
+			tokenManager: mockTokenManager{},
+			strict:       tt.strict,
+			onDrift: func(d SchemaDrift) {
+				reported = append(reported, d)
+			},
+		}
+		got, err := client.Account(AccountParams{})
+		tearDown()
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s: client.Account failed: %v", tt.msg, err)
+		} else if !tt.expectSuccess {
+			if _, ok := err.(*SchemaDriftError); !ok {
+				t.Errorf("%s: expected *SchemaDriftError, got %T: %v", tt.msg, err, err)
+			}
+		}
+		if len(reported) != tt.wantReported {
+			t.Errorf("%s: reported %d drifts, want %d", tt.msg, len(reported), tt.wantReported)
+		}
+		for _, d := range reported {
+			if d.Endpoint != "/accounts/prosper/" {
+				t.Errorf("%s: unexpected drift endpoint %q", tt.msg, d.Endpoint)
+			}
+		}
+		if got.AvailableCashBalance != 22139.89 && tt.expectSuccess {
+			t.Errorf("%s: response was not decoded, got %+v", tt.msg, got)
+		}
+		if got.Raw != nil {
+			t.Errorf("%s: Raw should be nil unless the client keeps raw JSON, got %s", tt.msg, got.Raw)
+		}
+	}
+}
+
+func TestStrictDecodingReturnsDriftedOrder(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	mux.HandleFunc("/orders/",
+		func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "POST")
+			fmt.Fprint(w, `{
+				"order_id": "067e6162-3b6f-4ae2-a171-2470b63dff00",
+				"bid_requests": [
+					{"listing_id": 215032, "bid_status": "PENDING", "bid_amount": 32}
+				],
+				"order_status": "IN_PROGRESS",
+				"order_date": "2015-09-17 19:54:58 +0000",
+				"order_channel": "API"
+			}`)
+		},
+	)
+	var reported []SchemaDrift
+	client := defaultClient{
+		baseURL:      server.URL,
+		tokenManager: mockTokenManager{},
+		strict:       true,
+		onDrift: func(d SchemaDrift) {
+			reported = append(reported, d)
+		},
+	}
+	got, err := client.PlaceBid([]BidRequest{{215032, 32}})
+	if err != nil {
+		t.Fatalf("client.PlaceBid should not fail on drift in a POST response, got %v", err)
+	}
+	if got.OrderID != "067e6162-3b6f-4ae2-a171-2470b63dff00" {
+		t.Errorf("client.PlaceBid lost the order, got %+v", got)
+	}
+	if len(reported) != 1 || reported[0].Endpoint != "/orders/" {
+		t.Errorf("client.PlaceBid should report drift for /orders/, got %+v", reported)
+	}
+}
+
+func TestDriftReport(t *testing.T) {
+	r := NewDriftReport()
+	r.Add(SchemaDrift{Endpoint: "/notes/"})
+	if r.HasDrift() {
+		t.Errorf("report without differences should not have drift")
+	}
+	r.Add(SchemaDrift{Endpoint: "/search/listings/", UnknownFields: []string{"result[].new_field"}})
+	r.Add(SchemaDrift{Endpoint: "/search/listings/", MissingFields: []string{"result[].listing_title"}})
+	if !r.HasDrift() {
+		t.Errorf("report with differences should have drift")
+	}
+	want := "/notes/ (1 responses)\n" +
+		"/search/listings/ (2 responses)\n" +
+		"  unknown field result[].new_field (1/2)\n" +
+		"  missing field result[].listing_title (1/2)"
+	if got := r.String(); got != want {
+		t.Errorf("DriftReport.String() got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestClientEndpoint(t *testing.T) {
+	c := defaultClient{baseURL: baseProsperURL}
+	var tests = []struct {
+		path string
+		want string
+	}{
+		{"/v1/search/listings/", "/search/listings/"},
+		{"/v1/orders/", "/orders/"},
+		{"/v1/orders/90cf709d-81d6-416a-89f2-ba6ab8146ef2", "/orders/{order_id}"},
+	}
+	for _, tt := range tests {
+		if got := c.endpoint(tt.path); got != tt.want {
+			t.Errorf("endpoint(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
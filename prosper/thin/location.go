@@ -0,0 +1,13 @@
+package thin
+
+import (
+	"time"
+
+	"github.com/mtlynch/gofn-prosper/interval"
+)
+
+// DefaultLocation returns the location Prosper uses for its business dates and
+// for interpreting date filters in search queries: America/Los_Angeles.
+func DefaultLocation() *time.Location {
+	return interval.DefaultDateLocation()
+}
@@ -1,6 +1,7 @@
 package thin
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -44,6 +45,10 @@ type (
 		NoteDefaultReason                    int64   `json:"note_default_reason"`
 		NoteDefaultReasonDescription         string  `json:"note_default_reason_description"`
 		IsSold                               bool    `json:"is_sold"`
+		// Amounts holds the exact decimal text of the currency fields.
+		Amounts NoteResultAmounts `json:"-"`
+		// Raw holds the JSON for this note when the client keeps raw JSON.
+		Raw json.RawMessage `json:"-"`
 	}
 
 	// NotesResponse contains the full response from the Notes API in minimally
@@ -61,10 +66,19 @@ type (
 func (c defaultClient) Notes(p NotesParams) (response NotesResponse, err error) {
 	q := notesParamsToQueryString(p)
 	url := fmt.Sprintf("%s/notes/?%s", c.baseURL, q)
-	err = c.DoRequest("GET", url, nil, &response)
+	body, err := c.doRequest("GET", url, nil, &response)
 	if err != nil {
 		return NotesResponse{}, err
 	}
+	if c.keepRaw {
+		raw, err := rawResults(body)
+		if err != nil {
+			return NotesResponse{}, err
+		}
+		for i := range response.Result {
+			response.Result[i].Raw = raw[i]
+		}
+	}
 	return response, nil
 }
 
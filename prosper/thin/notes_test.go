@@ -127,6 +127,20 @@ func TestNotesSuccessfulResponse(t *testing.T) {
 				NoteDefaultReason:                    2,
 				NoteDefaultReasonDescription:         "Bankruptcy",
 				IsSold: false,
+				Amounts: NoteResultAmounts{
+					AmountBorrowed:                       "25000",
+					PrincipalBalanceProRataShare:         "42.17326",
+					ServiceFeesPaidProRataShare:          "-0.13544",
+					PrincipalPaidProRataShare:            "7.82672",
+					InterestPaidProRataShare:             "4.7373",
+					ProsperFeesPaidProRataShare:          "0",
+					LateFeesPaidProRataShare:             "0",
+					DebtSaleProceedsReceivedProRataShare: "0",
+					NextPaymentDueAmountProRataShare:     "0",
+					NoteOwnershipAmount:                  "50",
+					NoteSaleGrossAmountReceived:          "0",
+					NoteSaleFeesPaid:                     "0",
+				},
 			},
 			{
 				LoanNumber:                           7772,
@@ -157,6 +171,20 @@ func TestNotesSuccessfulResponse(t *testing.T) {
 				NoteDefaultReason:                    1,
 				NoteDefaultReasonDescription:         "Delinquency",
 				IsSold: false,
+				Amounts: NoteResultAmounts{
+					AmountBorrowed:                       "15000",
+					PrincipalBalanceProRataShare:         "0",
+					ServiceFeesPaidProRataShare:          "-0.019167",
+					PrincipalPaidProRataShare:            "1.1119",
+					InterestPaidProRataShare:             "0.648233",
+					ProsperFeesPaidProRataShare:          "0",
+					LateFeesPaidProRataShare:             "0",
+					DebtSaleProceedsReceivedProRataShare: "4.270933",
+					NextPaymentDueAmountProRataShare:     "0",
+					NoteOwnershipAmount:                  "50",
+					NoteSaleGrossAmountReceived:          "0",
+					NoteSaleFeesPaid:                     "0",
+				},
 			},
 		},
 		ResultCount: 2,
@@ -226,3 +254,32 @@ func TestNotesParamsToQueryString(t *testing.T) {
 		}
 	}
 }
+
+func TestNotesKeepsRawJSON(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	mux.HandleFunc("/notes/",
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"result": [{"loan_number": 1, "new_field": "x"}, {"loan_number": 2}], "result_count": 2, "total_count": 2}`)
+		},
+	)
+	client := defaultClient{
+		baseURL:      server.URL,
+		tokenManager: mockTokenManager{},
+		keepRaw:      true,
+	}
+	got, err := client.Notes(NotesParams{})
+	if err != nil {
+		t.Fatalf("client.Notes failed: %v", err)
+	}
+	want := []string{`{"loan_number": 1, "new_field": "x"}`, `{"loan_number": 2}`}
+	if len(got.Result) != len(want) {
+		t.Fatalf("client.Notes returned %d notes, want %d", len(got.Result), len(want))
+	}
+	for i, w := range want {
+		if string(got.Result[i].Raw) != w {
+			t.Errorf("client.Notes Result[%d].Raw = %s, want %s", i, got.Result[i].Raw, w)
+		}
+	}
+}
@@ -24,6 +24,8 @@ type (
 		Status          string  `json:"bid_status"`
 		BidResult       string  `json:"bid_result"`
 		BidAmountPlaced float64 `json:"bid_amount_placed"`
+		// Amounts holds the exact decimal text of the currency fields.
+		Amounts BidStatusAmounts `json:"-"`
 	}
 
 	// OrderResponse represents the full JSON response from the Prosper order
@@ -35,6 +37,8 @@ type (
 		BidStatus   []BidStatus `json:"bid_requests"`
 		OrderStatus string      `json:"order_status"`
 		OrderDate   string      `json:"order_date"`
+		// Raw holds the JSON response when the client keeps raw JSON.
+		Raw json.RawMessage `json:"-"`
 	}
 )
 
@@ -46,10 +50,13 @@ func (c defaultClient) PlaceBid(br []BidRequest) (response OrderResponse, err er
 	if err != nil {
 		return OrderResponse{}, err
 	}
-	err = c.DoRequest("POST", c.baseURL+"/orders/", bytes.NewReader(reqBody), &response)
+	body, err := c.doRequest("POST", c.baseURL+"/orders/", bytes.NewReader(reqBody), &response)
 	if err != nil {
 		return OrderResponse{}, err
 	}
+	if c.keepRaw {
+		response.Raw = body
+	}
 	return response, nil
 }
 
@@ -57,9 +64,12 @@ func (c defaultClient) PlaceBid(br []BidRequest) (response OrderResponse, err er
 // Prosper /orders/{order_id}/listings API described at:
 // https://developers.prosper.com/docs/investor/orders-api/
 func (c defaultClient) OrderStatus(orderID string) (response OrderResponse, err error) {
-	err = c.DoRequest("GET", c.baseURL+"/orders/"+orderID, nil, &response)
+	body, err := c.doRequest("GET", c.baseURL+"/orders/"+orderID, nil, &response)
 	if err != nil {
 		return OrderResponse{}, err
 	}
+	if c.keepRaw {
+		response.Raw = body
+	}
 	return response, nil
 }
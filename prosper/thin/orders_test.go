@@ -54,7 +54,8 @@ func TestPlaceBidSuccessfulResponse(t *testing.T) {
 					ListingID: 215032,
 					BidAmount: 32.0,
 				},
-				Status: "PENDING",
+				Status:  "PENDING",
+				Amounts: BidStatusAmounts{BidAmount: "32"},
 			},
 		},
 		OrderStatus: "IN_PROGRESS",
@@ -146,6 +147,7 @@ func TestOrderStatusSuccessfulResponse(t *testing.T) {
 				Status:          "INVESTED",
 				BidResult:       "BID_SUCCEEDED",
 				BidAmountPlaced: 100.0,
+				Amounts:         BidStatusAmounts{BidAmount: "100", BidAmountPlaced: "100"},
 			},
 		},
 		OrderStatus: "COMPLETED",
@@ -1,6 +1,10 @@
 package thin
 
-import "github.com/mtlynch/gofn-prosper/interval"
+import (
+	"encoding/json"
+
+	"github.com/mtlynch/gofn-prosper/interval"
+)
 
 type (
 	// SearchFilter specifies a filter for the types of listings to retrieve in
@@ -15,6 +19,32 @@ type (
 		Rating                                    []string
 		ListingStartDate                          interval.TimeRange
 		ListingStatus                             []int
+		ListingNumber                             []int64
+		ListingTerm                               []int
+		ListingAmount                             interval.Float64Range
+		AmountRemaining                           interval.Float64Range
+		PercentFunded                             interval.Float64Range
+		LenderYield                               interval.Float64Range
+		BorrowerRate                              interval.Float64Range
+		EffectiveYield                            interval.Float64Range
+		EstimatedLossRate                         interval.Float64Range
+		FicoScore                                 []string
+		ProsperScore                              interval.Int32Range
+		BorrowerState                             []string
+		EmploymentStatusDescription               []string
+		MonthsEmployed                            interval.Int32Range
+		StatedMonthlyIncome                       interval.Float64Range
+		IsHomeowner                               *bool
+		IncomeVerifiable                          *bool
+		ListingCategoryID                         []int
+		PriorProsperLoans                         interval.Int32Range
+		PriorProsperLoansActive                   interval.Int32Range
+	}
+
+	// SortKey specifies a single field by which to sort Search results.
+	SortKey struct {
+		Field      string
+		Descending bool
 	}
 
 	// SearchParams specifies parameters to the Search.
@@ -22,6 +52,7 @@ type (
 		Offset                  int
 		Limit                   int
 		ExcludeListingsInvested bool
+		SortBy                  []SortKey
 		Filter                  SearchFilter
 	}
 
@@ -40,6 +71,9 @@ type (
 		BorrowerRate                              float64 `json:"borrower_rate"`
 		BorrowerState                             string  `json:"borrower_state"`
 		ChannelCode                               string  `json:"channel_code"`
+		CoBorrowerApplication                     bool    `json:"co_borrower_application"`
+		CombinedDtiWprosperLoan                   float64 `json:"combined_dti_wprosper_loan"`
+		CombinedStatedMonthlyIncome               float64 `json:"combined_stated_monthly_income"`
 		CreditLinesLast7Years                     int64   `json:"credit_lines_last7_years"`
 		CreditPullDate                            string  `json:"credit_pull_date"`
 		CurrentCreditLines                        int64   `json:"current_credit_lines"`
@@ -65,6 +99,7 @@ type (
 		InstallmentBalance                        float64 `json:"installment_balance"`
 		InvestmentTypeDescription                 string  `json:"investment_type_description"`
 		InvestmentTypeid                          int64   `json:"investment_typeid"`
+		Invested                                  bool    `json:"invested"`
 		IsHomeowner                               bool    `json:"is_homeowner"`
 		LastUpdatedDate                           string  `json:"last_updated_date"`
 		LenderIndicator                           int64   `json:"lender_indicator"`
@@ -123,6 +158,10 @@ type (
 		WasDelinquentDerog                        int64   `json:"was_delinquent_derog"`
 		WholeLoanEndDate                          string  `json:"whole_loan_end_date"`
 		WholeLoanStartDate                        string  `json:"whole_loan_start_date"`
+		// Amounts holds the exact decimal text of the currency fields.
+		Amounts SearchResultAmounts `json:"-"`
+		// Raw holds the JSON for this listing when the client keeps raw JSON.
+		Raw json.RawMessage `json:"-"`
 	}
 
 	// SearchResponse contains the full response from the Search API in minimally
@@ -135,13 +174,24 @@ type (
 )
 
 // Search queries Prosper for current listings that match specified search
-// parameters. Search implements the REST API described at:
+// parameters. It sends the filter as given; callers that build filters from
+// untrusted input should check them first with SearchFilter.Validate.
+// Search implements the REST API described at:
 // https://developers.prosper.com/docs/investor/searchlistings-api/
 func (c defaultClient) Search(p SearchParams) (response SearchResponse, err error) {
-	queryString := searchParamsToQueryString(p)
-	err = c.DoRequest("GET", c.baseURL+"/search/listings/?"+queryString, nil, &response)
+	queryString := EncodeSearchQuery(p, c.loc())
+	body, err := c.doRequest("GET", c.baseURL+"/search/listings/?"+queryString, nil, &response)
 	if err != nil {
 		return SearchResponse{}, err
 	}
+	if c.keepRaw {
+		raw, err := rawResults(body)
+		if err != nil {
+			return SearchResponse{}, err
+		}
+		for i := range response.Results {
+			response.Results[i].Raw = raw[i]
+		}
+	}
 	return response, nil
 }
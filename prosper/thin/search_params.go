@@ -1,90 +1,194 @@
 package thin
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/mtlynch/gofn-prosper/interval"
 )
 
-func stringsToClauseValues(name string, s []string) string {
-	joined := strings.Join(s, ",")
-	return fmt.Sprintf("%s=%s", name, joined)
+// queryTimeLayout is the layout of time filters in search queries. Prosper
+// interprets them as wall clock times in Pacific time.
+const queryTimeLayout = "2006-01-02 15:04:05"
+
+func addList(q url.Values, name string, values []string) {
+	if len(values) > 0 {
+		q.Set(name, strings.Join(values, ","))
+	}
+}
+
+func addInts(q url.Values, name string, ints []int) {
+	var values []string
+	for _, v := range ints {
+		values = append(values, strconv.Itoa(v))
+	}
+	addList(q, name, values)
+}
+
+func addInt64s(q url.Values, name string, ints []int64) {
+	var values []string
+	for _, v := range ints {
+		values = append(values, strconv.FormatInt(v, 10))
+	}
+	addList(q, name, values)
+}
+
+func addInt8s(q url.Values, name string, ints []int8) {
+	var values []string
+	for _, v := range ints {
+		values = append(values, strconv.Itoa(int(v)))
+	}
+	addList(q, name, values)
 }
 
-func intsToClauseValues(name string, ints []int) string {
-	converted := []string{}
-	for _, val := range ints {
-		converted = append(converted, fmt.Sprintf("%d", val))
+func addBool(q url.Values, name string, b *bool) {
+	if b != nil {
+		q.Set(name, strconv.FormatBool(*b))
 	}
-	return stringsToClauseValues(name, converted)
 }
 
-func float64RangeToClauses(name string, r interval.Float64Range) (clauses []string) {
+func addFloat64Range(q url.Values, name string, r interval.Float64Range) {
 	if r.Min != nil {
-		clauses = append(clauses, fmt.Sprintf("%s_min=%.4f", name, *r.Min))
+		q.Set(name+"_min", fmt.Sprintf("%.4f", *r.Min))
 	}
 	if r.Max != nil {
-		clauses = append(clauses, fmt.Sprintf("%s_max=%.4f", name, *r.Max))
+		q.Set(name+"_max", fmt.Sprintf("%.4f", *r.Max))
 	}
-	return clauses
 }
 
-func int32RangeToClauses(name string, r interval.Int32Range) (clauses []string) {
+func addInt32Range(q url.Values, name string, r interval.Int32Range) {
 	if r.Min != nil {
-		clauses = append(clauses, fmt.Sprintf("%s_min=%d", name, *r.Min))
+		q.Set(name+"_min", strconv.FormatInt(int64(*r.Min), 10))
 	}
 	if r.Max != nil {
-		clauses = append(clauses, fmt.Sprintf("%s_max=%d", name, *r.Max))
+		q.Set(name+"_max", strconv.FormatInt(int64(*r.Max), 10))
 	}
-	return clauses
 }
 
-func formatTime(t time.Time) string {
-	return t.Format("2006-01-02+15:04:05")
+// formatTime formats t as the wall clock time in loc, which is how Prosper
+// interprets time filters.
+func formatTime(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(queryTimeLayout)
 }
 
-func timeRangeToClauses(name string, r interval.TimeRange) (clauses []string) {
+func addTimeRange(q url.Values, name string, r interval.TimeRange, loc *time.Location) {
 	if r.Min != nil {
-		clauses = append(clauses, fmt.Sprintf("%s_min=%s", name, formatTime(*r.Min)))
+		q.Set(name+"_min", formatTime(*r.Min, loc))
 	}
 	if r.Max != nil {
-		clauses = append(clauses, fmt.Sprintf("%s_max=%s", name, formatTime(*r.Max)))
+		q.Set(name+"_max", formatTime(*r.Max, loc))
 	}
-	return clauses
 }
 
-func searchParamsToQueryString(p SearchParams) string {
-	var clauses []string
+func addSortKeys(q url.Values, name string, keys []SortKey) {
+	var sorts []string
+	for _, k := range keys {
+		direction := "asc"
+		if k.Descending {
+			direction = "desc"
+		}
+		sorts = append(sorts, k.Field+" "+direction)
+	}
+	addList(q, name, sorts)
+}
+
+func searchParamsToQuery(p SearchParams, loc *time.Location) url.Values {
+	q := url.Values{}
 	if p.Offset != 0 {
-		clauses = append(clauses, fmt.Sprintf("offset=%d", p.Offset))
+		q.Set("offset", strconv.Itoa(p.Offset))
 	}
 	if p.Limit != 0 {
-		clauses = append(clauses, fmt.Sprintf("limit=%d", p.Limit))
+		q.Set("limit", strconv.Itoa(p.Limit))
 	}
 	if p.ExcludeListingsInvested {
-		clauses = append(clauses, "exclude_listings_invested=true")
+		q.Set("exclude_listings_invested", "true")
 	}
-	if len(p.Filter.IncomeRange) > 0 {
-		var rangeValues []string
-		for _, v := range p.Filter.IncomeRange {
-			rangeValues = append(rangeValues, fmt.Sprintf("%d", v))
-		}
-		clauses = append(clauses, stringsToClauseValues("income_range", rangeValues))
+	addSortKeys(q, "sort_by", p.SortBy)
+	f := p.Filter
+	addInt8s(q, "income_range", f.IncomeRange)
+	addList(q, "prosper_rating", f.Rating)
+	addInts(q, "listing_status", f.ListingStatus)
+	addFloat64Range(q, "estimated_return", f.EstimatedReturn)
+	addInt32Range(q, "inquiries_last6_months", f.InquiriesLast6Months)
+	addInt32Range(q, "prior_prosper_loans_late_payments_one_month_plus", f.PriorProsperLoansLatePaymentsOneMonthPlus)
+	addFloat64Range(q, "prior_prosper_loans_balance_outstanding", f.PriorProsperLoansBalanceOutstanding)
+	addFloat64Range(q, "dti_wprosper_loan", f.DtiWprosperLoan)
+	addTimeRange(q, "listing_start_date", f.ListingStartDate, loc)
+	addInt64s(q, "listing_number", f.ListingNumber)
+	addInts(q, "listing_term", f.ListingTerm)
+	addFloat64Range(q, "listing_amount", f.ListingAmount)
+	addFloat64Range(q, "amount_remaining", f.AmountRemaining)
+	addFloat64Range(q, "percent_funded", f.PercentFunded)
+	addFloat64Range(q, "lender_yield", f.LenderYield)
+	addFloat64Range(q, "borrower_rate", f.BorrowerRate)
+	addFloat64Range(q, "effective_yield", f.EffectiveYield)
+	addFloat64Range(q, "estimated_loss_rate", f.EstimatedLossRate)
+	addList(q, "fico_score", f.FicoScore)
+	addInt32Range(q, "prosper_score", f.ProsperScore)
+	addList(q, "borrower_state", f.BorrowerState)
+	addList(q, "employment_status_description", f.EmploymentStatusDescription)
+	addInt32Range(q, "months_employed", f.MonthsEmployed)
+	addFloat64Range(q, "stated_monthly_income", f.StatedMonthlyIncome)
+	addBool(q, "is_homeowner", f.IsHomeowner)
+	addBool(q, "income_verifiable", f.IncomeVerifiable)
+	addInts(q, "listing_category_id", f.ListingCategoryID)
+	addInt32Range(q, "prior_prosper_loans", f.PriorProsperLoans)
+	addInt32Range(q, "prior_prosper_loans_active", f.PriorProsperLoansActive)
+	return q
+}
+
+// EncodeSearchQuery encodes p as the query string of a Prosper search request,
+// with parameters sorted by name and every value escaped. Time filters are
+// written as wall clock times in loc, or in DefaultLocation() if loc is nil.
+// List values are joined with commas, so they must not contain commas
+// themselves.
+func EncodeSearchQuery(p SearchParams, loc *time.Location) string {
+	if loc == nil {
+		loc = DefaultLocation()
 	}
-	if len(p.Filter.Rating) > 0 {
-		clauses = append(clauses, stringsToClauseValues("prosper_rating", p.Filter.Rating))
+	return searchParamsToQuery(p, loc).Encode()
+}
+
+// validateRange checks that r is a non-empty range Prosper can express as a
+// filter. Prosper treats _min and _max filters as inclusive, so exclusive
+// bounds are rejected.
+func validateRange[T interval.Value](name string, r interval.Range[T]) error {
+	if r.MinExclusive || r.MaxExclusive {
+		return fmt.Errorf("invalid %s filter: exclusive bounds are not supported", name)
 	}
-	if len(p.Filter.ListingStatus) > 0 {
-		clauses = append(clauses, intsToClauseValues("listing_status", p.Filter.ListingStatus))
+	if err := r.Validate(); err != nil {
+		return fmt.Errorf("invalid %s filter: %v", name, err)
 	}
-	clauses = append(clauses, float64RangeToClauses("estimated_return", p.Filter.EstimatedReturn)...)
-	clauses = append(clauses, int32RangeToClauses("inquiries_last6_months", p.Filter.InquiriesLast6Months)...)
-	clauses = append(clauses, int32RangeToClauses("prior_prosper_loans_late_payments_one_month_plus", p.Filter.PriorProsperLoansLatePaymentsOneMonthPlus)...)
-	clauses = append(clauses, float64RangeToClauses("prior_prosper_loans_balance_outstanding", p.Filter.PriorProsperLoansBalanceOutstanding)...)
-	clauses = append(clauses, float64RangeToClauses("dti_wprosper_loan", p.Filter.DtiWprosperLoan)...)
-	clauses = append(clauses, timeRangeToClauses("listing_start_date", p.Filter.ListingStartDate)...)
+	return nil
+}
 
-	return strings.Join(clauses, "&")
+// Validate returns an error if any range in the filter is empty, such as one
+// whose minimum is greater than its maximum, or has an exclusive bound. The
+// error joins one error for each invalid range.
+func (f SearchFilter) Validate() error {
+	errs := []error{
+		validateRange("estimated_return", f.EstimatedReturn),
+		validateRange("inquiries_last6_months", f.InquiriesLast6Months),
+		validateRange("prior_prosper_loans_late_payments_one_month_plus", f.PriorProsperLoansLatePaymentsOneMonthPlus),
+		validateRange("prior_prosper_loans_balance_outstanding", f.PriorProsperLoansBalanceOutstanding),
+		validateRange("dti_wprosper_loan", f.DtiWprosperLoan),
+		validateRange("listing_start_date", f.ListingStartDate),
+		validateRange("listing_amount", f.ListingAmount),
+		validateRange("amount_remaining", f.AmountRemaining),
+		validateRange("percent_funded", f.PercentFunded),
+		validateRange("lender_yield", f.LenderYield),
+		validateRange("borrower_rate", f.BorrowerRate),
+		validateRange("effective_yield", f.EffectiveYield),
+		validateRange("estimated_loss_rate", f.EstimatedLossRate),
+		validateRange("prosper_score", f.ProsperScore),
+		validateRange("months_employed", f.MonthsEmployed),
+		validateRange("stated_monthly_income", f.StatedMonthlyIncome),
+		validateRange("prior_prosper_loans", f.PriorProsperLoans),
+		validateRange("prior_prosper_loans_active", f.PriorProsperLoansActive),
+	}
+	return errors.Join(errs...)
 }
@@ -7,7 +7,9 @@ import (
 	"github.com/mtlynch/gofn-prosper/interval"
 )
 
-func TestSearchParamsToQueryString(t *testing.T) {
+func TestEncodeSearchQuery(t *testing.T) {
+	isTrue := true
+	isFalse := false
 	var tests = []struct {
 		p    SearchParams
 		want string
@@ -30,6 +32,36 @@ func TestSearchParamsToQueryString(t *testing.T) {
 			},
 			want: "exclude_listings_invested=true",
 		},
+		{
+			p: SearchParams{
+				SortBy: []SortKey{
+					{Field: "lender_yield", Descending: true},
+				},
+			},
+			want: "sort_by=lender_yield+desc",
+		},
+		{
+			p: SearchParams{
+				SortBy: []SortKey{
+					{Field: "listing_start_date", Descending: true},
+					{Field: "percent_funded"},
+				},
+			},
+			want: "sort_by=listing_start_date+desc%2Cpercent_funded+asc",
+		},
+		{
+			p: SearchParams{
+				Limit:                   50,
+				ExcludeListingsInvested: true,
+				SortBy: []SortKey{
+					{Field: "effective_yield", Descending: true},
+				},
+				Filter: SearchFilter{
+					Rating: []string{"AA", "A"},
+				},
+			},
+			want: "exclude_listings_invested=true&limit=50&prosper_rating=AA%2CA&sort_by=effective_yield+desc",
+		},
 		{
 			p: SearchParams{
 				Filter: SearchFilter{
@@ -49,7 +81,7 @@ func TestSearchParamsToQueryString(t *testing.T) {
 					},
 				},
 			},
-			want: "estimated_return_min=0.0500&estimated_return_max=0.0700",
+			want: "estimated_return_max=0.0700&estimated_return_min=0.0500",
 		},
 		{
 			p: SearchParams{
@@ -73,7 +105,7 @@ func TestSearchParamsToQueryString(t *testing.T) {
 					IncomeRange: []int8{2, 3},
 				},
 			},
-			want: "income_range=2,3",
+			want: "income_range=2%2C3",
 		},
 		{
 			p: SearchParams{
@@ -81,7 +113,7 @@ func TestSearchParamsToQueryString(t *testing.T) {
 					IncomeRange: []int8{2, 3, 8},
 				},
 			},
-			want: "income_range=2,3,8",
+			want: "income_range=2%2C3%2C8",
 		},
 		{
 			p: SearchParams{
@@ -177,7 +209,7 @@ func TestSearchParamsToQueryString(t *testing.T) {
 					Rating: []string{"A", "C", "E"},
 				},
 			},
-			want: "prosper_rating=A,C,E",
+			want: "prosper_rating=A%2CC%2CE",
 		},
 		{
 			p: SearchParams{
@@ -193,7 +225,7 @@ func TestSearchParamsToQueryString(t *testing.T) {
 					ListingStatus: []int{2, 6, 7},
 				},
 			},
-			want: "listing_status=2,6,7",
+			want: "listing_status=2%2C6%2C7",
 		},
 		{
 			p: SearchParams{
@@ -203,7 +235,7 @@ func TestSearchParamsToQueryString(t *testing.T) {
 					},
 				},
 			},
-			want: "listing_start_date_min=2016-02-28+11:46:05",
+			want: "listing_start_date_min=2016-02-28+11%3A46%3A05",
 		},
 		{
 			p: SearchParams{
@@ -213,7 +245,7 @@ func TestSearchParamsToQueryString(t *testing.T) {
 					},
 				},
 			},
-			want: "listing_start_date_max=2016-02-28+11:46:05",
+			want: "listing_start_date_max=2016-02-28+11%3A46%3A05",
 		},
 		{
 			p: SearchParams{
@@ -224,14 +256,293 @@ func TestSearchParamsToQueryString(t *testing.T) {
 					},
 				},
 			},
-			want: "listing_start_date_min=2016-02-28+11:46:05&listing_start_date_max=2016-02-29+11:46:05",
+			want: "listing_start_date_max=2016-02-29+11%3A46%3A05&listing_start_date_min=2016-02-28+11%3A46%3A05",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					ListingNumber: []int64{4247229, 4245951},
+				},
+			},
+			want: "listing_number=4247229%2C4245951",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					ListingTerm: []int{36, 60},
+				},
+			},
+			want: "listing_term=36%2C60",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					ListingAmount: interval.Float64Range{
+						Min: interval.CreateFloat64(2000.0),
+						Max: interval.CreateFloat64(15000.0),
+					},
+				},
+			},
+			want: "listing_amount_max=15000.0000&listing_amount_min=2000.0000",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					AmountRemaining: interval.Float64Range{
+						Min: interval.CreateFloat64(25.0),
+					},
+				},
+			},
+			want: "amount_remaining_min=25.0000",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					PercentFunded: interval.Float64Range{
+						Max: interval.CreateFloat64(0.75),
+					},
+				},
+			},
+			want: "percent_funded_max=0.7500",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					LenderYield: interval.Float64Range{
+						Min: interval.CreateFloat64(0.1),
+					},
+					BorrowerRate: interval.Float64Range{
+						Max: interval.CreateFloat64(0.25),
+					},
+				},
+			},
+			want: "borrower_rate_max=0.2500&lender_yield_min=0.1000",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					EffectiveYield: interval.Float64Range{
+						Min: interval.CreateFloat64(0.08),
+					},
+					EstimatedLossRate: interval.Float64Range{
+						Max: interval.CreateFloat64(0.06),
+					},
+				},
+			},
+			want: "effective_yield_min=0.0800&estimated_loss_rate_max=0.0600",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					FicoScore: []string{"720-739", "740-759"},
+				},
+			},
+			want: "fico_score=720-739%2C740-759",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					ProsperScore: interval.Int32Range{
+						Min: interval.CreateInt32(6),
+						Max: interval.CreateInt32(11),
+					},
+				},
+			},
+			want: "prosper_score_max=11&prosper_score_min=6",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					BorrowerState: []string{"CA", "NY"},
+				},
+			},
+			want: "borrower_state=CA%2CNY",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					EmploymentStatusDescription: []string{"Employed", "Retired"},
+				},
+			},
+			want: "employment_status_description=Employed%2CRetired",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					MonthsEmployed: interval.Int32Range{
+						Min: interval.CreateInt32(24),
+					},
+				},
+			},
+			want: "months_employed_min=24",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					StatedMonthlyIncome: interval.Float64Range{
+						Min: interval.CreateFloat64(4000.0),
+					},
+				},
+			},
+			want: "stated_monthly_income_min=4000.0000",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					IsHomeowner: &isTrue,
+				},
+			},
+			want: "is_homeowner=true",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					IsHomeowner:      &isFalse,
+					IncomeVerifiable: &isTrue,
+				},
+			},
+			want: "income_verifiable=true&is_homeowner=false",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					ListingCategoryID: []int{1, 7},
+				},
+			},
+			want: "listing_category_id=1%2C7",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					PriorProsperLoans: interval.Int32Range{
+						Min: interval.CreateInt32(1),
+					},
+					PriorProsperLoansActive: interval.Int32Range{
+						Max: interval.CreateInt32(0),
+					},
+				},
+			},
+			want: "prior_prosper_loans_active_max=0&prior_prosper_loans_min=1",
+		},
+		{
+			p: SearchParams{
+				Limit: 25,
+				Filter: SearchFilter{
+					Rating:      []string{"A"},
+					ListingTerm: []int{36},
+					IsHomeowner: &isTrue,
+				},
+			},
+			want: "is_homeowner=true&limit=25&listing_term=36&prosper_rating=A",
+		},
+		{
+			p: SearchParams{
+				Filter: SearchFilter{
+					Rating:                      []string{"N/A"},
+					EmploymentStatusDescription: []string{"Self-employed & other"},
+				},
+			},
+			want: "employment_status_description=Self-employed+%26+other&prosper_rating=N%2FA",
 		},
 	}
 	for _, tt := range tests {
-		got := searchParamsToQueryString(tt.p)
+		got := EncodeSearchQuery(tt.p, time.UTC)
 		if got != tt.want {
-			t.Errorf("searchParamsToQueryString() got: %v, want: %v", got, tt.want)
+			t.Errorf("EncodeSearchQuery() got: %v, want: %v", got, tt.want)
 		}
 	}
 
 }
+
+func TestEncodeSearchQueryConvertsTimesToLocation(t *testing.T) {
+	eastern := time.FixedZone("EST", -5*60*60)
+	var tests = []struct {
+		start time.Time
+		loc   *time.Location
+		want  string
+		msg   string
+	}{
+		{
+			start: time.Date(2016, 2, 28, 11, 46, 5, 0, time.UTC),
+			loc:   DefaultLocation(),
+			want:  "listing_start_date_min=2016-02-28+03%3A46%3A05",
+			msg:   "UTC time should be sent as Pacific time",
+		},
+		{
+			start: time.Date(2016, 7, 1, 1, 0, 0, 0, eastern),
+			loc:   DefaultLocation(),
+			want:  "listing_start_date_min=2016-06-30+23%3A00%3A00",
+			msg:   "Eastern time should be sent as Pacific daylight time",
+		},
+		{
+			start: time.Date(2016, 2, 28, 11, 46, 5, 0, eastern),
+			loc:   time.UTC,
+			want:  "listing_start_date_min=2016-02-28+16%3A46%3A05",
+			msg:   "configured location should override the default",
+		},
+	}
+	for _, tt := range tests {
+		p := SearchParams{
+			Filter: SearchFilter{
+				ListingStartDate: interval.TimeRange{Min: &tt.start},
+			},
+		}
+		got := EncodeSearchQuery(p, tt.loc)
+		if got != tt.want {
+			t.Errorf("%s: EncodeSearchQuery() got: %v, want: %v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestSearchFilterValidate(t *testing.T) {
+	var tests = []struct {
+		f             SearchFilter
+		expectSuccess bool
+		msg           string
+	}{
+		{
+			f:             SearchFilter{},
+			expectSuccess: true,
+			msg:           "empty filter should be valid",
+		},
+		{
+			f: SearchFilter{
+				EstimatedReturn: interval.NewFloat64Range(0.05, 0.1),
+				ProsperScore:    interval.NewInt32Range(4, 4),
+			},
+			expectSuccess: true,
+			msg:           "ranges with min <= max should be valid",
+		},
+		{
+			f: SearchFilter{
+				ProsperScore: interval.NewInt32Range(8, 4),
+			},
+			expectSuccess: false,
+			msg:           "range with min > max should be invalid",
+		},
+		{
+			f: SearchFilter{
+				ListingStartDate: interval.NewTimeRange(
+					time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC),
+					time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			expectSuccess: false,
+			msg:           "time range with min > max should be invalid",
+		},
+		{
+			f: SearchFilter{
+				LenderYield: interval.Float64Range{Min: interval.CreateFloat64(0.1), MinExclusive: true},
+			},
+			expectSuccess: false,
+			msg:           "range with exclusive bound should be invalid",
+		},
+	}
+	for _, tt := range tests {
+		err := tt.f.Validate()
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s: Validate failed: %v", tt.msg, err)
+		} else if !tt.expectSuccess && err == nil {
+			t.Errorf("%s: expected Validate to fail", tt.msg)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package thin
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mtlynch/gofn-prosper/interval"
+)
+
+// ParseSearchQuery parses the query string of a Prosper search request, such as
+// one produced by EncodeSearchQuery, back into SearchParams. Time filters are
+// read as wall clock times in loc, or in DefaultLocation() if loc is nil. It
+// returns an error for unrecognized parameters and malformed values.
+func ParseSearchQuery(query string, loc *time.Location) (SearchParams, error) {
+	if loc == nil {
+		loc = DefaultLocation()
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return SearchParams{}, err
+	}
+	q := queryParser{values: values, loc: loc, seen: map[string]bool{}}
+	var p SearchParams
+	p.Offset = q.int("offset")
+	p.Limit = q.int("limit")
+	p.ExcludeListingsInvested = q.bool("exclude_listings_invested")
+	p.SortBy = q.sortKeys("sort_by")
+	f := &p.Filter
+	f.IncomeRange = q.int8s("income_range")
+	f.Rating = q.list("prosper_rating")
+	f.ListingStatus = q.ints("listing_status")
+	f.EstimatedReturn = q.float64Range("estimated_return")
+	f.InquiriesLast6Months = q.int32Range("inquiries_last6_months")
+	f.PriorProsperLoansLatePaymentsOneMonthPlus = q.int32Range("prior_prosper_loans_late_payments_one_month_plus")
+	f.PriorProsperLoansBalanceOutstanding = q.float64Range("prior_prosper_loans_balance_outstanding")
+	f.DtiWprosperLoan = q.float64Range("dti_wprosper_loan")
+	f.ListingStartDate = q.timeRange("listing_start_date")
+	f.ListingNumber = q.int64s("listing_number")
+	f.ListingTerm = q.ints("listing_term")
+	f.ListingAmount = q.float64Range("listing_amount")
+	f.AmountRemaining = q.float64Range("amount_remaining")
+	f.PercentFunded = q.float64Range("percent_funded")
+	f.LenderYield = q.float64Range("lender_yield")
+	f.BorrowerRate = q.float64Range("borrower_rate")
+	f.EffectiveYield = q.float64Range("effective_yield")
+	f.EstimatedLossRate = q.float64Range("estimated_loss_rate")
+	f.FicoScore = q.list("fico_score")
+	f.ProsperScore = q.int32Range("prosper_score")
+	f.BorrowerState = q.list("borrower_state")
+	f.EmploymentStatusDescription = q.list("employment_status_description")
+	f.MonthsEmployed = q.int32Range("months_employed")
+	f.StatedMonthlyIncome = q.float64Range("stated_monthly_income")
+	f.IsHomeowner = q.boolPtr("is_homeowner")
+	f.IncomeVerifiable = q.boolPtr("income_verifiable")
+	f.ListingCategoryID = q.ints("listing_category_id")
+	f.PriorProsperLoans = q.int32Range("prior_prosper_loans")
+	f.PriorProsperLoansActive = q.int32Range("prior_prosper_loans_active")
+	if q.err != nil {
+		return SearchParams{}, q.err
+	}
+	var unrecognized []string
+	for name := range values {
+		if !q.seen[name] {
+			unrecognized = append(unrecognized, name)
+		}
+	}
+	if len(unrecognized) > 0 {
+		sort.Strings(unrecognized)
+		return SearchParams{}, fmt.Errorf("unrecognized search parameters: %s", strings.Join(unrecognized, ", "))
+	}
+	return p, nil
+}
+
+// queryParser reads typed values from a search query. It records the first
+// error it encounters, after which all reads return zero values.
+type queryParser struct {
+	values url.Values
+	loc    *time.Location
+	seen   map[string]bool
+	err    error
+}
+
+func (q *queryParser) get(name string) (string, bool) {
+	q.seen[name] = true
+	vs, ok := q.values[name]
+	if !ok || q.err != nil {
+		return "", false
+	}
+	if len(vs) != 1 || vs[0] == "" {
+		q.err = fmt.Errorf("search parameter %s must have exactly one non-empty value", name)
+		return "", false
+	}
+	return vs[0], true
+}
+
+func (q *queryParser) fail(name, value string, err error) {
+	if q.err == nil {
+		q.err = fmt.Errorf("invalid value for search parameter %s: %q: %v", name, value, err)
+	}
+}
+
+func (q *queryParser) int(name string) int {
+	s, ok := q.get(name)
+	if !ok {
+		return 0
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		q.fail(name, s, err)
+	}
+	return v
+}
+
+func (q *queryParser) bool(name string) bool {
+	b := q.boolPtr(name)
+	return b != nil && *b
+}
+
+func (q *queryParser) boolPtr(name string) *bool {
+	s, ok := q.get(name)
+	if !ok {
+		return nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		q.fail(name, s, err)
+		return nil
+	}
+	return &b
+}
+
+func (q *queryParser) list(name string) []string {
+	s, ok := q.get(name)
+	if !ok {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func (q *queryParser) ints(name string) []int {
+	var ints []int
+	for _, s := range q.list(name) {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			q.fail(name, s, err)
+			return nil
+		}
+		ints = append(ints, v)
+	}
+	return ints
+}
+
+func (q *queryParser) int8s(name string) []int8 {
+	var ints []int8
+	for _, s := range q.list(name) {
+		v, err := strconv.ParseInt(s, 10, 8)
+		if err != nil {
+			q.fail(name, s, err)
+			return nil
+		}
+		ints = append(ints, int8(v))
+	}
+	return ints
+}
+
+func (q *queryParser) int64s(name string) []int64 {
+	var ints []int64
+	for _, s := range q.list(name) {
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			q.fail(name, s, err)
+			return nil
+		}
+		ints = append(ints, v)
+	}
+	return ints
+}
+
+func (q *queryParser) sortKeys(name string) []SortKey {
+	var keys []SortKey
+	for _, s := range q.list(name) {
+		parts := strings.Split(s, " ")
+		if len(parts) != 2 || (parts[1] != "asc" && parts[1] != "desc") {
+			q.fail(name, s, fmt.Errorf("expected \"<field> asc\" or \"<field> desc\""))
+			return nil
+		}
+		keys = append(keys, SortKey{Field: parts[0], Descending: parts[1] == "desc"})
+	}
+	return keys
+}
+
+func (q *queryParser) float64Range(name string) (r interval.Float64Range) {
+	parse := func(s string) *float64 {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			q.fail(name, s, err)
+			return nil
+		}
+		return &v
+	}
+	if s, ok := q.get(name + "_min"); ok {
+		r.Min = parse(s)
+	}
+	if s, ok := q.get(name + "_max"); ok {
+		r.Max = parse(s)
+	}
+	return r
+}
+
+func (q *queryParser) int32Range(name string) (r interval.Int32Range) {
+	parse := func(s string) *int32 {
+		v, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			q.fail(name, s, err)
+			return nil
+		}
+		return interval.CreateInt32(int32(v))
+	}
+	if s, ok := q.get(name + "_min"); ok {
+		r.Min = parse(s)
+	}
+	if s, ok := q.get(name + "_max"); ok {
+		r.Max = parse(s)
+	}
+	return r
+}
+
+func (q *queryParser) timeRange(name string) (r interval.TimeRange) {
+	parse := func(s string) *time.Time {
+		t, err := time.ParseInLocation(queryTimeLayout, s, q.loc)
+		if err != nil {
+			q.fail(name, s, err)
+			return nil
+		}
+		return &t
+	}
+	if s, ok := q.get(name + "_min"); ok {
+		r.Min = parse(s)
+	}
+	if s, ok := q.get(name + "_max"); ok {
+		r.Max = parse(s)
+	}
+	return r
+}
@@ -0,0 +1,220 @@
+package thin
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mtlynch/gofn-prosper/interval"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	isTrue := true
+	var tests = []struct {
+		query         string
+		want          SearchParams
+		expectSuccess bool
+		msg           string
+	}{
+		{
+			query:         "",
+			want:          SearchParams{},
+			expectSuccess: true,
+			msg:           "empty query should parse to empty params",
+		},
+		{
+			query: "exclude_listings_invested=true&is_homeowner=true&limit=50&prosper_rating=N%2FA%2CAA&sort_by=effective_yield+desc%2Clisting_number+asc",
+			want: SearchParams{
+				Limit:                   50,
+				ExcludeListingsInvested: true,
+				SortBy: []SortKey{
+					{Field: "effective_yield", Descending: true},
+					{Field: "listing_number"},
+				},
+				Filter: SearchFilter{
+					Rating:      []string{"N/A", "AA"},
+					IsHomeowner: &isTrue,
+				},
+			},
+			expectSuccess: true,
+			msg:           "escaped values should parse",
+		},
+		{
+			query: "listing_start_date_min=2016-02-28+03%3A46%3A05&prosper_score_max=11&lender_yield_min=0.1000",
+			want: SearchParams{
+				Filter: SearchFilter{
+					ListingStartDate: interval.TimeRange{Min: interval.CreateTime(time.Date(2016, 2, 28, 11, 46, 5, 0, time.UTC))},
+					ProsperScore:     interval.Int32Range{Max: interval.CreateInt32(11)},
+					LenderYield:      interval.Float64Range{Min: interval.CreateFloat64(0.1)},
+				},
+			},
+			expectSuccess: true,
+			msg:           "ranges should parse",
+		},
+		{
+			query:         "listing_term=36&borrower_city=Boston",
+			expectSuccess: false,
+			msg:           "unrecognized parameter should fail",
+		},
+		{
+			query:         "limit=ten",
+			expectSuccess: false,
+			msg:           "malformed integer should fail",
+		},
+		{
+			query:         "limit=10&limit=20",
+			expectSuccess: false,
+			msg:           "repeated parameter should fail",
+		},
+		{
+			query:         "sort_by=effective_yield+sideways",
+			expectSuccess: false,
+			msg:           "malformed sort direction should fail",
+		},
+		{
+			query:         "listing_start_date_min=2016-02-28T03%3A46%3A05Z",
+			expectSuccess: false,
+			msg:           "malformed time should fail",
+		},
+		{
+			query:         "income_range=2%2C300",
+			expectSuccess: false,
+			msg:           "out of range income range should fail",
+		},
+	}
+	for _, tt := range tests {
+		got, err := ParseSearchQuery(tt.query, DefaultLocation())
+		if tt.expectSuccess && err != nil {
+			t.Errorf("%s: ParseSearchQuery(%q) failed: %v", tt.msg, tt.query, err)
+			continue
+		} else if !tt.expectSuccess {
+			if err == nil {
+				t.Errorf("%s: expected ParseSearchQuery(%q) to fail", tt.msg, tt.query)
+			}
+			continue
+		}
+		if EncodeSearchQuery(got, DefaultLocation()) != EncodeSearchQuery(tt.want, DefaultLocation()) {
+			t.Errorf("%s: ParseSearchQuery(%q) = %+v, want %+v", tt.msg, tt.query, got, tt.want)
+		}
+	}
+}
+
+// randomSearchParams generates SearchParams whose values survive encoding
+// exactly: floats have at most four decimal places and times are whole seconds
+// in loc, away from the hours around daylight saving time transitions.
+func randomSearchParams(r *rand.Rand, loc *time.Location) SearchParams {
+	maybe := func() bool { return r.Intn(2) == 0 }
+	float64Range := func() (fr interval.Float64Range) {
+		if maybe() {
+			fr.Min = interval.CreateFloat64(float64(r.Intn(2000000)-1000000) / 10000)
+		}
+		if maybe() {
+			fr.Max = interval.CreateFloat64(float64(r.Intn(2000000)-1000000) / 10000)
+		}
+		return fr
+	}
+	int32Range := func() (ir interval.Int32Range) {
+		if maybe() {
+			ir.Min = interval.CreateInt32(r.Int31() - r.Int31())
+		}
+		if maybe() {
+			ir.Max = interval.CreateInt32(r.Int31() - r.Int31())
+		}
+		return ir
+	}
+	randomTime := func() *time.Time {
+		t := time.Date(2010+r.Intn(15), time.Month(1+r.Intn(12)), 1+r.Intn(28), 4+r.Intn(20), r.Intn(60), r.Intn(60), 0, loc)
+		return &t
+	}
+	strs := func(choices ...string) []string {
+		var s []string
+		for _, c := range choices {
+			if maybe() {
+				s = append(s, c)
+			}
+		}
+		return s
+	}
+	ints := func() []int {
+		var s []int
+		for i := r.Intn(4); i > 0; i-- {
+			s = append(s, r.Intn(100))
+		}
+		return s
+	}
+	boolPtr := func() *bool {
+		if maybe() {
+			return nil
+		}
+		b := maybe()
+		return &b
+	}
+	var p SearchParams
+	p.Offset = r.Intn(1000)
+	p.Limit = r.Intn(500)
+	p.ExcludeListingsInvested = maybe()
+	for i := r.Intn(3); i > 0; i-- {
+		p.SortBy = append(p.SortBy, SortKey{Field: []string{"effective_yield", "lender_yield", "listing_number"}[r.Intn(3)], Descending: maybe()})
+	}
+	f := &p.Filter
+	for i := r.Intn(3); i > 0; i-- {
+		f.IncomeRange = append(f.IncomeRange, int8(r.Intn(8)))
+	}
+	f.Rating = strs("AA", "A", "N/A", "HR")
+	f.ListingStatus = ints()
+	f.EstimatedReturn = float64Range()
+	f.InquiriesLast6Months = int32Range()
+	f.PriorProsperLoansLatePaymentsOneMonthPlus = int32Range()
+	f.PriorProsperLoansBalanceOutstanding = float64Range()
+	f.DtiWprosperLoan = float64Range()
+	if maybe() {
+		f.ListingStartDate.Min = randomTime()
+	}
+	if maybe() {
+		f.ListingStartDate.Max = randomTime()
+	}
+	for i := r.Intn(3); i > 0; i-- {
+		f.ListingNumber = append(f.ListingNumber, r.Int63())
+	}
+	f.ListingTerm = ints()
+	f.ListingAmount = float64Range()
+	f.AmountRemaining = float64Range()
+	f.PercentFunded = float64Range()
+	f.LenderYield = float64Range()
+	f.BorrowerRate = float64Range()
+	f.EffectiveYield = float64Range()
+	f.EstimatedLossRate = float64Range()
+	f.FicoScore = strs("<600", "720-739", "820-850")
+	f.ProsperScore = int32Range()
+	f.BorrowerState = strs("CA", "NY", "TX")
+	f.EmploymentStatusDescription = strs("Employed", "Self-employed & other", "50% time")
+	f.MonthsEmployed = int32Range()
+	f.StatedMonthlyIncome = float64Range()
+	f.IsHomeowner = boolPtr()
+	f.IncomeVerifiable = boolPtr()
+	f.ListingCategoryID = ints()
+	f.PriorProsperLoans = int32Range()
+	f.PriorProsperLoansActive = int32Range()
+	return p
+}
+
+func TestSearchQueryRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, loc := range []*time.Location{DefaultLocation(), time.UTC} {
+		for i := 0; i < 500; i++ {
+			p := randomSearchParams(r, loc)
+			query := EncodeSearchQuery(p, loc)
+			got, err := ParseSearchQuery(query, loc)
+			if err != nil {
+				t.Fatalf("ParseSearchQuery(%q) failed: %v", query, err)
+			}
+			if !reflect.DeepEqual(got, p) {
+				t.Fatalf("ParseSearchQuery(EncodeSearchQuery(p)) = %+v, want %+v (query %q)", got, p, query)
+			}
+			if requery := EncodeSearchQuery(got, loc); requery != query {
+				t.Fatalf("EncodeSearchQuery(ParseSearchQuery(%q)) = %q", query, requery)
+			}
+		}
+	}
+}
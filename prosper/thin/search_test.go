@@ -377,6 +377,20 @@ func TestSearchSuccessfulResponse(t *testing.T) {
 				NowDelinquentDerog:                  0,
 				StatedMonthlyIncome:                 3000,
 				TotalInquiries:                      6,
+				Amounts: SearchResultAmounts{
+					AmountDelinquent:      "0",
+					AmountFunded:          "833.91",
+					AmountParticipation:   "0",
+					AmountRemaining:       "7166.09",
+					InstallmentBalance:    "0",
+					ListingAmount:         "8000",
+					ListingMonthlyPayment: "285.46",
+					MonthlyDebt:           "144",
+					RealEstateBalance:     "0",
+					RealEstatePayment:     "0",
+					RevolvingBalance:      "978",
+					StatedMonthlyIncome:   "3000",
+				},
 			},
 			{
 				PriorProsperLoans:                         0,
@@ -474,6 +488,20 @@ func TestSearchSuccessfulResponse(t *testing.T) {
 				NowDelinquentDerog:                  0,
 				StatedMonthlyIncome:                 5694.33,
 				TotalInquiries:                      3,
+				Amounts: SearchResultAmounts{
+					AmountDelinquent:      "0",
+					AmountFunded:          "1530",
+					AmountParticipation:   "0",
+					AmountRemaining:       "18470",
+					InstallmentBalance:    "0",
+					ListingAmount:         "20000",
+					ListingMonthlyPayment: "480.32",
+					MonthlyDebt:           "751",
+					RealEstateBalance:     "52177",
+					RealEstatePayment:     "476",
+					RevolvingBalance:      "34949",
+					StatedMonthlyIncome:   "5694.33",
+				},
 			},
 			{
 				PriorProsperLoans:                         0,
@@ -571,6 +599,20 @@ func TestSearchSuccessfulResponse(t *testing.T) {
 				NowDelinquentDerog:                  0,
 				StatedMonthlyIncome:                 2944.75,
 				TotalInquiries:                      7,
+				Amounts: SearchResultAmounts{
+					AmountDelinquent:      "0",
+					AmountFunded:          "7601.92",
+					AmountParticipation:   "0",
+					AmountRemaining:       "2398.08",
+					InstallmentBalance:    "29479",
+					ListingAmount:         "10000",
+					ListingMonthlyPayment: "404.56",
+					MonthlyDebt:           "1354",
+					RealEstateBalance:     "62896",
+					RealEstatePayment:     "857",
+					RevolvingBalance:      "13465",
+					StatedMonthlyIncome:   "2944.75",
+				},
 			},
 		},
 		ResultCount: 3,
@@ -0,0 +1,61 @@
+package prosper
+
+import (
+	"fmt"
+
+	"github.com/mtlynch/gofn-prosper/prosper/logging"
+)
+
+// unknownEnumText is the text representation of every Unknown enum variant.
+// The enums are integer types with no room for the raw value Prosper sent, so
+// parsers record that value in the parent object's UnknownEnumValues instead.
+const unknownEnumText = "Unknown"
+
+// UnknownEnumValue describes an enum value from the Prosper API that this
+// package does not recognize, such as a bid_result value Prosper added after
+// this package was written.
+type UnknownEnumValue struct {
+	// Field is the name of the Prosper attribute, such as "bid_result".
+	Field string
+	// Value is the raw value Prosper sent.
+	Value string
+}
+
+// UnknownEnumHandler is called for each unrecognized enum value that a client
+// accepts.
+type UnknownEnumHandler func(UnknownEnumValue)
+
+// unknownEnumPolicy controls how parsers handle enum values they do not
+// recognize. The zero value rejects unknown values.
+type unknownEnumPolicy struct {
+	accept    bool
+	onUnknown UnknownEnumHandler
+	logger    logging.Logger
+}
+
+// acceptUnknown returns true if the unrecognized value raw for the given field
+// should be accepted. If so, it records the raw value in values, logs a warning
+// and notifies the handler.
+func (p unknownEnumPolicy) acceptUnknown(field string, raw interface{}, values map[string]string) bool {
+	if !p.accept {
+		return false
+	}
+	value := fmt.Sprint(raw)
+	values[field] = value
+	logging.OrDiscard(p.logger).Log(logging.Warn, "accepted unknown enum value from Prosper API",
+		logging.F("field", field),
+		logging.F("value", value))
+	if p.onUnknown != nil {
+		p.onUnknown(UnknownEnumValue{Field: field, Value: value})
+	}
+	return true
+}
+
+// nilIfEmpty returns nil for an empty map so that parsed objects without
+// unknown values compare equal to ones that never recorded any.
+func nilIfEmpty(values map[string]string) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
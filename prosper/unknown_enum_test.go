@@ -0,0 +1,122 @@
+package prosper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mtlynch/gofn-prosper/prosper/thin"
+)
+
+func TestOrderParserUnknownEnums(t *testing.T) {
+	input := thin.OrderResponse{
+		OrderID: "90cf709d-81d6-416a-89f2-ba6ab8146ef2",
+		BidStatus: []thin.BidStatus{
+			{
+				BidRequest: thin.BidRequest{
+					ListingID: 2211270,
+					BidAmount: 100.0,
+				},
+				Status:          "INVESTED",
+				BidResult:       "BID_SUCCEEDED_WITH_FLAIR",
+				BidAmountPlaced: 100.0,
+			},
+		},
+		OrderStatus: "ARCHIVED",
+		OrderDate:   "2015-09-17 19:54:58 +0000",
+	}
+
+	if _, err := (defaultOrderParser{}).Parse(input); err == nil {
+		t.Errorf("expected unknown bid_result to fail when unknown enums are not accepted")
+	}
+
+	var reported []UnknownEnumValue
+	p := defaultOrderParser{
+		unknownEnums: unknownEnumPolicy{
+			accept: true,
+			onUnknown: func(v UnknownEnumValue) {
+				reported = append(reported, v)
+			},
+		},
+	}
+	got, err := p.Parse(input)
+	if err != nil {
+		t.Fatalf("expected unknown enums to be accepted, got error: %v", err)
+	}
+	if got.OrderStatus != OrderStatusUnknown {
+		t.Errorf("unexpected OrderStatus. got %v, want %v", got.OrderStatus, OrderStatusUnknown)
+	}
+	if want := map[string]string{"order_status": "ARCHIVED"}; !reflect.DeepEqual(got.UnknownEnumValues, want) {
+		t.Errorf("unexpected order UnknownEnumValues. got %v, want %v", got.UnknownEnumValues, want)
+	}
+	bid := got.BidStatus[0]
+	if bid.Result != BidResultUnknown || bid.Status != Invested {
+		t.Errorf("unexpected bid status. got %+v", bid)
+	}
+	if want := map[string]string{"bid_result": "BID_SUCCEEDED_WITH_FLAIR"}; !reflect.DeepEqual(bid.UnknownEnumValues, want) {
+		t.Errorf("unexpected bid UnknownEnumValues. got %v, want %v", bid.UnknownEnumValues, want)
+	}
+	wantReported := []UnknownEnumValue{
+		{Field: "bid_result", Value: "BID_SUCCEEDED_WITH_FLAIR"},
+		{Field: "order_status", Value: "ARCHIVED"},
+	}
+	if !reflect.DeepEqual(reported, wantReported) {
+		t.Errorf("unexpected reported values. got %v, want %v", reported, wantReported)
+	}
+}
+
+func TestNoteParserUnknownEnums(t *testing.T) {
+	input := thin.NoteResult{
+		LoanNoteID:        "7735-1",
+		OriginationDate:   "2014-02-22",
+		Rating:            "AAA",
+		NoteStatus:        42,
+		NoteDefaultReason: 8,
+	}
+	p := defaultNoteParser{unknownEnums: unknownEnumPolicy{accept: true}}
+	got, err := p.Parse(input)
+	if err != nil {
+		t.Fatalf("expected unknown enums to be accepted, got error: %v", err)
+	}
+	if got.Rating != RatingUnknown || got.NoteStatus != NoteStatusUnknown {
+		t.Errorf("unexpected enums. got Rating %v, NoteStatus %v", got.Rating, got.NoteStatus)
+	}
+	if got.NoteDefaultReason == nil || *got.NoteDefaultReason != DefaultReasonUnknown {
+		t.Errorf("unexpected NoteDefaultReason. got %v, want %v", got.NoteDefaultReason, DefaultReasonUnknown)
+	}
+	want := map[string]string{
+		"prosper_rating":      "AAA",
+		"note_status":         "42",
+		"note_default_reason": "8",
+	}
+	if !reflect.DeepEqual(got.UnknownEnumValues, want) {
+		t.Errorf("unexpected UnknownEnumValues. got %v, want %v", got.UnknownEnumValues, want)
+	}
+}
+
+func TestListingParserUnknownEnums(t *testing.T) {
+	input := thin.SearchResult{
+		ListingNumber:       4247229,
+		ListingStatus:       3,
+		IncomeRange:         3,
+		FicoScore:           "660-679",
+		Rating:              "C",
+		OldestTradeOpenDate: "03221991",
+		VerificationStage:   1,
+		InvestmentTypeid:    1,
+		ListingCategoryID:   99,
+	}
+	if _, err := (defaultListingParser{}).Parse(input); err == nil {
+		t.Errorf("expected unknown listing_category_id to fail when unknown enums are not accepted")
+	}
+	p := defaultListingParser{unknownEnums: unknownEnumPolicy{accept: true}}
+	got, err := p.Parse(input)
+	if err != nil {
+		t.Fatalf("expected unknown enums to be accepted, got error: %v", err)
+	}
+	if got.ListingCategoryID != ListingCategoryUnknown {
+		t.Errorf("unexpected ListingCategoryID. got %v, want %v", got.ListingCategoryID, ListingCategoryUnknown)
+	}
+	if want := map[string]string{"listing_category_id": "99"}; !reflect.DeepEqual(got.UnknownEnumValues, want) {
+		t.Errorf("unexpected UnknownEnumValues. got %v, want %v", got.UnknownEnumValues, want)
+	}
+}
@@ -0,0 +1,231 @@
+package prosper
+
+import (
+	"context"
+	"time"
+
+	"github.com/mtlynch/gofn-prosper/interval"
+	"github.com/mtlynch/gofn-prosper/prosper/thin"
+)
+
+const (
+	defaultWatchInterval        = time.Minute
+	defaultWatchReleaseInterval = 5 * time.Second
+	defaultWatchLookback        = time.Hour
+	defaultWatchMaxSeen         = 10000
+)
+
+type (
+	// ReleaseWindow is a daily period during which Prosper releases a burst of
+	// new listings.
+	ReleaseWindow struct {
+		// Start is the time of day at which the window opens, as an offset from
+		// midnight.
+		Start    time.Duration
+		Duration time.Duration
+	}
+
+	// WatchParams contains the parameters to NewListingWatcher.
+	WatchParams struct {
+		// Search selects the listings to watch. Each poll narrows
+		// Filter.ListingStartDate to listings that started recently. Polls
+		// cannot narrow the search to recently updated listings, because the
+		// Search API has no last_updated_date filter, so the watcher compares
+		// each listing's LastUpdatedDate with the version it delivered before.
+		Search SearchParams
+		// Interval is the time between polls outside of release windows.
+		// Defaults to one minute.
+		Interval time.Duration
+		// ReleaseWindows lists the times of day at which to poll every
+		// ReleaseInterval instead.
+		ReleaseWindows []ReleaseWindow
+		// ReleaseInterval defaults to five seconds.
+		ReleaseInterval time.Duration
+		// Location is the time zone of ReleaseWindows. Defaults to
+		// thin.DefaultLocation(), the time zone of Prosper's business dates.
+		Location *time.Location
+		// Lookback is how long before the newest listing start date seen so
+		// far each poll reaches, so that changes to recent listings are
+		// noticed. Defaults to one hour.
+		Lookback time.Duration
+		// MaxSeen bounds the number of listings the watcher remembers in order
+		// to skip listings it has already delivered. It should exceed the
+		// number of listings that start within Lookback. Defaults to 10,000.
+		MaxSeen int
+		// OnError, if set, receives the errors of failed polls and the parse
+		// errors of listings skipped in ParseLenient mode.
+		OnError func(error)
+	}
+
+	// ListingEvent is a listing delivered by a ListingWatcher. Changed is true
+	// if the watcher delivered an earlier version of the listing, and false if
+	// the listing is new.
+	ListingEvent struct {
+		Listing Listing
+		Changed bool
+	}
+
+	// ListingWatcher polls the Search API and delivers only the listings it
+	// has not delivered before, or that changed since it delivered them.
+	ListingWatcher struct {
+		searcher ListingSearcher
+		params   WatchParams
+		// seen maps the listings delivered so far to their last update time,
+		// and order lists them from oldest to newest delivery.
+		seen   map[ListingNumber]seenListing
+		order  []ListingNumber
+		newest time.Time
+		now    func() time.Time
+		after  func(time.Duration) <-chan time.Time
+	}
+
+	seenListing struct {
+		startDate   time.Time
+		lastUpdated time.Time
+	}
+)
+
+// NewListingWatcher creates a ListingWatcher that searches s for listings that
+// match p.Search.
+func NewListingWatcher(s ListingSearcher, p WatchParams) *ListingWatcher {
+	if p.Interval == 0 {
+		p.Interval = defaultWatchInterval
+	}
+	if p.ReleaseInterval == 0 {
+		p.ReleaseInterval = defaultWatchReleaseInterval
+	}
+	if p.Location == nil {
+		p.Location = thin.DefaultLocation()
+	}
+	if p.Lookback == 0 {
+		p.Lookback = defaultWatchLookback
+	}
+	if p.MaxSeen == 0 {
+		p.MaxSeen = defaultWatchMaxSeen
+	}
+	if p.Search.Limit == 0 {
+		p.Search.Limit = MaxSearchLimit
+	}
+	return &ListingWatcher{
+		searcher: s,
+		params:   p,
+		seen:     map[ListingNumber]seenListing{},
+		now:      time.Now,
+		after:    time.After,
+	}
+}
+
+// Watch polls immediately, then repeatedly on the watcher's schedule, and
+// delivers new and changed listings on the returned channel until ctx is
+// cancelled, at which point it closes the channel. A watcher must not be
+// watched more than once at a time.
+func (w *ListingWatcher) Watch(ctx context.Context) <-chan ListingEvent {
+	events := make(chan ListingEvent)
+	go func() {
+		defer close(events)
+		for {
+			updates, err := w.poll(ctx)
+			if err != nil && ctx.Err() == nil {
+				w.reportError(err)
+			}
+			for _, u := range updates {
+				select {
+				case events <- u:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-w.after(w.delay(w.now())):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
+func (w *ListingWatcher) reportError(err error) {
+	if w.params.OnError != nil {
+		w.params.OnError(err)
+	}
+}
+
+// poll searches for recently started listings and returns those that are new
+// or changed since the previous poll.
+func (w *ListingWatcher) poll(ctx context.Context) ([]ListingEvent, error) {
+	p := w.params.Search
+	if !w.newest.IsZero() {
+		since := w.newest.Add(-w.params.Lookback)
+		p.Filter.ListingStartDate = p.Filter.ListingStartDate.Intersect(interval.TimeRange{Min: &since})
+		w.forgetBefore(since)
+	}
+	var events []ListingEvent
+	it := AllListings(ctx, w.searcher, p)
+	for it.Next() {
+		if e, ok := w.observe(it.Listing()); ok {
+			events = append(events, e)
+		}
+	}
+	for _, err := range it.ParseErrors() {
+		w.reportError(err)
+	}
+	return events, it.Err()
+}
+
+// observe records l and reports whether it is new or changed.
+func (w *ListingWatcher) observe(l Listing) (ListingEvent, bool) {
+	if l.ListingStartDate.After(w.newest) {
+		w.newest = l.ListingStartDate
+	}
+	prev, ok := w.seen[l.ListingNumber]
+	if ok && !l.LastUpdatedDate.After(prev.lastUpdated) {
+		return ListingEvent{}, false
+	}
+	w.seen[l.ListingNumber] = seenListing{startDate: l.ListingStartDate, lastUpdated: l.LastUpdatedDate}
+	if !ok {
+		w.order = append(w.order, l.ListingNumber)
+		for len(w.order) > w.params.MaxSeen {
+			delete(w.seen, w.order[0])
+			w.order = w.order[1:]
+		}
+	}
+	return ListingEvent{Listing: l, Changed: ok}, true
+}
+
+// forgetBefore drops the listings that started before t, which later polls no
+// longer search for.
+func (w *ListingWatcher) forgetBefore(t time.Time) {
+	kept := w.order[:0]
+	for _, n := range w.order {
+		if w.seen[n].startDate.Before(t) {
+			delete(w.seen, n)
+			continue
+		}
+		kept = append(kept, n)
+	}
+	w.order = kept
+}
+
+// delay returns the time to wait after now before the next poll. It is
+// ReleaseInterval during a release window. Otherwise, it is Interval, shortened
+// so that polling speeds up as soon as the next release window opens.
+func (w *ListingWatcher) delay(now time.Time) time.Duration {
+	d := w.params.Interval
+	local := now.In(w.params.Location)
+	for _, window := range w.params.ReleaseWindows {
+		// Check yesterday's window too, in case it runs past midnight.
+		for day := -1; day <= 1; day++ {
+			midnight := time.Date(local.Year(), local.Month(), local.Day()+day, 0, 0, 0, 0, w.params.Location)
+			start := midnight.Add(window.Start)
+			end := start.Add(window.Duration)
+			switch {
+			case !now.Before(start) && now.Before(end):
+				return w.params.ReleaseInterval
+			case start.After(now) && start.Sub(now) < d:
+				d = start.Sub(now)
+			}
+		}
+	}
+	return d
+}
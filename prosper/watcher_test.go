@@ -0,0 +1,274 @@
+package prosper
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtlynch/gofn-prosper/prosper/thin"
+)
+
+// mockWatchSearcher serves the listings in listings that match the search's
+// ListingStartDate filter, failing while err is set.
+type mockWatchSearcher struct {
+	listings  []Listing
+	err       error
+	paramsGot []SearchParams
+	lock      sync.Mutex
+}
+
+func (s *mockWatchSearcher) Search(p SearchParams) (SearchResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.paramsGot = append(s.paramsGot, p)
+	if s.err != nil {
+		return SearchResponse{}, s.err
+	}
+	var matched []Listing
+	for _, l := range s.listings {
+		if p.Filter.ListingStartDate.Contains(l.ListingStartDate) {
+			matched = append(matched, l)
+		}
+	}
+	var results []Listing
+	for i := p.Offset; i < len(matched) && i < p.Offset+p.Limit; i++ {
+		results = append(results, matched[i])
+	}
+	return SearchResponse{Results: results, ResultCount: len(results), TotalCount: len(matched)}, nil
+}
+
+func (s *mockWatchSearcher) setListings(listings []Listing) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.listings = listings
+}
+
+var watchBaseTime = time.Date(2016, 2, 28, 9, 0, 0, 0, time.UTC)
+
+func watchListing(n int64, startedMinutesAgo int, updated time.Time) Listing {
+	return Listing{
+		ListingNumber:    ListingNumber(n),
+		ListingStartDate: watchBaseTime.Add(-time.Duration(startedMinutesAgo) * time.Minute),
+		LastUpdatedDate:  updated,
+	}
+}
+
+func listingNumbers(events []ListingEvent) []ListingNumber {
+	var numbers []ListingNumber
+	for _, e := range events {
+		numbers = append(numbers, e.Listing.ListingNumber)
+	}
+	return numbers
+}
+
+func TestListingWatcherPoll(t *testing.T) {
+	t0 := watchBaseTime
+	t1 := watchBaseTime.Add(time.Minute)
+	s := &mockWatchSearcher{listings: []Listing{
+		watchListing(1, 180, t0),
+		watchListing(2, 30, t0),
+		watchListing(3, 0, t0),
+	}}
+	w := NewListingWatcher(s, WatchParams{Lookback: time.Hour})
+
+	events, err := w.poll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := listingNumbers(events), []ListingNumber{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("first poll should deliver every listing. got: %v, want: %v", got, want)
+	}
+	if s.paramsGot[0].Filter.ListingStartDate.Min != nil {
+		t.Errorf("first poll should not narrow the start date, got: %v", s.paramsGot[0].Filter.ListingStartDate)
+	}
+	if s.paramsGot[0].Limit != MaxSearchLimit {
+		t.Errorf("unexpected search limit. got: %d, want: %d", s.paramsGot[0].Limit, MaxSearchLimit)
+	}
+
+	events, _ = w.poll(context.Background())
+	if len(events) != 0 {
+		t.Errorf("second poll should deliver nothing, got: %+v", events)
+	}
+	wantSince := watchBaseTime.Add(-time.Hour)
+	if got := s.paramsGot[len(s.paramsGot)-1].Filter.ListingStartDate.Min; got == nil || !got.Equal(wantSince) {
+		t.Errorf("second poll should search listings since %v, got: %v", wantSince, got)
+	}
+	if _, ok := w.seen[1]; ok {
+		t.Errorf("listing before the search window should be forgotten")
+	}
+
+	s.setListings([]Listing{
+		watchListing(2, 30, t1),
+		watchListing(3, 0, t0),
+		watchListing(4, -5, t0),
+	})
+	events, _ = w.poll(context.Background())
+	want := []ListingEvent{
+		{Listing: watchListing(2, 30, t1), Changed: true},
+		{Listing: watchListing(4, -5, t0), Changed: false},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("unexpected events for changed and new listings. got: %+v, want: %+v", events, want)
+	}
+}
+
+func TestListingWatcherKeepsSearchFilter(t *testing.T) {
+	floor := watchBaseTime.Add(-10 * time.Minute)
+	s := &mockWatchSearcher{listings: []Listing{watchListing(1, 30, watchBaseTime), watchListing(2, 0, watchBaseTime)}}
+	p := WatchParams{}
+	p.Search.Filter.ListingStartDate.Min = &floor
+	p.Search.Filter.Rating = []Rating{RatingA}
+	w := NewListingWatcher(s, p)
+	w.poll(context.Background())
+	w.poll(context.Background())
+	got := s.paramsGot[len(s.paramsGot)-1].Filter
+	if got.ListingStartDate.Min == nil || !got.ListingStartDate.Min.Equal(floor) {
+		t.Errorf("narrowed start date should not precede the caller's filter, got: %v", got.ListingStartDate)
+	}
+	if !reflect.DeepEqual(got.Rating, []Rating{RatingA}) {
+		t.Errorf("caller's filter should be kept, got: %+v", got)
+	}
+}
+
+func TestListingWatcherMaxSeen(t *testing.T) {
+	s := &mockWatchSearcher{listings: []Listing{
+		watchListing(1, 3, watchBaseTime),
+		watchListing(2, 2, watchBaseTime),
+		watchListing(3, 1, watchBaseTime),
+	}}
+	w := NewListingWatcher(s, WatchParams{MaxSeen: 2})
+	w.poll(context.Background())
+	if len(w.seen) != 2 || len(w.order) != 2 {
+		t.Errorf("watcher should remember at most 2 listings, got: %v", w.order)
+	}
+	s.setListings([]Listing{watchListing(1, 3, watchBaseTime), watchListing(3, 1, watchBaseTime)})
+	events, _ := w.poll(context.Background())
+	if got, want := listingNumbers(events), []ListingNumber{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("forgotten listing should be delivered again. got: %v, want: %v", got, want)
+	}
+	if !reflect.DeepEqual(w.order, []ListingNumber{3, 1}) {
+		t.Errorf("watcher should forget the oldest listing first, got: %v", w.order)
+	}
+}
+
+func TestNewListingWatcherDefaultLocation(t *testing.T) {
+	w := NewListingWatcher(&mockWatchSearcher{}, WatchParams{})
+	if w.params.Location != thin.DefaultLocation() {
+		t.Errorf("release windows should default to Prosper's time zone, got: %v", w.params.Location)
+	}
+}
+
+func TestListingWatcherDelay(t *testing.T) {
+	pacific, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	w := NewListingWatcher(&mockWatchSearcher{}, WatchParams{
+		Interval:        time.Minute,
+		ReleaseInterval: 5 * time.Second,
+		Location:        pacific,
+		ReleaseWindows: []ReleaseWindow{
+			{Start: 9 * time.Hour, Duration: 10 * time.Minute},
+			{Start: 23*time.Hour + 55*time.Minute, Duration: 10 * time.Minute},
+		},
+	})
+	var tests = []struct {
+		now  time.Time
+		want time.Duration
+		msg  string
+	}{
+		{
+			now:  time.Date(2016, 2, 28, 8, 0, 0, 0, pacific),
+			want: time.Minute,
+			msg:  "outside a window should use the regular interval",
+		},
+		{
+			now:  time.Date(2016, 2, 28, 8, 59, 20, 0, pacific),
+			want: 40 * time.Second,
+			msg:  "poll should be scheduled when the window opens",
+		},
+		{
+			now:  time.Date(2016, 2, 28, 9, 0, 0, 0, pacific),
+			want: 5 * time.Second,
+			msg:  "window start should use the release interval",
+		},
+		{
+			now:  time.Date(2016, 2, 28, 9, 9, 59, 0, pacific),
+			want: 5 * time.Second,
+			msg:  "inside a window should use the release interval",
+		},
+		{
+			now:  time.Date(2016, 2, 28, 9, 10, 0, 0, pacific),
+			want: time.Minute,
+			msg:  "window end should use the regular interval",
+		},
+		{
+			now:  time.Date(2016, 2, 29, 0, 3, 0, 0, pacific),
+			want: 5 * time.Second,
+			msg:  "window that runs past midnight should use the release interval",
+		},
+		{
+			now:  time.Date(2016, 2, 28, 17, 0, 0, 0, time.UTC),
+			want: 5 * time.Second,
+			msg:  "windows should be interpreted in the watcher's location",
+		},
+	}
+	for _, tt := range tests {
+		if got := w.delay(tt.now); got != tt.want {
+			t.Errorf("%s: unexpected delay. got: %v, want: %v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestListingWatcherWatch(t *testing.T) {
+	s := &mockWatchSearcher{listings: []Listing{watchListing(1, 1, watchBaseTime)}}
+	errs := make(chan error, 1)
+	w := NewListingWatcher(s, WatchParams{
+		Interval: time.Minute,
+		OnError:  func(err error) { errs <- err },
+	})
+	w.now = func() time.Time { return watchBaseTime }
+	ticks := make(chan time.Time)
+	delays := make(chan time.Duration, 10)
+	w.after = func(d time.Duration) <-chan time.Time {
+		delays <- d
+		return ticks
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := w.Watch(ctx)
+	if e := <-events; e.Listing.ListingNumber != 1 || e.Changed {
+		t.Errorf("unexpected first event: %+v", e)
+	}
+
+	s.lock.Lock()
+	s.err = errors.New("mock search error")
+	s.lock.Unlock()
+	ticks <- watchBaseTime
+	if err := <-errs; err.Error() != "mock search error" {
+		t.Errorf("unexpected error reported: %v", err)
+	}
+
+	s.lock.Lock()
+	s.err = nil
+	s.lock.Unlock()
+	s.setListings([]Listing{watchListing(1, 1, watchBaseTime), watchListing(2, 0, watchBaseTime)})
+	ticks <- watchBaseTime
+	if e := <-events; e.Listing.ListingNumber != 2 || e.Changed {
+		t.Errorf("unexpected event after recovering from an error: %+v", e)
+	}
+
+	cancel()
+	if _, ok := <-events; ok {
+		t.Errorf("events channel should be closed after cancellation")
+	}
+	close(delays)
+	for d := range delays {
+		if d != time.Minute {
+			t.Errorf("unexpected delay between polls: %v", d)
+		}
+	}
+}